@@ -5,7 +5,9 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 
+	"github.com/mp1947/ya-url-shortener/config"
 	"github.com/mp1947/ya-url-shortener/internal/shortener"
 )
 
@@ -17,11 +19,28 @@ var (
 
 func main() {
 
+	cfg, err := config.InitConfig()
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	if *cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatalf("error printing configuration: %v", err)
+		}
+		return
+	}
+
+	if err := cfg.ValidateSecretKey(); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	sh, err := shortener.InitShortener(
 		ctx,
+		cfg,
 		buildVersion,
 		buildDate,
 		buildCommit,
@@ -0,0 +1,35 @@
+// Package main is shortenerctl, the operator tool of the URL shortener. It runs database migrations,
+// exports and imports links, manages admin API keys, mints tokens, compacts the event log and prints
+// statistics, using the configuration of the server.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/ctl"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+)
+
+func main() {
+
+	log.SetFlags(0)
+
+	cfg, err := config.InitConfig()
+	if err != nil {
+		fmt.Fprint(os.Stderr, ctl.Usage)
+		log.Fatalf("invalid configuration: %v", err)
+	}
+
+	if err := ctl.Run(context.Background(), cfg, os.Stdin, os.Stdout); err != nil {
+		if errors.Is(err, shrterr.ErrUnknownCommand) {
+			fmt.Fprint(os.Stderr, ctl.Usage)
+		}
+		log.Fatalf("error: %v", err)
+	}
+
+}
@@ -0,0 +1,24 @@
+package config
+
+import "slices"
+
+// Diff returns the sorted keys of the settings whose effective values differ between a and b.
+// Secrets are compared unmasked, so that rotating a secret is reported as a change.
+func Diff(a, b *Config) []string {
+	before, after := a.settings(), b.settings()
+
+	var changed []string
+	for key, value := range after {
+		if previous, ok := before[key]; !ok || previous != value {
+			changed = append(changed, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+
+	slices.Sort(changed)
+	return changed
+}
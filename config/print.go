@@ -0,0 +1,142 @@
+package config
+
+import (
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maskedValue replaces secrets in the printed configuration.
+const maskedValue = "*****"
+
+// dsnPassword matches the password of a key/value database connection string.
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('[^']*'|\S+)`)
+
+// Masked returns the effective settings keyed by setting key, as they would be written in a
+// configuration file, with the database, replica and Redis passwords, the OIDC client secret, the admin API keys
+// and the secret key masked.
+func (c *Config) Masked() map[string]any {
+	settings := c.settings()
+	settings["DATABASE_DSN"] = maskDSN(*c.DatabaseDSN)
+	settings["DATABASE_REPLICA_DSNS"] = maskDSNs(c.ReplicaDSNs)
+	settings["OIDC_CLIENT_SECRET"] = maskSecret(c.OIDC.ClientSecret)
+	settings["ADMIN_API_KEYS"] = maskList(c.AdminAPIKeys)
+	settings["REDIS_URL"] = maskDSN(c.Redis.URL)
+	settings["SECRET_KEY"] = maskSecret(*c.SecretKey)
+	return settings
+}
+
+// settings returns the effective settings keyed by setting key, secrets included.
+func (c *Config) settings() map[string]any {
+	settings := map[string]any{
+		"SERVER_ADDRESS":          *c.HTTPServerAddress,
+		"BASE_URL":                *c.BaseHTTPURL,
+		"FILE_STORAGE_PATH":       *c.FileStoragePath,
+		"AUDIT_LOG_PATH":          *c.AuditLogPath,
+		"DATABASE_DSN":            *c.DatabaseDSN,
+		"DATABASE_AUTO_MIGRATE":   *c.AutoMigrate,
+		"DATABASE_REPLICA_DSNS":   strings.Join(c.ReplicaDSNs, ","),
+		"READ_YOUR_WRITES_WINDOW": c.ReadYourWrites.String(),
+		"ENABLE_HTTPS":            *c.ShouldUseTLS,
+		"ENABLE_GRPC":             *c.GRPCEnabled,
+		"SINGLE_PORT":             *c.SinglePort,
+		"ENABLE_GATEWAY":          *c.GatewayEnabled,
+		"ENABLE_GRPC_REFLECTION":  *c.ReflectionEnabled,
+		"TRUSTED_SUBNET":          *c.TrustedSubnetRaw,
+		"TRUSTED_PROXIES":         *c.TrustedProxiesRaw,
+		"INTERNAL_CLIENT_CA_FILE": *c.InternalClientCA,
+		"TLS_CLIENT_CA_FILE":      *c.TLSClientCA,
+		"CERT_RELOAD_INTERVAL":    c.CertReloadInterval.String(),
+		"GRPC_PORT":               *c.GRPCServerAddress,
+		"BASE_GRPC_URL":           *c.BaseGRPCURL,
+		"OIDC_ISSUER_URL":         c.OIDC.IssuerURL,
+		"OIDC_CLIENT_ID":          c.OIDC.ClientID,
+		"OIDC_CLIENT_SECRET":      c.OIDC.ClientSecret,
+		"OIDC_REDIRECT_URL":       c.OIDC.RedirectURL,
+		"ADMIN_API_KEYS":          strings.Join(c.AdminAPIKeys, ","),
+		"SECRET_KEY":              *c.SecretKey,
+		"TRACING_EXPORTER":        c.Tracing.Exporter,
+		"TRACING_OTLP_ENDPOINT":   c.Tracing.OTLPEndpoint,
+		"TRACING_OTLP_INSECURE":   c.Tracing.OTLPInsecure,
+		"TRACING_SAMPLE_RATIO":    c.Tracing.SampleRatio,
+		"LOG_LEVEL":               c.Logging.Level,
+		"LOG_FORMAT":              c.Logging.Format,
+		"LOG_OUTPUT_PATHS":        c.Logging.OutputPaths,
+		"LOG_MAX_SIZE_MB":         c.Logging.MaxSizeMB,
+		"LOG_MAX_BACKUPS":         c.Logging.MaxBackups,
+		"LOG_MAX_AGE_DAYS":        c.Logging.MaxAgeDays,
+		"LOG_COMPRESS":            c.Logging.Compress,
+		"LOG_SAMPLING_INITIAL":    c.Logging.SamplingInitial,
+		"LOG_SAMPLING_THEREAFTER": c.Logging.SamplingThereafter,
+		"LOG_REDACT_PII":          c.Logging.RedactPII,
+		"CACHE_SIZE":              c.Cache.Size,
+		"CACHE_POLICY":            c.Cache.Policy,
+		"CACHE_TTL":               c.Cache.TTL.String(),
+		"CACHE_NEGATIVE_TTL":      c.Cache.NegativeTTL.String(),
+		"REDIS_URL":               c.Redis.URL,
+		"REDIS_KEY_PREFIX":        c.Redis.KeyPrefix,
+		"RATE_LIMIT_RPS":          c.RateLimit.RPS,
+		"RATE_LIMIT_BURST":        c.RateLimit.Burst,
+		"DELETION_JOB_TTL":        c.DeletionJobTTL.String(),
+		"ERROR_PAGES_DIR":         *c.ErrorPagesDir,
+	}
+
+	if c.TLSConfig != nil {
+		settings["TLS_CRT_FILE"] = c.TLSConfig.CrtFilePath
+		settings["TLS_KEY_FILE"] = c.TLSConfig.KeyFilePath
+	}
+
+	return settings
+}
+
+// Print writes the masked effective configuration to w as YAML with lower-cased keys,
+// which can be used as a configuration file.
+func (c *Config) Print(w io.Writer) error {
+	settings := make(map[string]any)
+	for key, value := range c.Masked() {
+		settings[strings.ToLower(key)] = value
+	}
+
+	encoder := yaml.NewEncoder(w)
+	if err := encoder.Encode(settings); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// maskSecret masks a non-empty secret.
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return maskedValue
+}
+
+// maskList masks every element of a list of secrets, keeping their number visible.
+func maskList(secrets []string) string {
+	masked := make([]string, len(secrets))
+	for i := range secrets {
+		masked[i] = maskedValue
+	}
+	return strings.Join(masked, ",")
+}
+
+// maskDSNs masks the password of every database connection string of a list, joined with commas.
+func maskDSNs(dsns []string) string {
+	masked := make([]string, len(dsns))
+	for i, dsn := range dsns {
+		masked[i] = maskDSN(dsn)
+	}
+	return strings.Join(masked, ",")
+}
+
+// maskDSN masks the password of a database connection string in URL or key/value form.
+func maskDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		return u.Redacted()
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+maskedValue)
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Supported values of the TRACING_EXPORTER, LOG_FORMAT and CACHE_POLICY settings, mirrored from the packages using them.
+var (
+	tracingExporters = []string{"none", "stdout", "otlp"}
+	logFormats       = []string{"json", "console"}
+	cachePolicies    = []string{"lru", "lfu"}
+)
+
+// minSecretKeyLength is the minimum length in bytes of SECRET_KEY, mirrored from auth.MinSecretKeyLength.
+const minSecretKeyLength = 32
+
+// hashedAPIKey matches an admin API key given as its SHA-256 hash, as written by the apikey package.
+var hashedAPIKey = regexp.MustCompile(`^sha256:[0-9a-fA-F]{64}$`)
+
+// Validate checks every setting of the configuration, and the settings which require another one to be
+// enabled, and returns an error joining one error per invalid setting, each naming the setting key, or nil
+// when the configuration is valid.
+func (c *Config) Validate() error {
+	var errs []error
+
+	check := func(key string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+
+	check("SERVER_ADDRESS", validateAddress(*c.HTTPServerAddress))
+	check("GRPC_PORT", validateAddress(*c.GRPCServerAddress))
+	check("BASE_URL", validateHTTPURL(*c.BaseHTTPURL))
+	// In single port mode the gRPC base URL is BASE_URL, already validated.
+	if !*c.SinglePort {
+		check("BASE_GRPC_URL", validateAddress(*c.BaseGRPCURL))
+	}
+
+	_, err := parseCIDRs(*c.TrustedSubnetRaw)
+	check("TRUSTED_SUBNET", err)
+	_, err = parseCIDRs(*c.TrustedProxiesRaw)
+	check("TRUSTED_PROXIES", err)
+
+	if *c.CertReloadInterval <= 0 {
+		check("CERT_RELOAD_INTERVAL", errors.New("must be positive"))
+	}
+
+	if len(c.ReplicaDSNs) > 0 && *c.DatabaseDSN == "" {
+		check("DATABASE_REPLICA_DSNS", errors.New("requires DATABASE_DSN to be set"))
+	}
+	if *c.ReadYourWrites < 0 {
+		check("READ_YOUR_WRITES_WINDOW", errors.New("must not be negative"))
+	}
+
+	if *c.ShouldUseTLS && c.TLSConfig != nil {
+		if c.TLSConfig.CrtFilePath == "" {
+			check("TLS_CRT_FILE", errors.New("must be set when https is enabled"))
+		}
+		if c.TLSConfig.KeyFilePath == "" {
+			check("TLS_KEY_FILE", errors.New("must be set when https is enabled"))
+		}
+	}
+
+	// Settings depending on another one would otherwise be silently ignored.
+	if *c.SinglePort && !*c.GRPCEnabled {
+		check("SINGLE_PORT", errors.New("requires ENABLE_GRPC, it serves grpc on the http listen address"))
+	}
+	if *c.TLSClientCA != "" && !*c.ShouldUseTLS {
+		check("TLS_CLIENT_CA_FILE", errors.New("requires ENABLE_HTTPS, client certificates are only requested over tls"))
+	}
+	// Calls guarded by the internal client CA must present a certificate over the TLS connection of the
+	// gRPC listener, without one every guarded call would be rejected.
+	if *c.InternalClientCA != "" && !*c.ShouldUseTLS {
+		check("INTERNAL_CLIENT_CA_FILE", errors.New("requires ENABLE_HTTPS, client certificates are only requested over tls"))
+	}
+	if *c.InternalClientCA != "" && !*c.GRPCEnabled {
+		check("INTERNAL_CLIENT_CA_FILE", errors.New("requires ENABLE_GRPC, client certificates are only checked by the grpc listener"))
+	}
+
+	if c.OIDC.Enabled() {
+		check("OIDC_ISSUER_URL", validateHTTPURL(c.OIDC.IssuerURL))
+		if c.OIDC.ClientID == "" {
+			check("OIDC_CLIENT_ID", errors.New("must be set when OIDC_ISSUER_URL is set"))
+		}
+		check("OIDC_REDIRECT_URL", validateHTTPURL(c.OIDC.RedirectURL))
+	}
+
+	for _, key := range c.AdminAPIKeys {
+		if strings.HasPrefix(key, "sha256:") && !hashedAPIKey.MatchString(key) {
+			check("ADMIN_API_KEYS", errors.New("hashed keys must be sha256: followed by 64 hex characters"))
+		}
+	}
+
+	check("TRACING_EXPORTER", validateOneOf(c.Tracing.Exporter, tracingExporters))
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		check("TRACING_SAMPLE_RATIO", fmt.Errorf("must be between 0 and 1, got %v", c.Tracing.SampleRatio))
+	}
+
+	_, err = zapcore.ParseLevel(c.Logging.Level)
+	check("LOG_LEVEL", err)
+	check("LOG_FORMAT", validateOneOf(c.Logging.Format, logFormats))
+	check("LOG_MAX_SIZE_MB", validateNonNegative(c.Logging.MaxSizeMB))
+	check("LOG_MAX_BACKUPS", validateNonNegative(c.Logging.MaxBackups))
+	check("LOG_MAX_AGE_DAYS", validateNonNegative(c.Logging.MaxAgeDays))
+	check("LOG_SAMPLING_INITIAL", validateNonNegative(c.Logging.SamplingInitial))
+	check("LOG_SAMPLING_THEREAFTER", validateNonNegative(c.Logging.SamplingThereafter))
+
+	check("CACHE_SIZE", validateNonNegative(c.Cache.Size))
+	if c.Cache.Enabled() {
+		check("CACHE_POLICY", validateOneOf(c.Cache.Policy, cachePolicies))
+		if c.Cache.TTL <= 0 {
+			check("CACHE_TTL", errors.New("must be positive when the cache is enabled"))
+		}
+		if c.Cache.NegativeTTL < 0 {
+			check("CACHE_NEGATIVE_TTL", errors.New("must not be negative"))
+		}
+	}
+
+	if c.Redis.Enabled() {
+		check("REDIS_URL", validateRedisURL(c.Redis.URL))
+	}
+
+	if c.RateLimit.RPS < 0 {
+		check("RATE_LIMIT_RPS", fmt.Errorf("must not be negative, got %v", c.RateLimit.RPS))
+	}
+	if c.RateLimit.RPS > 0 && c.RateLimit.Burst < 1 {
+		check("RATE_LIMIT_BURST", errors.New("must be at least 1 when rate limiting is enabled"))
+	}
+
+	if *c.DeletionJobTTL <= 0 {
+		check("DELETION_JOB_TTL", errors.New("must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateSecretKey checks SECRET_KEY, the key signing the user tokens. It is not part of Validate since
+// only the server and the shortenerctl token command, which checks it when setting the key, sign or verify
+// tokens: the other commands run without it.
+func (c *Config) ValidateSecretKey() error {
+	if *c.SecretKey == "" {
+		return errors.New("SECRET_KEY: must be set, it signs the user tokens")
+	}
+	if len(*c.SecretKey) < minSecretKeyLength {
+		return fmt.Errorf("SECRET_KEY: must be at least %d bytes long, got %d", minSecretKeyLength, len(*c.SecretKey))
+	}
+	return nil
+}
+
+// validateAddress checks that address is a host:port pair, the host may be empty.
+func validateAddress(address string) error {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateHTTPURL checks that rawURL is an absolute http or https URL.
+func validateHTTPURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("must be an absolute http or https url, got %q", rawURL)
+	}
+	return nil
+}
+
+// validateRedisURL checks that rawURL is a redis, rediss or unix URL.
+func validateRedisURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" && u.Scheme != "unix" {
+		return fmt.Errorf("must be a redis, rediss or unix url, got scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+// validateOneOf checks that value is one of allowed.
+func validateOneOf(value string, allowed []string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+// validateNonNegative checks that value is not negative.
+func validateNonNegative(value int) error {
+	if value < 0 {
+		return fmt.Errorf("must not be negative, got %d", value)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Package apikey provides API key lookup used to authenticate operator tooling.
+// Keys are never kept in plain text: they are identified by the SHA-256 hash of the raw value.
+// The configuration may hold either raw keys or their hashes, written as HashPrefix followed
+// by the hex-encoded hash, so that the raw keys need not be stored anywhere.
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+)
+
+const (
+	// keyIDLength is the number of hash characters used as the public key identifier.
+	keyIDLength = 12
+	// keySize is the number of random bytes of a generated key.
+	keySize = 32
+
+	// HashPrefix marks a configured key given as the hash of the raw key.
+	HashPrefix = "sha256:"
+)
+
+// Key describes an API key and the role it grants.
+// ID is a short public identifier derived from the key hash and safe to log.
+type Key struct {
+	ID   string
+	Hash string
+	Role string
+}
+
+// Store looks up API keys by their raw value.
+type Store interface {
+	Lookup(ctx context.Context, rawKey string) (Key, error)
+}
+
+// StaticStore is a Store holding keys passed through the configuration, which are only
+// changed by replacing all of them. Every key in a StaticStore grants the admin role.
+type StaticStore struct {
+	keys atomic.Pointer[map[string]Key]
+}
+
+// NewStaticStore creates a StaticStore from configured API keys, raw or hashed. Empty values are ignored.
+func NewStaticStore(entries []string) *StaticStore {
+	s := &StaticStore{}
+	s.Replace(entries)
+	return s
+}
+
+// Replace atomically replaces every key of the store with the given configured API keys, raw or hashed.
+// Empty values are ignored.
+func (s *StaticStore) Replace(entries []string) {
+	keys := make(map[string]Key, len(entries))
+
+	for _, entry := range entries {
+		if entry == "" {
+			continue
+		}
+		hash := entryHash(entry)
+		keys[hash] = Key{
+			ID:   hash[:keyIDLength],
+			Hash: hash,
+			Role: auth.RoleAdmin,
+		}
+	}
+
+	s.keys.Store(&keys)
+}
+
+// Lookup returns the key matching rawKey or shrterr.ErrAPIKeyNotFound.
+func (s *StaticStore) Lookup(ctx context.Context, rawKey string) (Key, error) {
+	key, ok := (*s.keys.Load())[Hash(rawKey)]
+	if !ok {
+		return Key{}, shrterr.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+// Hash returns the hex-encoded SHA-256 hash of a raw API key.
+func Hash(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// Generate returns a new random raw API key.
+func Generate() (string, error) {
+	b := make([]byte, keySize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Entry returns the hashed configuration entry of a raw API key, which grants the same access.
+func Entry(rawKey string) string {
+	return HashPrefix + Hash(rawKey)
+}
+
+// ID returns the public identifier of a configured API key, raw or hashed.
+func ID(entry string) string {
+	return entryHash(entry)[:keyIDLength]
+}
+
+// entryHash returns the hash of a configured API key, raw or hashed.
+// Entries with HashPrefix which do not hold a valid hash are taken as raw keys.
+func entryHash(entry string) string {
+	if hash, ok := strings.CutPrefix(entry, HashPrefix); ok {
+		if b, err := hex.DecodeString(hash); err == nil && len(b) == sha256.Size {
+			return strings.ToLower(hash)
+		}
+	}
+	return Hash(entry)
+}
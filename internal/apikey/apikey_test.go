@@ -0,0 +1,65 @@
+package apikey_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/internal/apikey"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticStore(t *testing.T) {
+	store := apikey.NewStaticStore([]string{"first-key", "", "second-key"})
+
+	t.Run("lookup known key", func(t *testing.T) {
+		key, err := store.Lookup(context.Background(), "second-key")
+		assert.NoError(t, err)
+		assert.Equal(t, auth.RoleAdmin, key.Role)
+		assert.Equal(t, apikey.Hash("second-key"), key.Hash)
+		assert.NotContains(t, key.ID, "second-key")
+	})
+
+	t.Run("lookup unknown key", func(t *testing.T) {
+		_, err := store.Lookup(context.Background(), "third-key")
+		assert.ErrorIs(t, err, shrterr.ErrAPIKeyNotFound)
+	})
+
+	t.Run("lookup empty key", func(t *testing.T) {
+		_, err := store.Lookup(context.Background(), "")
+		assert.ErrorIs(t, err, shrterr.ErrAPIKeyNotFound)
+	})
+}
+
+func TestStaticStoreReplace(t *testing.T) {
+	store := apikey.NewStaticStore([]string{"old-key"})
+
+	store.Replace([]string{"new-key"})
+
+	_, err := store.Lookup(context.Background(), "old-key")
+	assert.ErrorIs(t, err, shrterr.ErrAPIKeyNotFound)
+
+	_, err = store.Lookup(context.Background(), "new-key")
+	assert.NoError(t, err)
+}
+
+func TestHashedEntries(t *testing.T) {
+	raw, err := apikey.Generate()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, raw)
+
+	entry := apikey.Entry(raw)
+	assert.Equal(t, apikey.HashPrefix+apikey.Hash(raw), entry)
+	assert.NotContains(t, entry, raw)
+	assert.Equal(t, apikey.ID(raw), apikey.ID(entry))
+
+	store := apikey.NewStaticStore([]string{entry})
+
+	key, err := store.Lookup(context.Background(), raw)
+	assert.NoError(t, err)
+	assert.Equal(t, apikey.ID(entry), key.ID)
+
+	_, err = store.Lookup(context.Background(), entry)
+	assert.ErrorIs(t, err, shrterr.ErrAPIKeyNotFound, "the hashed entry itself is not a valid key")
+}
@@ -0,0 +1,97 @@
+// Package audit records actions performed on shortened URLs together with the actor that
+// performed them, so user and operator activity can be reviewed later.
+package audit
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+const (
+	// ResultSuccess marks an action that completed without an error.
+	ResultSuccess = "success"
+	// ResultFailure marks an action that returned an error.
+	ResultFailure = "failure"
+)
+
+// Operations recorded for the public API.
+const (
+	OpCreateURL      = "url.create"
+	OpCreateURLBatch = "url.create_batch"
+	OpDeleteURLs     = "url.delete"
+	OpLinkUserURLs   = "user.link_urls"
+)
+
+// Operations recorded for the administrative API.
+const (
+	OpAdminGetURL      = "admin.get_url"
+	OpAdminGetUserURLs = "admin.get_user_urls"
+	OpAdminDisableURLs = "admin.disable_urls"
+	OpAdminEnableURLs  = "admin.enable_urls"
+	OpAdminDeleteURLs  = "admin.delete_urls"
+	OpAdminReassign    = "admin.reassign_urls"
+)
+
+// defaultQueryLimit is the number of entries returned by a query without an explicit limit.
+const defaultQueryLimit = 100
+
+// Entry describes a single recorded action.
+type Entry struct {
+	Time       time.Time `json:"time"`
+	ActorID    string    `json:"actor_id"`
+	AuthMethod string    `json:"auth_method"`
+	ClientIP   string    `json:"client_ip,omitempty"`
+	Operation  string    `json:"operation"`
+	ShortURLs  []string  `json:"short_urls,omitempty"`
+	TargetUser string    `json:"target_user_id,omitempty"`
+	Result     string    `json:"result"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Filter narrows down an audit query. Zero values are ignored.
+// UserID matches both the actor and the target user of an entry.
+// From is inclusive and To is exclusive.
+type Filter struct {
+	UserID   string
+	ShortURL string
+	From     time.Time
+	To       time.Time
+	Limit    int
+}
+
+// Match reports whether the entry satisfies the filter. Limit is not taken into account.
+func (f Filter) Match(e Entry) bool {
+	if f.UserID != "" && e.ActorID != f.UserID && e.TargetUser != f.UserID {
+		return false
+	}
+	if f.ShortURL != "" && !slices.Contains(e.ShortURLs, f.ShortURL) {
+		return false
+	}
+	if !f.From.IsZero() && e.Time.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !e.Time.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+// EffectiveLimit returns the maximum number of entries a query with this filter returns.
+func (f Filter) EffectiveLimit() int {
+	if f.Limit <= 0 {
+		return defaultQueryLimit
+	}
+	return f.Limit
+}
+
+// Recorder persists audit entries.
+type Recorder interface {
+	Record(ctx context.Context, e Entry) error
+}
+
+// Store persists audit entries and looks them up. Query returns the newest entries first.
+type Store interface {
+	Recorder
+	Query(ctx context.Context, f Filter) ([]Entry, error)
+}
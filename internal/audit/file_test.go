@@ -0,0 +1,81 @@
+package audit_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	store, err := audit.NewFileStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entries := []audit.Entry{
+		{Time: start, ActorID: "user-1", Operation: audit.OpCreateURL, ShortURLs: []string{"aaa"}},
+		{Time: start.Add(time.Hour), ActorID: "user-2", Operation: audit.OpCreateURL, ShortURLs: []string{"bbb"}},
+		{Time: start.Add(2 * time.Hour), ActorID: "user-1", Operation: audit.OpDeleteURLs, ShortURLs: []string{"aaa", "bbb"}},
+		{Time: start.Add(3 * time.Hour), ActorID: "apikey:1", Operation: audit.OpAdminReassign, ShortURLs: []string{"bbb"}, TargetUser: "user-1"},
+	}
+	for _, e := range entries {
+		require.NoError(t, store.Record(ctx, e))
+	}
+
+	operations := func(entries []audit.Entry) []string {
+		ops := make([]string, len(entries))
+		for i, e := range entries {
+			ops[i] = e.Operation
+		}
+		return ops
+	}
+
+	tests := []struct {
+		name   string
+		filter audit.Filter
+		want   []string
+	}{
+		{
+			name:   "all entries newest first",
+			filter: audit.Filter{},
+			want:   []string{audit.OpAdminReassign, audit.OpDeleteURLs, audit.OpCreateURL, audit.OpCreateURL},
+		},
+		{
+			name:   "by actor or target user",
+			filter: audit.Filter{UserID: "user-1"},
+			want:   []string{audit.OpAdminReassign, audit.OpDeleteURLs, audit.OpCreateURL},
+		},
+		{
+			name:   "by short url",
+			filter: audit.Filter{ShortURL: "aaa"},
+			want:   []string{audit.OpDeleteURLs, audit.OpCreateURL},
+		},
+		{
+			name:   "by time range",
+			filter: audit.Filter{From: start.Add(time.Hour), To: start.Add(3 * time.Hour)},
+			want:   []string{audit.OpDeleteURLs, audit.OpCreateURL},
+		},
+		{
+			name:   "with limit",
+			filter: audit.Filter{Limit: 1},
+			want:   []string{audit.OpAdminReassign},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Query(ctx, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, operations(got))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package auth
+
+import "context"
+
+type actorCtxKey struct{}
+
+// Actor describes who performs a request: the user ID (or API key ID), the authentication
+// method used, the role granted to the caller and the IP address the request came from.
+type Actor struct {
+	ID       string
+	Method   string
+	Role     string
+	ClientIP string
+}
+
+// IsAdmin reports whether the actor holds the admin role.
+func (a Actor) IsAdmin() bool {
+	return a.Role == RoleAdmin
+}
+
+// WithActor returns a copy of ctx carrying the given actor.
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorCtxKey{}, a)
+}
+
+// ActorFromContext returns the actor stored in ctx and whether one was found.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	a, ok := ctx.Value(actorCtxKey{}).(Actor)
+	return a, ok
+}
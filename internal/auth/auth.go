@@ -3,27 +3,112 @@ package auth
 
 import (
 	"fmt"
-	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+)
+
+const (
+	// MethodAnonymous marks tokens issued to users identified only by a generated UUID.
+	MethodAnonymous = "anonymous"
+	// MethodOIDC marks tokens issued after a successful OpenID Connect login.
+	MethodOIDC = "oidc"
+	// MethodAPIKey marks requests authenticated with an API key.
+	MethodAPIKey = "api_key"
+
+	// RoleAdmin grants access to the administrative API.
+	RoleAdmin = "admin"
+
+	// MinSecretKeyLength is the minimum length in bytes of the key signing the tokens,
+	// the size of the HS256 hash.
+	MinSecretKeyLength = 32
+
+	// TokenTTL is the lifetime of the tokens issued by the server.
+	TokenTTL = 30 * 24 * time.Hour
 )
 
 // Claims holds JWT claims, embedding standard fields and a user ID.
+// For users signed in through an identity provider the registered Issuer and Subject
+// claims hold the provider issuer URL and the provider subject.
+// Role is empty for regular users and RoleAdmin for operators.
 type Claims struct {
 	jwt.RegisteredClaims
 	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role,omitempty"`
+}
+
+// Method returns the authentication method the claims were issued for.
+func (c *Claims) Method() string {
+	if c.Subject != "" {
+		return MethodOIDC
+	}
+	return MethodAnonymous
+}
+
+// IsAdmin reports whether the claims grant the admin role.
+func (c *Claims) IsAdmin() bool {
+	return c.Role == RoleAdmin
+}
+
+// secretKey holds the key signing and verifying the tokens. No token is issued or accepted until it is set.
+var secretKey atomic.Pointer[[]byte]
+
+// SetSecretKey sets the key signing and verifying the tokens, the SECRET_KEY setting.
+// Returns shrterr.ErrSecretKeyNotSet for an empty key and shrterr.ErrSecretKeyTooShort for a key
+// shorter than MinSecretKeyLength, in which case the previous key is kept.
+func SetSecretKey(key string) error {
+	if key == "" {
+		return shrterr.ErrSecretKeyNotSet
+	}
+	if len(key) < MinSecretKeyLength {
+		return fmt.Errorf("%w: %d bytes, at least %d are required", shrterr.ErrSecretKeyTooShort, len(key), MinSecretKeyLength)
+	}
+
+	k := []byte(key)
+	secretKey.Store(&k)
+	return nil
+}
+
+// key returns the key set by SetSecretKey, or shrterr.ErrSecretKeyNotSet when none is set.
+func key() ([]byte, error) {
+	k := secretKey.Load()
+	if k == nil {
+		return nil, shrterr.ErrSecretKeyNotSet
+	}
+	return *k, nil
 }
 
-var secretKey = []byte(os.Getenv("SECRET_KEY"))
+// NewClaims returns the claims of a token issued now by the server to the given user, valid for TokenTTL.
+func NewClaims(userID uuid.UUID) Claims {
+	now := time.Now()
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(TokenTTL)),
+		},
+		UserID: userID,
+	}
+}
 
-// CreateToken generates a JWT for the given userID using HS256 signing.
+// CreateToken generates a JWT for the given userID using HS256 signing, valid for TokenTTL.
 // Returns the token string or an error.
 func CreateToken(userID uuid.UUID) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
-		UserID: userID,
-	})
-	tokenString, err := token.SignedString(secretKey)
+	return CreateTokenWithClaims(NewClaims(userID))
+}
+
+// CreateTokenWithClaims generates a JWT holding the provided claims using HS256 signing.
+// Returns the token string or an error, shrterr.ErrSecretKeyNotSet when no key is set.
+func CreateTokenWithClaims(claims Claims) (string, error) {
+	k, err := key()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(k)
 
 	if err != nil {
 		return "", err
@@ -32,12 +117,17 @@ func CreateToken(userID uuid.UUID) (string, error) {
 	return tokenString, nil
 }
 
-// Validate checks the validity of a JWT token string and returns whether it's valid and the associated user UUID.
-// Returns false and uuid.Nil if the token is invalid or empty.
-func Validate(tokenString string) (bool, uuid.UUID) {
-
+// Parse checks the validity of a JWT token string and returns the claims it holds.
+// Returns an error if the token is empty, malformed, signed with an unexpected method, expired or invalid,
+// and shrterr.ErrSecretKeyNotSet when no key is set.
+func Parse(tokenString string) (*Claims, error) {
 	if tokenString == "" {
-		return false, uuid.Nil
+		return nil, jwt.ErrTokenMalformed
+	}
+
+	k, err := key()
+	if err != nil {
+		return nil, err
 	}
 
 	claims := &Claims{}
@@ -46,16 +136,32 @@ func Validate(tokenString string) (bool, uuid.UUID) {
 			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
 			}
-			return secretKey, nil
+			return k, nil
 		})
 	if err != nil {
-		return false, uuid.Nil
+		return nil, err
 	}
 
 	if !token.Valid {
-		fmt.Println("token not valid")
+		return nil, jwt.ErrTokenUnverifiable
+	}
+
+	return claims, nil
+}
+
+// Validate checks the validity of a JWT token string and returns whether it's valid and the associated user UUID.
+// Returns false and uuid.Nil if the token is invalid or empty.
+func Validate(tokenString string) (bool, uuid.UUID) {
+	claims, err := Parse(tokenString)
+	if err != nil {
 		return false, uuid.Nil
 	}
 
 	return true, claims.UserID
 }
+
+// UserIDFromSubject derives a stable shortener user ID from an identity provider issuer
+// and subject, so the same identity is always mapped to the same user.
+func UserIDFromSubject(issuer, subject string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(issuer+"#"+subject))
+}
@@ -2,12 +2,25 @@ package auth
 
 import (
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+const testSecretKey = "test-secret-key-0123456789abcdef"
+
+func TestMain(m *testing.M) {
+	if err := SetSecretKey(testSecretKey); err != nil {
+		panic(err)
+	}
+	m.Run()
+}
+
 func createToken() (string, error) {
 	userID := uuid.New()
 	return CreateToken(userID)
@@ -19,6 +32,54 @@ func TestCreateToken(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotEmpty(t, token)
 	})
+
+	t.Run("token_expires", func(t *testing.T) {
+		token, err := createToken()
+		require.NoError(t, err)
+
+		claims, err := Parse(token)
+		require.NoError(t, err)
+		require.NotNil(t, claims.IssuedAt)
+		require.NotNil(t, claims.ExpiresAt)
+		assert.Equal(t, TokenTTL, claims.ExpiresAt.Sub(claims.IssuedAt.Time))
+	})
+}
+
+func TestSetSecretKey(t *testing.T) {
+	t.Run("empty_key", func(t *testing.T) {
+		assert.ErrorIs(t, SetSecretKey(""), shrterr.ErrSecretKeyNotSet)
+	})
+
+	t.Run("short_key", func(t *testing.T) {
+		assert.ErrorIs(t, SetSecretKey("short"), shrterr.ErrSecretKeyTooShort)
+
+		token, err := createToken()
+		require.NoError(t, err)
+		_, err = Parse(token)
+		assert.NoError(t, err, "the previous key is kept")
+	})
+
+	t.Run("forged_admin_token", func(t *testing.T) {
+		for _, key := range []string{"", "another-secret-key-0123456789abcdef"} {
+			forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{UserID: uuid.New(), Role: RoleAdmin}).
+				SignedString([]byte(key))
+			require.NoError(t, err)
+
+			_, err = Parse(forged)
+			assert.Error(t, err, "key %q", key)
+		}
+	})
+
+	t.Run("expired_token", func(t *testing.T) {
+		token, err := CreateTokenWithClaims(Claims{
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute))},
+			UserID:           uuid.New(),
+		})
+		require.NoError(t, err)
+
+		_, err = Parse(token)
+		assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+	})
 }
 
 func TestValidate(t *testing.T) {
@@ -47,3 +108,48 @@ func BenchmarkCreateToken(b *testing.B) {
 	}
 	b.ReportAllocs()
 }
+
+func TestUserIDFromSubject(t *testing.T) {
+	t.Run("same_subject_same_user", func(t *testing.T) {
+		first := UserIDFromSubject("https://idp.example.com", "employee-1")
+		second := UserIDFromSubject("https://idp.example.com", "employee-1")
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("different_issuer_different_user", func(t *testing.T) {
+		first := UserIDFromSubject("https://idp.example.com", "employee-1")
+		second := UserIDFromSubject("https://other.example.com", "employee-1")
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("parse_oidc_claims", func(t *testing.T) {
+		userID := UserIDFromSubject("https://idp.example.com", "employee-1")
+		claims := Claims{UserID: userID}
+		claims.Issuer = "https://idp.example.com"
+		claims.Subject = "employee-1"
+
+		token, err := CreateTokenWithClaims(claims)
+		assert.NoError(t, err)
+
+		parsed, err := Parse(token)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, parsed.UserID)
+		assert.Equal(t, MethodOIDC, parsed.Method())
+	})
+
+	t.Run("parse_anonymous_claims", func(t *testing.T) {
+		token, err := createToken()
+		assert.NoError(t, err)
+
+		parsed, err := Parse(token)
+		assert.NoError(t, err)
+		assert.Equal(t, MethodAnonymous, parsed.Method())
+	})
+
+	t.Run("parse_empty_token", func(t *testing.T) {
+		_, err := Parse("")
+		assert.Error(t, err)
+	})
+}
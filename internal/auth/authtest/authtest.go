@@ -0,0 +1,18 @@
+// Package authtest provides the key signing the JWTs of tests, which the auth package
+// requires before it issues or accepts any token.
+package authtest
+
+import "github.com/mp1947/ya-url-shortener/internal/auth"
+
+// SecretKey is the key signing the JWTs of tests.
+const SecretKey = "test-secret-key-0123456789abcdef"
+
+// Environ is the SECRET_KEY setting holding SecretKey, for tests loading the configuration.
+const Environ = "SECRET_KEY=" + SecretKey
+
+// Init sets SecretKey as the key signing the JWTs. It is meant to be called by TestMain.
+func Init() {
+	if err := auth.SetSecretKey(SecretKey); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,191 @@
+// Package cache implements a bounded in-process cache whose entries expire after a TTL. When the cache
+// is full, adding an entry evicts the least recently used one (LRU) or the least frequently used one (LFU),
+// ties between equally used entries being broken by recency.
+package cache
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Eviction policies.
+const (
+	LRU = "lru"
+	LFU = "lfu"
+)
+
+// ErrUnknownPolicy is returned by New when the eviction policy is not supported.
+var ErrUnknownPolicy = errors.New("unknown cache eviction policy")
+
+// Cache is a bounded cache safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	lfu     bool
+	now     func() time.Time
+	tick    uint64
+	entries map[K]*entry[K, V]
+	queue   queue[K, V]
+}
+
+// entry is a cached value along with its expiry and the usage the eviction policy is based on.
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+	uses    uint64
+	used    uint64
+	index   int
+}
+
+// New returns a cache holding at most size entries evicted according to policy, LRU or LFU.
+func New[K comparable, V any](size int, policy string) (*Cache[K, V], error) {
+	if policy != LRU && policy != LFU {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPolicy, policy)
+	}
+
+	c := &Cache[K, V]{
+		size:    size,
+		lfu:     policy == LFU,
+		now:     time.Now,
+		entries: make(map[K]*entry[K, V], size),
+	}
+	c.queue.lfu = c.lfu
+
+	return c, nil
+}
+
+// Get returns the value cached for key and whether it was found and has not expired.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	if !c.now().Before(e.expires) {
+		c.remove(e)
+		var zero V
+		return zero, false
+	}
+
+	c.touch(e)
+	heap.Fix(&c.queue, e.index)
+
+	return e.value, true
+}
+
+// Set caches value for key during ttl, evicting an entry if the cache is full.
+func (c *Cache[K, V]) Set(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size <= 0 {
+		return
+	}
+
+	expires := c.now().Add(ttl)
+
+	if e, ok := c.entries[key]; ok {
+		e.value = value
+		e.expires = expires
+		c.touch(e)
+		heap.Fix(&c.queue, e.index)
+		return
+	}
+
+	if len(c.entries) >= c.size {
+		c.remove(c.queue.entries[0])
+	}
+
+	e := &entry[K, V]{key: key, value: value, expires: expires}
+	c.touch(e)
+	c.entries[key] = e
+	heap.Push(&c.queue, e)
+}
+
+// Delete removes the entries cached for keys.
+func (c *Cache[K, V]) Delete(keys ...K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if e, ok := c.entries[key]; ok {
+			c.remove(e)
+		}
+	}
+}
+
+// Purge removes every entry.
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[K]*entry[K, V], c.size)
+	c.queue.entries = nil
+}
+
+// Len returns the number of cached entries, expired ones included until they are evicted.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// touch records a use of e.
+func (c *Cache[K, V]) touch(e *entry[K, V]) {
+	c.tick++
+	e.used = c.tick
+	e.uses++
+}
+
+// remove removes e from the cache.
+func (c *Cache[K, V]) remove(e *entry[K, V]) {
+	heap.Remove(&c.queue, e.index)
+	delete(c.entries, e.key)
+}
+
+// queue is a heap of entries ordered by eviction priority, the next entry to evict first.
+type queue[K comparable, V any] struct {
+	entries []*entry[K, V]
+	lfu     bool
+}
+
+func (q *queue[K, V]) Len() int {
+	return len(q.entries)
+}
+
+func (q *queue[K, V]) Less(i, j int) bool {
+	a, b := q.entries[i], q.entries[j]
+	if q.lfu && a.uses != b.uses {
+		return a.uses < b.uses
+	}
+	return a.used < b.used
+}
+
+func (q *queue[K, V]) Swap(i, j int) {
+	q.entries[i], q.entries[j] = q.entries[j], q.entries[i]
+	q.entries[i].index = i
+	q.entries[j].index = j
+}
+
+func (q *queue[K, V]) Push(x any) {
+	e := x.(*entry[K, V])
+	e.index = len(q.entries)
+	q.entries = append(q.entries, e)
+}
+
+func (q *queue[K, V]) Pop() any {
+	n := len(q.entries)
+	e := q.entries[n-1]
+	q.entries[n-1] = nil
+	q.entries = q.entries[:n-1]
+	return e
+}
@@ -0,0 +1,66 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	_, err := cache.New[string, int](2, "fifo")
+	assert.ErrorIs(t, err, cache.ErrUnknownPolicy)
+
+	tests := []struct {
+		policy  string
+		evicted string
+	}{
+		// "a" is used last, "b" is the least recently used
+		{policy: cache.LRU, evicted: "b"},
+		// "b" is used more often, "a" is the least frequently used
+		{policy: cache.LFU, evicted: "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			c, err := cache.New[string, int](2, tt.policy)
+			require.NoError(t, err)
+
+			c.Set("a", 1, time.Minute)
+			c.Set("b", 2, time.Minute)
+			c.Get("b")
+			c.Get("b")
+			c.Get("a")
+			c.Set("c", 3, time.Minute)
+
+			assert.Equal(t, 2, c.Len())
+			_, ok := c.Get(tt.evicted)
+			assert.False(t, ok)
+			v, ok := c.Get("c")
+			assert.True(t, ok)
+			assert.Equal(t, 3, v)
+		})
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	c, err := cache.New[string, int](2, cache.LRU)
+	require.NoError(t, err)
+
+	c.Set("a", 1, time.Minute)
+	c.Set("b", 2, 0)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 1, c.Len())
+
+	c.Delete("a", "missing")
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+
+	c.Set("a", 1, time.Minute)
+	c.Purge()
+	assert.Equal(t, 0, c.Len())
+}
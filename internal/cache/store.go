@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store holds encoded values by key for a bounded time. Local is the in-process default, a store shared
+// by the instances of the service, such as the Redis one, lets them share the values they cache.
+type Store interface {
+	// Get returns the value stored for key and whether it was found and has not expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value for key during ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes the values stored for keys.
+	Delete(ctx context.Context, keys ...string) error
+	// Purge removes every value.
+	Purge(ctx context.Context) error
+}
+
+// Counter is implemented by the stores able to count their values without walking a shared keyspace,
+// the statistics of a cache report its entries only when its store is a Counter.
+type Counter interface {
+	// Len returns the number of stored values.
+	Len(ctx context.Context) (int, error)
+}
+
+// Local is a Store keeping values in a Cache of the process.
+type Local struct {
+	c *Cache[string, []byte]
+}
+
+// NewLocal returns a Local store holding at most size values evicted according to policy, LRU or LFU.
+func NewLocal(size int, policy string) (*Local, error) {
+	c, err := New[string, []byte](size, policy)
+	if err != nil {
+		return nil, err
+	}
+	return &Local{c: c}, nil
+}
+
+// Get returns the value cached for key.
+func (l *Local) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, ok := l.c.Get(key)
+	return value, ok, nil
+}
+
+// Set caches value for key during ttl.
+func (l *Local) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	l.c.Set(key, value, ttl)
+	return nil
+}
+
+// Delete removes the values cached for keys.
+func (l *Local) Delete(ctx context.Context, keys ...string) error {
+	l.c.Delete(keys...)
+	return nil
+}
+
+// Purge removes every cached value.
+func (l *Local) Purge(ctx context.Context) error {
+	l.c.Purge()
+	return nil
+}
+
+// Len returns the number of cached values.
+func (l *Local) Len(ctx context.Context) (int, error) {
+	return l.c.Len(), nil
+}
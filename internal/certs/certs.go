@@ -0,0 +1,45 @@
+// Package certs provides helpers for loading the certificates used by the HTTP and gRPC servers.
+package certs
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// LoadCertPool reads PEM encoded CA certificates from path into a new certificate pool.
+// Returns an error if the file cannot be read or contains no certificates.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no pem certificates found in %s", path)
+	}
+
+	return pool, nil
+}
+
+// VerifyClientCertificate checks that the first of the given peer certificates is a client
+// certificate issued by one of the CAs in roots. The remaining certificates are used as intermediates.
+func VerifyClientCertificate(peerCerts []*x509.Certificate, roots *x509.CertPool) error {
+	if len(peerCerts) == 0 {
+		return fmt.Errorf("no client certificate presented")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range peerCerts[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := peerCerts[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	return err
+}
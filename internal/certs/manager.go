@@ -0,0 +1,137 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultWatchInterval is the interval between file checks used when none is configured.
+const defaultWatchInterval = time.Minute
+
+// Manager holds the server certificate loaded from a crt and key file pair and reloads it
+// when either file changes, so rotated certificates are picked up without a restart.
+// The current certificate is served through GetCertificate.
+type Manager struct {
+	crtPath string
+	keyPath string
+	logger  *zap.Logger
+
+	current atomic.Pointer[tls.Certificate]
+
+	mu       sync.Mutex
+	crtStamp fileStamp
+	keyStamp fileStamp
+}
+
+// fileStamp identifies a version of a file by its modification time and size.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewManager loads the certificate from crtPath and keyPath and returns a Manager serving it.
+// Returns an error if the initial certificate cannot be loaded.
+func NewManager(crtPath, keyPath string, l *zap.Logger) (*Manager, error) {
+	m := &Manager{
+		crtPath: crtPath,
+		keyPath: keyPath,
+		logger:  l,
+	}
+
+	if _, err := m.Reload(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetCertificate returns the current certificate. It is meant to be used as tls.Config.GetCertificate.
+func (m *Manager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return m.current.Load(), nil
+}
+
+// TLSConfig returns a server tls.Config serving the current certificate of the Manager.
+func (m *Manager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: m.GetCertificate,
+	}
+}
+
+// Reload loads the certificate again if the crt or key file changed since the last successful load.
+// It reports whether a new certificate was loaded. On error the previous certificate is kept.
+func (m *Manager) Reload() (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	crtStamp, err := stat(m.crtPath)
+	if err != nil {
+		return false, err
+	}
+	keyStamp, err := stat(m.keyPath)
+	if err != nil {
+		return false, err
+	}
+
+	if m.current.Load() != nil && crtStamp.equal(m.crtStamp) && keyStamp.equal(m.keyStamp) {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(m.crtPath, m.keyPath)
+	if err != nil {
+		return false, err
+	}
+
+	m.current.Store(&cert)
+	m.crtStamp = crtStamp
+	m.keyStamp = keyStamp
+
+	return true, nil
+}
+
+// Watch checks the crt and key files for changes every interval until ctx is done.
+// Failed reloads are logged and retried on the next check, which covers an external agent
+// writing the crt and key files one after the other.
+// A non-positive interval falls back to defaultWatchInterval.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := m.Reload()
+			if err != nil {
+				m.logger.Warn("error reloading tls certificate", zap.String("crt_file", m.crtPath), zap.Error(err))
+				continue
+			}
+			if reloaded {
+				m.logger.Info("tls certificate reloaded", zap.String("crt_file", m.crtPath))
+			}
+		}
+	}
+}
+
+func (s fileStamp) equal(other fileStamp) bool {
+	return s.modTime.Equal(other.modTime) && s.size == other.size
+}
+
+func stat(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
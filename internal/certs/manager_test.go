@@ -0,0 +1,100 @@
+package certs_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/certs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeSelfSigned writes a self-signed certificate with the given common name and its key
+// to crtPath and keyPath, setting the modification time of both files to modTime.
+func writeSelfSigned(t *testing.T, crtPath, keyPath, commonName string, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(crtPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	require.NoError(t, os.Chtimes(crtPath, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyPath, modTime, modTime))
+}
+
+func servedCommonName(t *testing.T, m *certs.Manager) string {
+	cert, err := m.GetCertificate(nil)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+
+	return leaf.Subject.CommonName
+}
+
+func TestManager(t *testing.T) {
+	dir := t.TempDir()
+	crtPath := filepath.Join(dir, "cert.crt")
+	keyPath := filepath.Join(dir, "key.pem")
+	start := time.Now().Add(-time.Hour)
+
+	_, err := certs.NewManager(crtPath, keyPath, zap.NewNop())
+	assert.Error(t, err, "missing files must fail")
+
+	writeSelfSigned(t, crtPath, keyPath, "first", start)
+
+	m, err := certs.NewManager(crtPath, keyPath, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "first", servedCommonName(t, m))
+
+	t.Run("unchanged files are not reloaded", func(t *testing.T) {
+		reloaded, err := m.Reload()
+		require.NoError(t, err)
+		assert.False(t, reloaded)
+	})
+
+	t.Run("broken files keep the previous certificate", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(crtPath, []byte("garbage"), 0600))
+
+		_, err := m.Reload()
+		assert.Error(t, err)
+		assert.Equal(t, "first", servedCommonName(t, m))
+	})
+
+	t.Run("rotated files are picked up by watch", func(t *testing.T) {
+		writeSelfSigned(t, crtPath, keyPath, "second", start.Add(time.Minute))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go m.Watch(ctx, 10*time.Millisecond)
+
+		assert.Eventually(t, func() bool {
+			return servedCommonName(t, m) == "second"
+		}, time.Second, 10*time.Millisecond)
+	})
+}
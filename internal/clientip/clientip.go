@@ -0,0 +1,203 @@
+// Package clientip resolves the IP address of the client that issued a request.
+// Forwarding headers (Forwarded, X-Forwarded-For, X-Real-IP) are taken into account only
+// when the request was received from a trusted proxy, so they cannot be spoofed by clients
+// connecting directly to the service.
+package clientip
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+type ipCtxKey struct{}
+
+// Networks is a set of IP networks which can be replaced while it is in use.
+type Networks struct {
+	nets atomic.Pointer[[]*net.IPNet]
+}
+
+// NewNetworks creates a Networks set holding the given networks.
+func NewNetworks(nets []*net.IPNet) *Networks {
+	n := &Networks{}
+	n.Set(nets)
+	return n
+}
+
+// Set atomically replaces the networks of the set.
+func (n *Networks) Set(nets []*net.IPNet) {
+	n.nets.Store(&nets)
+}
+
+// Get returns the current networks of the set, which must not be modified.
+func (n *Networks) Get() []*net.IPNet {
+	return *n.nets.Load()
+}
+
+// Contains reports whether ip belongs to any of the current networks of the set.
+func (n *Networks) Contains(ip net.IP) bool {
+	return Contains(n.Get(), ip)
+}
+
+// Resolver resolves client IP addresses using the configured trusted proxy networks.
+type Resolver struct {
+	proxies *Networks
+}
+
+// NewResolver creates a Resolver trusting forwarding headers set by peers in the given networks.
+// A Resolver without trusted proxies always resolves to the address of the peer.
+func NewResolver(proxies []*net.IPNet) *Resolver {
+	return &Resolver{proxies: NewNetworks(proxies)}
+}
+
+// SetProxies replaces the trusted proxy networks, requests being resolved keep the previous ones.
+func (r *Resolver) SetProxies(proxies []*net.IPNet) {
+	r.proxies.Set(proxies)
+}
+
+// IsTrustedProxy reports whether ip belongs to one of the trusted proxy networks.
+func (r *Resolver) IsTrustedProxy(ip net.IP) bool {
+	return r.proxies.Contains(ip)
+}
+
+// Resolve returns the client IP address of an HTTP request received from remoteAddr.
+// If the peer is a trusted proxy, the forwarding chain is read from the Forwarded header,
+// or X-Forwarded-For if Forwarded is absent, and walked from the right, skipping trusted
+// proxies. The first untrusted address is the client. X-Real-IP is used when neither chain
+// header is present. Returns nil if remoteAddr cannot be parsed.
+func (r *Resolver) Resolve(remoteAddr string, header http.Header) net.IP {
+	return r.resolve(
+		remoteAddr,
+		forwardedFor(header.Values("Forwarded")),
+		splitList(header.Values("X-Forwarded-For")),
+		header.Get("X-Real-IP"),
+	)
+}
+
+// ResolveMetadata is Resolve for gRPC calls. The forwarding chain is read from the
+// lower-cased metadata keys "forwarded", "x-forwarded-for" and "x-real-ip".
+func (r *Resolver) ResolveMetadata(remoteAddr string, get func(key string) []string) net.IP {
+	var realIP string
+	if values := get("x-real-ip"); len(values) > 0 {
+		realIP = values[0]
+	}
+
+	return r.resolve(
+		remoteAddr,
+		forwardedFor(get("forwarded")),
+		splitList(get("x-forwarded-for")),
+		realIP,
+	)
+}
+
+func (r *Resolver) resolve(remoteAddr string, forwarded, xForwardedFor []string, realIP string) net.IP {
+	proxies := r.proxies.Get()
+
+	peer := ParseHost(remoteAddr)
+	if peer == nil || !Contains(proxies, peer) {
+		return peer
+	}
+
+	chain := forwarded
+	if len(chain) == 0 {
+		chain = xForwardedFor
+	}
+
+	if len(chain) == 0 {
+		if ip := ParseHost(realIP); ip != nil {
+			return ip
+		}
+		return peer
+	}
+
+	client := peer
+	for i := len(chain) - 1; i >= 0; i-- {
+		ip := ParseHost(chain[i])
+		if ip == nil {
+			break
+		}
+		client = ip
+		if !Contains(proxies, ip) {
+			break
+		}
+	}
+
+	return client
+}
+
+// Contains reports whether ip belongs to any of the given networks.
+func Contains(nets []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseHost parses an IP address optionally followed by a port, as found in RemoteAddr
+// and forwarding headers: "192.0.2.1", "192.0.2.1:80", "2001:db8::1", "[2001:db8::1]:80".
+// Returns nil if no IP address can be parsed.
+func ParseHost(s string) net.IP {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	return net.ParseIP(s)
+}
+
+// WithIP returns a copy of ctx carrying the resolved client IP address.
+func WithIP(ctx context.Context, ip net.IP) context.Context {
+	return context.WithValue(ctx, ipCtxKey{}, ip)
+}
+
+// FromContext returns the client IP address stored in ctx, or nil if there is none.
+func FromContext(ctx context.Context) net.IP {
+	ip, _ := ctx.Value(ipCtxKey{}).(net.IP)
+	return ip
+}
+
+// StringFromContext returns the client IP address stored in ctx as a string,
+// or an empty string if there is none.
+func StringFromContext(ctx context.Context) string {
+	if ip := FromContext(ctx); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
+// splitList splits comma-separated header values into trimmed elements.
+func splitList(values []string) []string {
+	var result []string
+	for _, v := range values {
+		for _, element := range strings.Split(v, ",") {
+			if element = strings.TrimSpace(element); element != "" {
+				result = append(result, element)
+			}
+		}
+	}
+	return result
+}
+
+// forwardedFor extracts the "for" parameters of RFC 7239 Forwarded header values in order.
+// Elements without a "for" parameter are skipped, obfuscated identifiers are kept so that
+// they stop the walk over the chain.
+func forwardedFor(values []string) []string {
+	var result []string
+	for _, element := range splitList(values) {
+		for _, pair := range strings.Split(element, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok || !strings.EqualFold(key, "for") {
+				continue
+			}
+			result = append(result, strings.Trim(value, `"`))
+		}
+	}
+	return result
+}
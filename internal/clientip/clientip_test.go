@@ -0,0 +1,128 @@
+package clientip_test
+
+import (
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParseCIDRs(t *testing.T, cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatalf("bad cidr %s: %v", cidr, err)
+		}
+		nets[i] = n
+	}
+	return nets
+}
+
+func TestResolve(t *testing.T) {
+	r := clientip.NewResolver(mustParseCIDRs(t, "10.0.0.0/8", "fd00::/8"))
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		header     http.Header
+		want       string
+	}{
+		{
+			name:       "untrusted peer ignores headers",
+			remoteAddr: "203.0.113.7:51000",
+			header: http.Header{
+				"X-Real-Ip":       {"10.1.1.1"},
+				"X-Forwarded-For": {"10.1.1.1"},
+			},
+			want: "203.0.113.7",
+		},
+		{
+			name:       "trusted peer without headers",
+			remoteAddr: "10.0.0.2:51000",
+			header:     http.Header{},
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "x-real-ip from trusted peer",
+			remoteAddr: "10.0.0.2:51000",
+			header:     http.Header{"X-Real-Ip": {"198.51.100.4"}},
+			want:       "198.51.100.4",
+		},
+		{
+			name:       "x-forwarded-for skips trusted proxies",
+			remoteAddr: "10.0.0.2:51000",
+			header:     http.Header{"X-Forwarded-For": {"192.0.2.99, 198.51.100.4", "10.0.0.3"}},
+			want:       "198.51.100.4",
+		},
+		{
+			name:       "forwarded takes precedence over x-forwarded-for",
+			remoteAddr: "[fd00::1]:51000",
+			header: http.Header{
+				"Forwarded":       {`for="[2001:db8:cafe::17]:4711";proto=https, for=10.0.0.5`},
+				"X-Forwarded-For": {"198.51.100.4"},
+			},
+			want: "2001:db8:cafe::17",
+		},
+		{
+			name:       "obfuscated forwarded identifier stops the walk",
+			remoteAddr: "10.0.0.2:51000",
+			header:     http.Header{"Forwarded": {"for=_hidden, for=10.0.0.5"}},
+			want:       "10.0.0.5",
+		},
+		{
+			name:       "ipv6 peer without port",
+			remoteAddr: "2001:db8::1",
+			header:     http.Header{},
+			want:       "2001:db8::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.Resolve(tt.remoteAddr, tt.header).String())
+		})
+	}
+}
+
+func TestResolveWithoutTrustedProxies(t *testing.T) {
+	r := clientip.NewResolver(nil)
+
+	ip := r.Resolve("10.0.0.2:51000", http.Header{"X-Real-Ip": {"192.0.2.1"}})
+	assert.Equal(t, "10.0.0.2", ip.String())
+
+	assert.Nil(t, r.Resolve("not an address", http.Header{}))
+}
+
+func TestContains(t *testing.T) {
+	nets := mustParseCIDRs(t, "192.168.0.0/24", "2001:db8::/32")
+
+	assert.True(t, clientip.Contains(nets, net.ParseIP("192.168.0.10")))
+	assert.True(t, clientip.Contains(nets, net.ParseIP("2001:db8::10")))
+	assert.False(t, clientip.Contains(nets, net.ParseIP("192.168.1.10")))
+	assert.False(t, clientip.Contains(nets, nil))
+}
+
+func TestNetworksSet(t *testing.T) {
+	n := clientip.NewNetworks(mustParseCIDRs(t, "192.168.0.0/24"))
+	assert.True(t, n.Contains(net.ParseIP("192.168.0.10")))
+
+	n.Set(mustParseCIDRs(t, "10.0.0.0/8"))
+	assert.False(t, n.Contains(net.ParseIP("192.168.0.10")))
+	assert.True(t, n.Contains(net.ParseIP("10.1.2.3")))
+
+	n.Set(nil)
+	assert.False(t, n.Contains(net.ParseIP("10.1.2.3")))
+}
+
+func TestResolverSetProxies(t *testing.T) {
+	r := clientip.NewResolver(nil)
+	header := http.Header{"X-Real-Ip": {"192.0.2.1"}}
+
+	assert.Equal(t, "10.0.0.2", r.Resolve("10.0.0.2:51000", header).String())
+
+	r.SetProxies(mustParseCIDRs(t, "10.0.0.0/8"))
+	assert.Equal(t, "192.0.2.1", r.Resolve("10.0.0.2:51000", header).String())
+}
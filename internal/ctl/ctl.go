@@ -0,0 +1,114 @@
+// Package ctl implements the commands of shortenerctl, the operator tool of the URL shortener.
+// Commands use the same configuration as the server: flags, environment and configuration file are
+// read by the config package, the command and its arguments being the arguments following the flags.
+package ctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/repository"
+	"github.com/mp1947/ya-url-shortener/internal/repository/database"
+	"github.com/mp1947/ya-url-shortener/internal/repository/inmemory"
+	"go.uber.org/zap"
+)
+
+// Usage describes the commands of shortenerctl.
+const Usage = `usage: shortenerctl [flags] <command> [arguments]
+
+Flags, environment variables and configuration file are those of the shortener server.
+
+Commands:
+  migrate up|down|status       apply pending migrations, roll back the latest one or list them
+  export [file]                write every stored link as JSON lines to file or stdout
+  import [file]                store the links written by export, read from file or stdin
+  keys create                  generate an admin API key and print its ADMIN_API_KEYS entry
+  keys list                    print the ids of the configured admin API keys
+  keys revoke <id>             print ADMIN_API_KEYS without the key with the given id
+  token [-admin] [-ttl 24h] <user id>
+                               mint a JWT for the given user ID
+  transfer [-dry-run] [-batch-size 500] to-database|to-eventlog
+                               move the links of the event log at FILE_STORAGE_PATH to the
+                               database at DATABASE_DSN or the other way round, skipping links
+                               already present and reporting conflicts
+  compact                      compact the event log of the in-memory storage
+  stats                        print the internal statistics of the storage
+`
+
+// command holds what the shortenerctl commands share: the configuration and the streams.
+type command struct {
+	cfg *config.Config
+	in  io.Reader
+	out io.Writer
+}
+
+// Run runs the command named by the positional arguments of cfg, reading input from in
+// and writing output to out. Logs are written to stderr so that out only holds the output
+// of the command. Returns an error wrapping shrterr.ErrUnknownCommand when the command is
+// unknown or its arguments are missing.
+func Run(ctx context.Context, cfg *config.Config, in io.Reader, out io.Writer) error {
+	c := &command{cfg: cfg, in: in, out: out}
+
+	args := cfg.Args
+	if len(args) == 0 {
+		return fmt.Errorf("%w: no command given", shrterr.ErrUnknownCommand)
+	}
+
+	switch args[0] {
+	case "migrate":
+		return c.migrate(ctx, args[1:])
+	case "export":
+		return c.exportLinks(ctx, args[1:])
+	case "import":
+		return c.importLinks(ctx, args[1:])
+	case "keys":
+		return c.keys(args[1:])
+	case "token":
+		return c.token(args[1:])
+	case "transfer":
+		return c.transferLinks(ctx, args[1:])
+	case "compact":
+		return c.compact(ctx)
+	case "stats":
+		return c.stats(ctx)
+	}
+
+	return fmt.Errorf("%w: %s", shrterr.ErrUnknownCommand, args[0])
+}
+
+// openRepository creates the repository described by the configuration, as the server does,
+// except that database migrations are never applied implicitly. The returned function closes it.
+func (c *command) openRepository(ctx context.Context) (repository.Repository, *zap.Logger, func(), error) {
+	logging := *c.cfg.Logging
+	logging.OutputPaths = "stderr"
+
+	l, _, err := logger.New(&logging)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cfg := *c.cfg
+	autoMigrate := false
+	cfg.AutoMigrate = &autoMigrate
+
+	repo, err := repository.CreateRepository(l, cfg, ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	closeRepo := func() {
+		switch r := repo.(type) {
+		case *database.Database:
+			r.Close()
+		case *inmemory.Memory:
+			_ = r.EP.File.Close()
+		}
+		_ = l.Sync()
+	}
+
+	return repo, l, closeRepo, nil
+}
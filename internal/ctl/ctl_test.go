@@ -0,0 +1,224 @@
+package ctl_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/apikey"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
+	"github.com/mp1947/ya-url-shortener/internal/ctl"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const links = `{"short_url":"aaa","original_url":"https://a.example.com","user_id":"user-1"}
+{"short_url":"bbb","original_url":"https://b.example.com","user_id":"user-1","is_disabled":true}
+{"short_url":"ccc","original_url":"https://c.example.com","user_id":"user-2","is_deleted":true}
+`
+
+// run runs shortenerctl with args using the given event log, and returns its output.
+func run(t *testing.T, storage string, in string, args ...string) (string, error) {
+	t.Helper()
+
+	cfg, err := config.Load(append([]string{"-f", storage}, args...), []string{authtest.Environ, "LOG_LEVEL=error"}, nil)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	err = ctl.Run(context.Background(), cfg, strings.NewReader(in), &out)
+	return out.String(), err
+}
+
+func TestRun(t *testing.T) {
+	_, err := run(t, filepath.Join(t.TempDir(), "events.out"), "", "unknown")
+	assert.ErrorIs(t, err, shrterr.ErrUnknownCommand)
+
+	_, err = run(t, filepath.Join(t.TempDir(), "events.out"), "")
+	assert.ErrorIs(t, err, shrterr.ErrUnknownCommand)
+
+	_, err = run(t, filepath.Join(t.TempDir(), "events.out"), "", "migrate", "status")
+	assert.ErrorIs(t, err, shrterr.ErrStorageTypeMismatch)
+
+	_, err = run(t, filepath.Join(t.TempDir(), "events.out"), "", "transfer", "to-database")
+	assert.ErrorIs(t, err, shrterr.ErrStorageTypeMismatch)
+
+	_, err = run(t, filepath.Join(t.TempDir(), "events.out"), "", "transfer", "-dry-run", "sideways")
+	assert.ErrorIs(t, err, shrterr.ErrUnknownCommand)
+}
+
+func TestExportImport(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "source.out")
+
+	out, err := run(t, source, links, "import")
+	require.NoError(t, err)
+	assert.Equal(t, "imported 3 links, skipped 0 already stored, 0 conflicting\n", out)
+
+	t.Run("import is idempotent", func(t *testing.T) {
+		out, err := run(t, source, links, "import")
+		require.NoError(t, err)
+		assert.Equal(t, "imported 0 links, skipped 3 already stored, 0 conflicting\n", out)
+	})
+
+	t.Run("interrupted import is resumed", func(t *testing.T) {
+		storage := filepath.Join(t.TempDir(), "events.out")
+
+		// the import stopped after saving bbb and ccc, before their flags were set
+		_, err := run(t, storage, strings.Join([]string{
+			`{"short_url":"bbb","original_url":"https://b.example.com","user_id":"user-1"}`,
+			`{"short_url":"ccc","original_url":"https://c.example.com","user_id":"user-2"}`,
+			"",
+		}, "\n"), "import")
+		require.NoError(t, err)
+
+		out, err := run(t, storage, links, "import")
+		require.NoError(t, err)
+		assert.Equal(t, "imported 1 links, skipped 2 already stored, 0 conflicting\n", out)
+
+		resumed, err := run(t, storage, "", "export")
+		require.NoError(t, err)
+		exported, err := run(t, source, "", "export")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, strings.Split(exported, "\n"), strings.Split(resumed, "\n"), "the flags are reconciled")
+	})
+
+	t.Run("conflicting links", func(t *testing.T) {
+		out, err := run(t, source, strings.Join([]string{
+			`{"short_url":"aaa","original_url":"https://other.example.com","user_id":"user-1","is_disabled":true}`,
+			`{"short_url":"zzz","original_url":"https://a.example.com","user_id":"user-1","is_disabled":true}`,
+			"",
+		}, "\n"), "import")
+		require.NoError(t, err)
+		assert.Equal(t, "imported 0 links, skipped 0 already stored, 2 conflicting\n", out)
+
+		exported, err := run(t, source, "", "export")
+		require.NoError(t, err)
+		assert.Contains(t, exported, `{"short_url":"aaa","original_url":"https://a.example.com","user_id":"user-1"}`, "the stored link is unchanged")
+	})
+
+	t.Run("export to another storage", func(t *testing.T) {
+		exported, err := run(t, source, "", "export")
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			`{"short_url":"aaa","original_url":"https://a.example.com","user_id":"user-1"}`,
+			`{"short_url":"bbb","original_url":"https://b.example.com","user_id":"user-1","is_disabled":true}`,
+			`{"short_url":"ccc","original_url":"https://c.example.com","user_id":"user-2","is_deleted":true}`,
+			"",
+		}, "\n"), exported)
+
+		target := filepath.Join(t.TempDir(), "target.out")
+		_, err = run(t, target, exported, "import")
+		require.NoError(t, err)
+
+		reexported, err := run(t, target, "", "export")
+		require.NoError(t, err)
+		assert.Equal(t, exported, reexported)
+	})
+
+	t.Run("export to file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "links.jsonl")
+		_, err := run(t, source, "", "export", file)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, 3, strings.Count(string(data), "\n"))
+	})
+}
+
+func TestCompact(t *testing.T) {
+	storage := filepath.Join(t.TempDir(), "events.out")
+
+	_, err := run(t, storage, links, "import")
+	require.NoError(t, err)
+
+	before, err := run(t, storage, "", "export")
+	require.NoError(t, err)
+
+	out, err := run(t, storage, "", "compact")
+	require.NoError(t, err)
+	assert.Contains(t, out, "from 5 to 3 events")
+
+	data, err := os.ReadFile(storage)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(data), "\n"))
+
+	after, err := run(t, storage, "", "export")
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+}
+
+func TestKeys(t *testing.T) {
+	storage := filepath.Join(t.TempDir(), "events.out")
+
+	out, err := run(t, storage, "", "keys", "create")
+	require.NoError(t, err)
+	assert.Contains(t, out, "entry: "+apikey.HashPrefix)
+
+	cfg, err := config.Load([]string{"keys", "revoke", apikey.ID("first-key")}, []string{
+		authtest.Environ,
+		"ADMIN_API_KEYS=first-key," + apikey.Entry("second-key"),
+	}, nil)
+	require.NoError(t, err)
+
+	var revoked bytes.Buffer
+	require.NoError(t, ctl.Run(context.Background(), cfg, nil, &revoked))
+	assert.Equal(t, "ADMIN_API_KEYS="+apikey.Entry("second-key")+"\n", revoked.String())
+
+	cfg.Args = []string{"keys", "revoke", "unknown"}
+	assert.ErrorIs(t, ctl.Run(context.Background(), cfg, nil, &revoked), shrterr.ErrAPIKeyNotFound)
+}
+
+func TestToken(t *testing.T) {
+	userID := uuid.New()
+
+	out, err := run(t, filepath.Join(t.TempDir(), "events.out"), "", "token", "-admin", userID.String())
+	require.NoError(t, err)
+
+	claims, err := auth.Parse(strings.TrimSpace(out))
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.True(t, claims.IsAdmin())
+	assert.NotNil(t, claims.ExpiresAt)
+
+	_, err = run(t, filepath.Join(t.TempDir(), "events.out"), "", "token")
+	assert.ErrorIs(t, err, shrterr.ErrUnknownCommand)
+
+	t.Run("no secret key", func(t *testing.T) {
+		cfg, err := config.Load([]string{"token", "-admin", userID.String()}, []string{authtest.Environ}, nil)
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		for key, want := range map[string]error{"": shrterr.ErrSecretKeyNotSet, "short": shrterr.ErrSecretKeyTooShort} {
+			*cfg.SecretKey = key
+			assert.ErrorIs(t, ctl.Run(context.Background(), cfg, nil, &out), want)
+		}
+		assert.Empty(t, out.String(), "no token is minted")
+	})
+}
+
+func TestStats(t *testing.T) {
+	storage := filepath.Join(t.TempDir(), "events.out")
+
+	_, err := run(t, storage, links, "import")
+	require.NoError(t, err)
+
+	out, err := run(t, storage, "", "stats")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"urls"`)
+
+	t.Run("no secret key", func(t *testing.T) {
+		cfg, err := config.Load([]string{"-f", storage, "stats"}, []string{"LOG_LEVEL=error"}, nil)
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		require.NoError(t, ctl.Run(context.Background(), cfg, nil, &out))
+		assert.Contains(t, out.String(), `"urls"`)
+	})
+}
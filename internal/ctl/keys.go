@@ -0,0 +1,64 @@
+package ctl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mp1947/ya-url-shortener/internal/apikey"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+)
+
+// keys runs "keys create", "keys list" or "keys revoke <id>". Admin API keys are configured by
+// ADMIN_API_KEYS, so these commands print the entries to configure, hashed so that raw keys are
+// never stored, and the new value is applied by reloading the server configuration with SIGHUP.
+func (c *command) keys(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: keys expects one of create, list or revoke", shrterr.ErrUnknownCommand)
+	}
+
+	switch args[0] {
+	case "create":
+		raw, err := apikey.Generate()
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(c.out,
+			"id: %s\nkey: %s\nentry: %s\n\nThe key is not shown again. Add the entry to ADMIN_API_KEYS.\n",
+			apikey.ID(raw), raw, apikey.Entry(raw),
+		)
+		return err
+	case "list":
+		for _, entry := range c.cfg.AdminAPIKeys {
+			kind := "raw"
+			if strings.HasPrefix(entry, apikey.HashPrefix) {
+				kind = "hashed"
+			}
+			fmt.Fprintf(c.out, "%s\t%s\n", apikey.ID(entry), kind)
+		}
+		return nil
+	case "revoke":
+		if len(args) != 2 {
+			return fmt.Errorf("%w: keys revoke expects a key id", shrterr.ErrUnknownCommand)
+		}
+
+		var kept []string
+		for _, entry := range c.cfg.AdminAPIKeys {
+			if apikey.ID(entry) == args[1] {
+				continue
+			}
+			if !strings.HasPrefix(entry, apikey.HashPrefix) {
+				entry = apikey.Entry(entry)
+			}
+			kept = append(kept, entry)
+		}
+
+		if len(kept) == len(c.cfg.AdminAPIKeys) {
+			return fmt.Errorf("%w: %s", shrterr.ErrAPIKeyNotFound, args[1])
+		}
+
+		_, err := fmt.Fprintf(c.out, "ADMIN_API_KEYS=%s\n", strings.Join(kept, ","))
+		return err
+	}
+
+	return fmt.Errorf("%w: keys %s", shrterr.ErrUnknownCommand, args[0])
+}
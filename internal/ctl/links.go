@@ -0,0 +1,150 @@
+package ctl
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/repository"
+	"go.uber.org/zap"
+)
+
+// link is a stored link as written by export and read by import, one JSON object per line.
+type link struct {
+	ShortURL    string `json:"short_url"`
+	OriginalURL string `json:"original_url"`
+	UserID      string `json:"user_id"`
+	IsDeleted   bool   `json:"is_deleted,omitempty"`
+	IsDisabled  bool   `json:"is_disabled,omitempty"`
+}
+
+// exportLinks writes every link of the storage to the file given as argument, or to the output.
+func (c *command) exportLinks(ctx context.Context, args []string) error {
+	repo, l, closeRepo, err := c.openRepository(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeRepo()
+
+	out := c.out
+	if len(args) > 0 {
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		out = f
+	}
+
+	w := bufio.NewWriter(out)
+	encoder := json.NewEncoder(w)
+	count := 0
+
+	err = repo.ListURLs(ctx, func(u model.URL) error {
+		count++
+		return encoder.Encode(link{
+			ShortURL:    u.ShortURLID,
+			OriginalURL: u.OriginalURL,
+			UserID:      u.UserID,
+			IsDeleted:   u.IsDeleted,
+			IsDisabled:  u.IsDisabled,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	l.Info("links have been exported", zap.Int("count", count))
+
+	return w.Flush()
+}
+
+// importLinks stores the links read from the file given as argument, or from the input, and restores
+// their disabled and deleted flags. A link whose short URL is already stored with the same original URL
+// is skipped, its flags being reconciled with the imported ones, so that an interrupted import can be run
+// again. A link whose short or original URL is stored for another link is reported as conflicting.
+func (c *command) importLinks(ctx context.Context, args []string) error {
+	repo, l, closeRepo, err := c.openRepository(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeRepo()
+
+	in := c.in
+	if len(args) > 0 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		in = f
+	}
+
+	decoder := json.NewDecoder(in)
+	var imported, skipped, conflicting int
+
+	for {
+		var lnk link
+		if err := decoder.Decode(&lnk); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("error reading link %d: %w", imported+skipped+conflicting+1, err)
+		}
+
+		err := repo.Save(ctx, lnk.ShortURL, lnk.OriginalURL, lnk.UserID)
+		if errors.Is(err, shrterr.ErrOriginalURLAlreadyExists) {
+			stored, err := repo.Get(ctx, lnk.ShortURL)
+			if errors.Is(err, shrterr.ErrShortURLNotFound) || (err == nil && stored.OriginalURL != lnk.OriginalURL) {
+				l.Warn("link conflicts with a stored link", zap.String("short_url", lnk.ShortURL))
+				conflicting++
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("error reading %s: %w", lnk.ShortURL, err)
+			}
+
+			if err := reconcileFlags(ctx, repo, lnk, stored); err != nil {
+				return err
+			}
+			skipped++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error importing %s: %w", lnk.ShortURL, err)
+		}
+
+		if err := reconcileFlags(ctx, repo, lnk, model.URL{}); err != nil {
+			return err
+		}
+		imported++
+	}
+
+	_, err = fmt.Fprintf(c.out, "imported %d links, skipped %d already stored, %d conflicting\n", imported, skipped, conflicting)
+	return err
+}
+
+// reconcileFlags sets the disabled and deleted flags of the stored link to those of lnk. Deletion is
+// final, so a stored deleted link stays deleted.
+func reconcileFlags(ctx context.Context, repo repository.Repository, lnk link, stored model.URL) error {
+	if lnk.IsDisabled != stored.IsDisabled {
+		if _, err := repo.SetDisabled(ctx, []string{lnk.ShortURL}, lnk.IsDisabled); err != nil {
+			return fmt.Errorf("error disabling %s: %w", lnk.ShortURL, err)
+		}
+	}
+	if lnk.IsDeleted && !stored.IsDeleted {
+		if _, err := repo.ForceDeleteBatch(ctx, []string{lnk.ShortURL}); err != nil {
+			return fmt.Errorf("error deleting %s: %w", lnk.ShortURL, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package ctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/repository/database"
+	"github.com/pressly/goose/v3"
+)
+
+// migrate runs "migrate up", "migrate down" or "migrate status" on the database storage.
+func (c *command) migrate(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: migrate expects one of up, down or status", shrterr.ErrUnknownCommand)
+	}
+
+	if *c.cfg.DatabaseDSN == "" {
+		return fmt.Errorf("%w: migrate requires DATABASE_DSN", shrterr.ErrStorageTypeMismatch)
+	}
+
+	repo, _, closeRepo, err := c.openRepository(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeRepo()
+
+	db := repo.(*database.Database)
+
+	switch args[0] {
+	case "up":
+		applied, err := db.MigrateUp(ctx)
+		for _, version := range applied {
+			fmt.Fprintf(c.out, "applied migration %d\n", version)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(c.out, "no pending migrations")
+		}
+		return err
+	case "down":
+		version, err := db.MigrateDown(ctx)
+		if errors.Is(err, goose.ErrNoNextVersion) {
+			fmt.Fprintln(c.out, "no applied migrations")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "rolled back migration %d\n", version)
+		return nil
+	case "status":
+		statuses, err := db.MigrationStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tSTATE\tSOURCE")
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, state, s.Source)
+		}
+		return w.Flush()
+	}
+
+	return fmt.Errorf("%w: migrate %s", shrterr.ErrUnknownCommand, args[0])
+}
@@ -0,0 +1,53 @@
+package ctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/repository/inmemory"
+)
+
+// compact compacts the event log of the in-memory storage. The server must not be running
+// on the same event log.
+func (c *command) compact(ctx context.Context) error {
+	if *c.cfg.DatabaseDSN != "" {
+		return fmt.Errorf("%w: compact requires the in-memory storage", shrterr.ErrStorageTypeMismatch)
+	}
+
+	repo, _, closeRepo, err := c.openRepository(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeRepo()
+
+	m := repo.(*inmemory.Memory)
+	before := m.EP.CurrentUUID
+
+	after, err := m.Compact(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.out, "compacted %s from %d to %d events\n", *c.cfg.FileStoragePath, before, after)
+	return err
+}
+
+// stats prints the internal statistics of the storage as JSON.
+func (c *command) stats(ctx context.Context) error {
+	repo, _, closeRepo, err := c.openRepository(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeRepo()
+
+	stats, err := repo.GetInternalStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(c.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stats)
+}
@@ -0,0 +1,60 @@
+package ctl
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+)
+
+// defaultTokenTTL is the lifetime of minted tokens when none is given.
+const defaultTokenTTL = 24 * time.Hour
+
+// token runs "token [-admin] [-ttl duration] <user id>", printing a JWT signed with SECRET_KEY
+// for the given user, granting the admin role with -admin. A zero TTL mints a token which never expires.
+// Returns an error when SECRET_KEY is empty or too short, as the server refuses to start with it.
+func (c *command) token(args []string) error {
+	flagSet := flag.NewFlagSet("token", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	admin := flagSet.Bool("admin", false, "grant the admin role")
+	ttl := flagSet.Duration("ttl", defaultTokenTTL, "lifetime of the token, 0 for no expiry")
+
+	if err := flagSet.Parse(args); err != nil {
+		return fmt.Errorf("%w: token: %v", shrterr.ErrUnknownCommand, err)
+	}
+	if flagSet.NArg() != 1 {
+		return fmt.Errorf("%w: token expects a user id", shrterr.ErrUnknownCommand)
+	}
+
+	userID, err := uuid.Parse(flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if err := auth.SetSecretKey(*c.cfg.SecretKey); err != nil {
+		return fmt.Errorf("SECRET_KEY: %w", err)
+	}
+
+	claims := auth.Claims{UserID: userID}
+	if *admin {
+		claims.Role = auth.RoleAdmin
+	}
+	if *ttl > 0 {
+		now := time.Now()
+		claims.IssuedAt = jwt.NewNumericDate(now)
+		claims.ExpiresAt = jwt.NewNumericDate(now.Add(*ttl))
+	}
+
+	token, err := auth.CreateTokenWithClaims(claims)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(c.out, token)
+	return err
+}
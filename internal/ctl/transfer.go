@@ -0,0 +1,91 @@
+package ctl
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/repository/database"
+	"github.com/mp1947/ya-url-shortener/internal/transfer"
+)
+
+// transferLinks runs "transfer [-dry-run] [-batch-size n] to-database|to-eventlog", moving the links
+// of the event log at FILE_STORAGE_PATH to the database at DATABASE_DSN or the other way round.
+// The server must not be running on either storage.
+func (c *command) transferLinks(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("transfer", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	dryRun := flagSet.Bool("dry-run", false, "report what would be written without writing")
+	batchSize := flagSet.Int("batch-size", transfer.DefaultBatchSize, "number of links written at once")
+
+	if err := flagSet.Parse(args); err != nil {
+		return fmt.Errorf("%w: transfer: %v", shrterr.ErrUnknownCommand, err)
+	}
+	direction := flagSet.Arg(0)
+	if flagSet.NArg() != 1 || (direction != "to-database" && direction != "to-eventlog") {
+		return fmt.Errorf("%w: transfer expects one of to-database or to-eventlog", shrterr.ErrUnknownCommand)
+	}
+
+	if *c.cfg.DatabaseDSN == "" || *c.cfg.FileStoragePath == "" {
+		return fmt.Errorf("%w: transfer requires DATABASE_DSN and FILE_STORAGE_PATH", shrterr.ErrStorageTypeMismatch)
+	}
+
+	repo, _, closeRepo, err := c.openRepository(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeRepo()
+
+	db := repo.(*database.Database)
+	opts := transfer.Options{BatchSize: *batchSize, DryRun: *dryRun}
+
+	var report transfer.Report
+	if direction == "to-database" {
+		if err := db.CheckMigrations(ctx); err != nil {
+			return fmt.Errorf("%w, run migrate up first", err)
+		}
+
+		report, err = transfer.Run(ctx, transfer.EventLogSource(*c.cfg.FileStoragePath), transfer.DatabaseTarget{DB: db}, opts)
+	} else {
+		target, openErr := transfer.OpenEventLog(*c.cfg.FileStoragePath)
+		if openErr != nil {
+			return openErr
+		}
+		defer target.Close()
+
+		report, err = transfer.Run(ctx, transfer.DatabaseSource(db), target, opts)
+	}
+
+	if printErr := c.printReport(report, *dryRun); printErr != nil && err == nil {
+		err = printErr
+	}
+	return err
+}
+
+// printReport prints the summary of a transfer followed by its conflicts.
+func (c *command) printReport(report transfer.Report, dryRun bool) error {
+	written := "wrote"
+	if dryRun {
+		written = "would write"
+	}
+
+	_, err := fmt.Fprintf(c.out, "read %d links, %s %d, %d already present, %d conflicts\n",
+		report.Read, written, report.Written, report.Present, len(report.Conflicts))
+	if err != nil || len(report.Conflicts) == 0 {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONFLICT\tSHORT_URL\tORIGINAL_URL\tEXISTING")
+	for _, conflict := range report.Conflicts {
+		existing := conflict.Reason
+		if existing == "" {
+			existing = conflict.Existing.ShortURLID + " " + conflict.Existing.OriginalURL
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", conflict.Kind, conflict.Link.ShortURLID, conflict.Link.OriginalURL, existing)
+	}
+	return w.Flush()
+}
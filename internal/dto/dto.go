@@ -1,6 +1,8 @@
 // Package dto provides data transfer objects for URL shortening requests and responses.
 package dto
 
+import "time"
+
 // ShortenRequest represents a request payload for shortening a URL.
 type ShortenRequest struct {
 	URL string `json:"url" binding:"required"`
@@ -24,6 +26,15 @@ type BatchShortenResponse struct {
 	ShortURL      string `json:"short_url"`
 }
 
+// BulkShortenResult represents the outcome of shortening one URL of a streamed or imported bulk request.
+// ShortURL is set unless the URL could not be shortened, Error unless it was shortened by the request:
+// a URL which was already shortened carries both.
+type BulkShortenResult struct {
+	CorrelationID string
+	ShortURL      string
+	Error         string
+}
+
 // ShortenURLsByUserID represents a mapping between a shortened URL and its original URL.
 type ShortenURLsByUserID struct {
 	ShortURL    string `json:"short_url"`
@@ -31,8 +42,89 @@ type ShortenURLsByUserID struct {
 }
 
 // InternalStatsResp represents the response structure containing statistics about
-// the number of shortened URLs and registered users in the system.
+// the number of shortened URLs and registered users in the system, and about the
+// cache of short URL lookups when it is enabled.
 type InternalStatsResp struct {
-	URLs  int `json:"urls"`
-	Users int `json:"user"`
+	URLs  int         `json:"urls"`
+	Users int         `json:"user"`
+	Cache *CacheStats `json:"cache,omitempty"`
+}
+
+// CacheStats represents the statistics of the cache of short URL lookups since the service started.
+// Hits include NegativeHits, the lookups of unknown short URLs answered by the cache, and HitRatio is
+// the fraction of lookups answered by the cache. Entries is omitted when the store of the cache cannot
+// count its values cheaply, as the Redis one.
+type CacheStats struct {
+	Hits         int64   `json:"hits"`
+	NegativeHits int64   `json:"negative_hits"`
+	Misses       int64   `json:"misses"`
+	Entries      *int    `json:"entries,omitempty"`
+	HitRatio     float64 `json:"hit_ratio"`
+}
+
+// DeletionJob represents the status of an asynchronous deletion of short URLs: its state is one of
+// pending, running, done or failed, and Deleted is the number of deleted short URLs once it is done.
+type DeletionJob struct {
+	ID        string    `json:"id"`
+	State     string    `json:"state"`
+	ShortURLs int       `json:"short_urls"`
+	Deleted   int64     `json:"deleted"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AuthResponse represents the response returned after a successful identity provider login,
+// containing the shortener user ID, the issued token and the number of anonymous URLs linked to the user.
+type AuthResponse struct {
+	UserID     string `json:"user_id"`
+	Token      string `json:"token"`
+	LinkedURLs int64  `json:"linked_urls"`
+}
+
+// AdminURL represents a shortened URL as seen by an operator, including its owner
+// and its deleted and disabled flags.
+type AdminURL struct {
+	ShortURLID  string `json:"short_url_id"`
+	ShortURL    string `json:"short_url"`
+	OriginalURL string `json:"original_url"`
+	UserID      string `json:"user_id,omitempty"`
+	IsDeleted   bool   `json:"is_deleted"`
+	IsDisabled  bool   `json:"is_disabled"`
+}
+
+// AdminReassignRequest represents an operator request to transfer short URLs to another user.
+type AdminReassignRequest struct {
+	ShortURLs []string `json:"short_urls" binding:"required"`
+	UserID    string   `json:"user_id" binding:"required"`
+}
+
+// AdminActionResponse represents the number of short URLs affected by an operator action.
+type AdminActionResponse struct {
+	Affected int64 `json:"affected"`
+}
+
+// LogLevel represents the minimum level of the entries written by the logger.
+type LogLevel struct {
+	Level string `json:"level" binding:"required"`
+}
+
+// ExportedURL represents a shortened URL of the user as written by the bulk export,
+// including its identifier and its deleted and disabled flags.
+type ExportedURL struct {
+	ShortURLID  string `json:"short_url_id"`
+	ShortURL    string `json:"short_url"`
+	OriginalURL string `json:"original_url"`
+	IsDeleted   bool   `json:"is_deleted"`
+	IsDisabled  bool   `json:"is_disabled"`
+}
+
+// ImportResult represents the outcome of shortening one row of a bulk import.
+// Row is the 1-based number of the data row in the imported body. ShortURL and Error are set as those of
+// BulkShortenResult.
+type ImportResult struct {
+	Row           int    `json:"row"`
+	CorrelationID string `json:"correlation_id"`
+	ShortURL      string `json:"short_url,omitempty"`
+	Error         string `json:"error,omitempty"`
 }
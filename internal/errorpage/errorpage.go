@@ -0,0 +1,149 @@
+// Package errorpage renders the HTML pages answering the browsers which follow unknown or deleted
+// short URLs. The pages are rendered from templates embedded in the binary, each of which can be
+// overridden by a file of the same name in a directory. Clients which do not prefer HTML, such as
+// API clients, are answered with problem details instead.
+package errorpage
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
+)
+
+const contentTypeHTML = "text/html; charset=utf-8"
+
+// embedded holds the default templates of the pages.
+//
+//go:embed templates/*.html
+var embedded embed.FS
+
+// templateNames maps the kinds of errors answered with a page to the file name of its template.
+var templateNames = map[shrterr.Kind]string{
+	shrterr.KindNotFound: "not_found.html",
+	shrterr.KindGone:     "gone.html",
+}
+
+// Data is the data the templates of the pages are executed with.
+type Data struct {
+	// Status is the HTTP status of the response and Title its status text.
+	Status int
+	Title  string
+	// Detail describes the error, as the problem details would.
+	Detail string
+	// Path is the path of the requested short URL.
+	Path string
+	// RequestID is the ID of the request, empty if unknown.
+	RequestID string
+}
+
+// Pages holds the parsed templates of the error pages.
+type Pages struct {
+	templates map[shrterr.Kind]*template.Template
+}
+
+// Load parses the templates of the error pages. When dir is not empty, the templates it contains,
+// not_found.html and gone.html, override the embedded ones. Returns an error if dir is not a readable
+// directory or a template cannot be parsed.
+func Load(dir string) (*Pages, error) {
+	var overrides fs.FS
+	if dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("error reading error pages directory: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("error pages path %s is not a directory", dir)
+		}
+		overrides = os.DirFS(dir)
+	}
+
+	p := &Pages{templates: make(map[shrterr.Kind]*template.Template, len(templateNames))}
+	for kind, name := range templateNames {
+		content, err := readTemplate(overrides, name)
+		if err != nil {
+			return nil, err
+		}
+
+		tmpl, err := template.New(name).Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing error page template: %w", err)
+		}
+		p.templates[kind] = tmpl
+	}
+
+	return p, nil
+}
+
+// Default returns the error pages of the embedded templates.
+func Default() *Pages {
+	p, err := Load("")
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// readTemplate returns the content of the template name from overrides, or the embedded one when
+// overrides is nil or has none.
+func readTemplate(overrides fs.FS, name string) ([]byte, error) {
+	if overrides != nil {
+		content, err := fs.ReadFile(overrides, name)
+		if err == nil {
+			return content, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("error reading error page template: %w", err)
+		}
+	}
+	return embedded.ReadFile("templates/" + name)
+}
+
+// Write aborts the request with err. Clients preferring HTML over JSON are answered with the page of
+// the kind of err when there is one, the other clients and errors with problem details as by
+// problem.Write. A nil Pages always answers with problem details.
+func (p *Pages) Write(c *gin.Context, err error) {
+	c.Header("Vary", "Accept")
+
+	tmpl, ok := p.template(shrterr.KindOf(err))
+	if !ok || c.NegotiateFormat(problem.ContentType, gin.MIMEJSON, gin.MIMEHTML) != gin.MIMEHTML {
+		problem.Write(c, err)
+		return
+	}
+
+	d := problem.New(err)
+	data := Data{
+		Status:    d.Status,
+		Title:     d.Title,
+		Detail:    d.Detail,
+		Path:      c.Request.URL.Path,
+		RequestID: requestid.FromContext(c.Request.Context()),
+	}
+
+	var page bytes.Buffer
+	if execErr := tmpl.Execute(&page, data); execErr != nil {
+		_ = c.Error(fmt.Errorf("error rendering error page: %w", execErr))
+		problem.Write(c, err)
+		return
+	}
+
+	c.Data(d.Status, contentTypeHTML, page.Bytes())
+	c.Abort()
+}
+
+// template returns the template of the page of kind, if any.
+func (p *Pages) template(kind shrterr.Kind) (*template.Template, bool) {
+	if p == nil {
+		return nil, false
+	}
+	tmpl, ok := p.templates[kind]
+	return tmpl, ok
+}
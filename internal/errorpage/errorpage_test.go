@@ -0,0 +1,77 @@
+package errorpage_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/errorpage"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// write answers a browser request of path with err written by p.
+func write(p *errorpage.Pages, path string, err error) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/:id", func(c *gin.Context) { p.Write(c, err) })
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("templates of the directory override the embedded ones", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "not_found.html"), []byte("<p>{{.Status}} {{.Title}}: {{.Path}}</p>"), 0o600))
+
+		p, err := errorpage.Load(dir)
+		require.NoError(t, err)
+
+		w := write(p, "/abc", shrterr.ErrShortURLNotFound)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "<p>404 Not Found: /abc</p>", w.Body.String())
+		assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+		w = write(p, "/abc", shrterr.ErrShortURLDeleted)
+		assert.Equal(t, http.StatusGone, w.Code)
+		assert.Contains(t, w.Body.String(), "has been deleted", "the embedded gone page is kept")
+	})
+
+	t.Run("invalid templates are reported", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "gone.html"), []byte("{{.Status"), 0o600))
+
+		_, err := errorpage.Load(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing directories are reported", func(t *testing.T) {
+		_, err := errorpage.Load(filepath.Join(t.TempDir(), "missing"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("errors without a page are written as problem details", func(t *testing.T) {
+		w := write(errorpage.Default(), "/abc", shrterr.ErrShortURLDisabled)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	})
+
+	t.Run("nil pages write problem details", func(t *testing.T) {
+		var p *errorpage.Pages
+		w := write(p, "/abc", shrterr.ErrShortURLNotFound)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	})
+}
@@ -4,14 +4,87 @@ package shrterr
 import "errors"
 
 // Package errors provides custom error variables used throughout the application for consistent error handling.
+// The errors which can be reported to clients are Error values, whose Kind decides how they are reported.
 //
 // The defined errors include:
 //   - ErrOriginalURLAlreadyExists: Indicates an attempt to add a URL that already exists in the storage.
 //   - ErrUnableToDetermineStorageType: Indicates that the application cannot identify or select a valid storage type for operation.
+//   - ErrOIDCStateMismatch: Indicates that the OIDC callback state does not match the one issued at login.
+//   - ErrOIDCNonceMismatch: Indicates that the ID token nonce does not match the one issued at login.
+//   - ErrOIDCMissingIDToken: Indicates that the identity provider token response has no ID token.
+//   - ErrAPIKeyNotFound: Indicates that the presented API key is unknown or revoked.
+//   - ErrInvalidAPIKey: Indicates that the presented API key does not grant access to the administrative API.
+//   - ErrAdminRoleRequired: Indicates that the caller does not hold the admin role.
+//   - ErrShortURLNotFound: Indicates that no URL is stored under the requested short URL ID.
+//   - ErrShortURLDeleted: Indicates that the URL stored under the requested short URL ID has been deleted.
+//   - ErrShortURLDisabled: Indicates that the URL stored under the requested short URL ID has been disabled by an operator.
+//   - ErrAuditLogPathUndefined: Indicates that no audit log file is configured for the in-memory storage.
+//   - ErrMigrationsPending: Indicates that the database schema is older than the embedded migrations.
+//   - ErrDeletionWorkerStopped: Indicates that the background worker processing deletions is not running.
+//   - ErrShuttingDown: Indicates that the application is shutting down and no longer accepts traffic.
+//   - ErrUnknownCommand: Indicates that shortenerctl was invoked with an unknown command or missing arguments.
+//   - ErrStorageTypeMismatch: Indicates that a shortenerctl command does not support the configured storage.
+//   - ErrDeletionJobNotFound: Indicates that no deletion job of the user has the requested ID.
+//   - ErrSecretKeyNotSet: Indicates that no key signing the JWTs is configured.
+//   - ErrSecretKeyTooShort: Indicates that the configured key signing the JWTs is too short.
 var (
 	// ErrOriginalURLAlreadyExists is returned when an attempt is made to add a URL that already exists in the storage.
-	ErrOriginalURLAlreadyExists = errors.New("original_url already exists")
+	ErrOriginalURLAlreadyExists = &Error{Kind: KindConflict, Message: "original_url already exists"}
 
 	// ErrUnableToDetermineStorageType is returned when the application cannot identify or select a valid storage type for operation.
 	ErrUnableToDetermineStorageType = errors.New("unable to determine storage type")
+
+	// ErrOIDCStateMismatch is returned when the state received on the OIDC callback does not match the one issued at login.
+	ErrOIDCStateMismatch = &Error{Kind: KindInvalidInput, Message: "oidc state mismatch", Field: "state"}
+
+	// ErrOIDCNonceMismatch is returned when the nonce of a verified ID token does not match the one issued at login.
+	ErrOIDCNonceMismatch = &Error{Kind: KindUnauthorized, Message: "oidc nonce mismatch"}
+
+	// ErrOIDCMissingIDToken is returned when the identity provider token response does not contain an ID token.
+	ErrOIDCMissingIDToken = &Error{Kind: KindUnauthorized, Message: "oidc token response has no id_token"}
+
+	// ErrAPIKeyNotFound is returned when the presented API key is unknown or has been revoked.
+	ErrAPIKeyNotFound = &Error{Kind: KindUnauthorized, Message: "api key not found"}
+
+	// ErrInvalidAPIKey is returned when the presented API key is unknown, revoked or does not hold the admin role.
+	ErrInvalidAPIKey = &Error{Kind: KindUnauthorized, Message: "invalid api key"}
+
+	// ErrAdminRoleRequired is returned when a caller without the admin role calls the administrative API.
+	ErrAdminRoleRequired = &Error{Kind: KindForbidden, Message: "admin role required"}
+
+	// ErrShortURLNotFound is returned when no URL is stored under the requested short URL ID.
+	ErrShortURLNotFound = &Error{Kind: KindNotFound, Message: "short url not found"}
+
+	// ErrShortURLDeleted is returned when the URL stored under the requested short URL ID has been deleted.
+	ErrShortURLDeleted = &Error{Kind: KindGone, Message: "short url has been deleted"}
+
+	// ErrShortURLDisabled is returned when the URL stored under the requested short URL ID has been disabled by an operator.
+	ErrShortURLDisabled = &Error{Kind: KindForbidden, Message: "short url has been disabled"}
+
+	// ErrAuditLogPathUndefined is returned when the in-memory storage is used without an audit log file path.
+	ErrAuditLogPathUndefined = errors.New("audit log path is not defined")
+
+	// ErrMigrationsPending is returned when the database schema version is older than the latest embedded migration.
+	ErrMigrationsPending = &Error{Kind: KindUnavailable, Message: "database migrations are pending"}
+
+	// ErrDeletionWorkerStopped is returned when the background worker processing deletions is not running.
+	ErrDeletionWorkerStopped = &Error{Kind: KindUnavailable, Message: "deletion worker is not running"}
+
+	// ErrShuttingDown is returned when the application is shutting down and no longer accepts traffic.
+	ErrShuttingDown = &Error{Kind: KindUnavailable, Message: "shutting down"}
+
+	// ErrUnknownCommand is returned when shortenerctl is invoked with an unknown command or missing arguments.
+	ErrUnknownCommand = errors.New("unknown command")
+
+	// ErrStorageTypeMismatch is returned when a shortenerctl command does not support the configured storage.
+	ErrStorageTypeMismatch = errors.New("command is not supported by the configured storage")
+
+	// ErrDeletionJobNotFound is returned when no deletion job of the user has the requested ID, or its status has expired.
+	ErrDeletionJobNotFound = &Error{Kind: KindNotFound, Message: "deletion job not found"}
+
+	// ErrSecretKeyNotSet is returned when a JWT is issued or verified before the key signing them is set.
+	ErrSecretKeyNotSet = errors.New("secret key is not set")
+
+	// ErrSecretKeyTooShort is returned when the key signing the JWTs is shorter than the minimum length.
+	ErrSecretKeyTooShort = errors.New("secret key is too short")
 )
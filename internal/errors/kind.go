@@ -0,0 +1,149 @@
+package shrterr
+
+import (
+	"errors"
+	"time"
+)
+
+// Kind classifies a domain error by the way it is reported to clients, whatever the transport.
+type Kind int
+
+const (
+	// KindInternal is an unexpected failure, whose details are not reported to clients.
+	KindInternal Kind = iota
+	// KindNotFound reports that the requested resource does not exist.
+	KindNotFound
+	// KindGone reports that the requested resource existed but has been deleted.
+	KindGone
+	// KindConflict reports that the request conflicts with the stored state, such as an already shortened URL.
+	KindConflict
+	// KindInvalidInput reports a malformed or invalid request.
+	KindInvalidInput
+	// KindUnsupportedMediaType reports a request body of a media type the endpoint does not accept.
+	KindUnsupportedMediaType
+	// KindUnauthorized reports missing or invalid credentials.
+	KindUnauthorized
+	// KindForbidden reports that the caller is not allowed to perform the request.
+	KindForbidden
+	// KindRateLimited reports that the caller exceeded its rate limit.
+	KindRateLimited
+	// KindUnavailable reports that the service cannot handle the request for now.
+	KindUnavailable
+)
+
+var kindNames = [...]string{
+	KindInternal:             "internal",
+	KindNotFound:             "not_found",
+	KindGone:                 "gone",
+	KindConflict:             "conflict",
+	KindInvalidInput:         "invalid_input",
+	KindUnsupportedMediaType: "unsupported_media_type",
+	KindUnauthorized:         "unauthorized",
+	KindForbidden:            "forbidden",
+	KindRateLimited:          "rate_limited",
+	KindUnavailable:          "unavailable",
+}
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return kindNames[KindInternal]
+	}
+	return kindNames[k]
+}
+
+// Error is a domain error of a given Kind. Its message is meant to be reported to clients,
+// while its cause, if any, is only logged.
+type Error struct {
+	// Kind classifies the error.
+	Kind Kind
+	// Message describes the error to clients.
+	Message string
+	// Field is the name of the invalid request field of a KindInvalidInput error, empty if unknown.
+	Field string
+	// RetryAfter is the time after which a KindRateLimited or KindUnavailable request may succeed, zero if unknown.
+	RetryAfter time.Duration
+	// Err is the cause of the error, nil if there is none.
+	Err error
+}
+
+// Error returns the message of the error followed by its cause.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the cause of the error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NotFound returns a KindNotFound error with the given message.
+func NotFound(message string) error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+// Gone returns a KindGone error with the given message.
+func Gone(message string) error {
+	return &Error{Kind: KindGone, Message: message}
+}
+
+// Conflict returns a KindConflict error with the given message.
+func Conflict(message string) error {
+	return &Error{Kind: KindConflict, Message: message}
+}
+
+// InvalidInput returns a KindInvalidInput error with the given message about the request field, which may be empty.
+func InvalidInput(field, message string) error {
+	return &Error{Kind: KindInvalidInput, Message: message, Field: field}
+}
+
+// UnsupportedMediaType returns a KindUnsupportedMediaType error with the given message.
+func UnsupportedMediaType(message string) error {
+	return &Error{Kind: KindUnsupportedMediaType, Message: message}
+}
+
+// Unauthorized returns a KindUnauthorized error with the given message.
+func Unauthorized(message string) error {
+	return &Error{Kind: KindUnauthorized, Message: message}
+}
+
+// Forbidden returns a KindForbidden error with the given message.
+func Forbidden(message string) error {
+	return &Error{Kind: KindForbidden, Message: message}
+}
+
+// RateLimited returns a KindRateLimited error for a request which may be retried after retryAfter.
+func RateLimited(retryAfter time.Duration) error {
+	return &Error{Kind: KindRateLimited, Message: "too many requests", RetryAfter: retryAfter}
+}
+
+// Unavailable returns a KindUnavailable error with the given message caused by err, which may be nil.
+func Unavailable(message string, err error) error {
+	return &Error{Kind: KindUnavailable, Message: message, Err: err}
+}
+
+// Classify returns the first Error in the chain of err. Errors without one are unexpected failures,
+// returned as a KindInternal Error caused by err. Classify returns nil when err is nil.
+func Classify(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return &Error{Kind: KindInternal, Message: "internal server error", Err: err}
+}
+
+// KindOf returns the Kind of the first Error in the chain of err, KindInternal if there is none.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
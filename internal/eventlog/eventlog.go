@@ -4,8 +4,10 @@ package eventlog
 import (
 	"encoding/json"
 	"os"
+	"time"
 
 	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
 )
 
 // Event holds URL shortening event data.
@@ -15,6 +17,7 @@ type Event struct {
 	OriginalURL string `json:"original_url"`
 	UserID      string `json:"user_uuid"`
 	IsDeleted   bool   `json:"is_deleted"`
+	IsDisabled  bool   `json:"is_disabled"`
 }
 
 // EventProcessor logs events to a file.
@@ -26,7 +29,12 @@ type EventProcessor struct {
 
 // NewEventProcessor creates an EventProcessor with the given config.
 func NewEventProcessor(cfg config.Config) (*EventProcessor, error) {
-	file, err := os.OpenFile(*cfg.FileStoragePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+	return Open(*cfg.FileStoragePath)
+}
+
+// Open creates an EventProcessor appending events to the file at path, which is created if needed.
+func Open(path string) (*EventProcessor, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +47,13 @@ func NewEventProcessor(cfg config.Config) (*EventProcessor, error) {
 
 // WriteEvent encodes the provided Event and writes it using the underlying Encoder.
 // It returns an error if the encoding or writing process fails.
+// The latency of the write is observed in the event log write duration metric.
 func (ep *EventProcessor) WriteEvent(e *Event) error {
+	t := time.Now()
+	defer func() {
+		metrics.EventLogWriteDuration.Observe(time.Since(t).Seconds())
+	}()
+
 	return ep.Encoder.Encode(&e)
 }
 
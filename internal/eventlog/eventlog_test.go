@@ -1,16 +1,22 @@
 package eventlog_test
 
 import (
+	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
 	"github.com/mp1947/ya-url-shortener/internal/eventlog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEventProcessor(t *testing.T) {
-	cfg := config.InitConfig()
+	cfg, err := config.Load(nil, []string{authtest.Environ}, nil)
+	assert.NoError(t, err)
 
 	ep, err := eventlog.NewEventProcessor(*cfg)
 
@@ -38,3 +44,50 @@ func TestEventProcessor(t *testing.T) {
 		assert.Greater(t, after, before)
 	})
 }
+
+func TestScan(t *testing.T) {
+	log := strings.Join([]string{
+		`{"uuid":"1","short_url":"aaa","original_url":"https://a.example.com","user_uuid":"user-1"}`,
+		`{"uuid":"2","short_url":"bbb","original_url":"https://b.example.com","user_uuid":"user-1"}`,
+		`{"uuid":"1","short_url":"aaa","original_url":"https://a.example.com","user_uuid":"user-2","is_disabled":true}`,
+		`{"uuid":"2","short_url":"bbb","original_url":"https://b.example.com","user_uuid":"user-1","is_deleted":true}`,
+	}, "\n")
+
+	var (
+		lines  []int
+		events []eventlog.Event
+	)
+	err := eventlog.Scan(strings.NewReader(log), func(line int, e eventlog.Event) error {
+		lines = append(lines, line)
+		events = append(events, e)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, []int{1, 2, 3, 4}, lines)
+	assert.Equal(t, "aaa", events[2].ShortURL)
+	assert.Equal(t, "user-2", events[2].UserID)
+	assert.True(t, events[2].IsDisabled)
+	assert.Equal(t, "bbb", events[3].ShortURL)
+	assert.True(t, events[3].IsDeleted)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = eventlog.Scan(strings.NewReader(log), func(line int, e eventlog.Event) error {
+		calls++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+
+	err = eventlog.Scan(strings.NewReader("not json"), func(line int, e eventlog.Event) error {
+		return nil
+	})
+	assert.ErrorContains(t, err, "line 1")
+
+	err = eventlog.ScanFile(filepath.Join(t.TempDir(), "missing.out"), func(line int, e eventlog.Event) error {
+		return errStop
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,52 @@
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// ScanFile calls fn for every event of the event log at path, as Scan does, reading the file one line
+// at a time. A missing file is an empty event log.
+func ScanFile(path string, fn func(line int, e Event) error) error {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return Scan(file, fn)
+}
+
+// Scan reads an event log from r and calls fn for every event in the order they were written, along with
+// the line of the event, starting at 1. A short URL has an event for every change, the latest one holding
+// its current owner and state. Unlike a restore, deleted short URLs are kept, their latest event having
+// IsDeleted set. Scan stops at the first error returned by fn.
+func Scan(r io.Reader, fn func(line int, e Event) error) error {
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if err := fn(line, event); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
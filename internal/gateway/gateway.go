@@ -0,0 +1,156 @@
+// Package gateway serves the Shortener gRPC service as a JSON/HTTP API generated by grpc-gateway
+// from the google.api.http annotations of the proto definition.
+//
+// Requests are forwarded in-process to the gRPC server over an in-memory listener, so the gRPC
+// interceptors (client IP, request ID, authentication, trusted subnet) apply to gateway calls as well.
+// Failed calls are answered with RFC 9457 problem details, like the other HTTP endpoints.
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	pb "github.com/mp1947/ya-url-shortener/internal/proto"
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	// Network is the network name of the in-process connections made by the gateway,
+	// as reported by the peer address of calls it forwards.
+	Network = "bufconn"
+
+	// ClientIPMetadataKey is the metadata key carrying the client IP resolved by the HTTP server.
+	// It is only honoured for calls received over Network.
+	ClientIPMetadataKey = "x-gateway-client-ip"
+
+	// OpenAPIPath is the path the OpenAPI document of the gateway is served at.
+	OpenAPIPath = "/v2/openapi.json"
+
+	bufferSize = 1 << 20
+)
+
+type tokenKey struct{}
+
+// WithToken returns a copy of ctx carrying the authentication token of the HTTP caller,
+// which the gateway forwards as the "authorization" metadata.
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// Gateway is an http.Handler transcoding JSON/HTTP requests to calls of the Shortener gRPC service.
+type Gateway struct {
+	mux      *runtime.ServeMux
+	listener *bufconn.Listener
+	conn     *grpc.ClientConn
+}
+
+// New creates a Gateway forwarding requests over an in-memory listener.
+// The gRPC server must be attached to the listener with Serve before requests are handled.
+func New(ctx context.Context) (*Gateway, error) {
+	listener := bufconn.Listen(bufferSize)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///"+Network,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(ignoreHeaders),
+		runtime.WithMetadata(annotate),
+		runtime.WithErrorHandler(writeError),
+	)
+
+	if err := pb.RegisterShortenerHandler(ctx, mux, conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	if err := mux.HandlePath(http.MethodGet, OpenAPIPath, serveOpenAPI); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return &Gateway{mux: mux, listener: listener, conn: conn}, nil
+}
+
+// ServeHTTP transcodes the request and forwards it to the gRPC server.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// Serve serves the in-process connections of the gateway with s. It blocks until s is stopped.
+func (g *Gateway) Serve(s *grpc.Server) error {
+	return s.Serve(g.listener)
+}
+
+// Close closes the client connection of the gateway.
+func (g *Gateway) Close() error {
+	return g.conn.Close()
+}
+
+// ignoreHeaders keeps HTTP headers out of the gRPC metadata. Authentication and the client IP
+// are resolved by the HTTP middleware and passed on by annotate, so callers cannot spoof them.
+func ignoreHeaders(string) (string, bool) {
+	return "", false
+}
+
+// annotate builds the metadata of a forwarded call from the token, client IP and request ID resolved by the HTTP middleware.
+func annotate(ctx context.Context, r *http.Request) metadata.MD {
+	md := metadata.MD{}
+
+	if token, _ := r.Context().Value(tokenKey{}).(string); token != "" {
+		md.Set("authorization", token)
+	}
+	if ip := clientip.StringFromContext(r.Context()); ip != "" {
+		md.Set(ClientIPMetadataKey, ip)
+	}
+	if id := requestid.FromContext(r.Context()); id != "" {
+		md.Set(requestid.MetadataKey, id)
+	}
+
+	return md
+}
+
+// writeError answers a failed call with the problem details of its gRPC status, forwarding the
+// header metadata sent by the server as runtime.MetadataHeaderPrefix headers, as grpc-gateway does.
+func writeError(
+	ctx context.Context,
+	_ *runtime.ServeMux,
+	_ runtime.Marshaler,
+	w http.ResponseWriter,
+	r *http.Request,
+	err error,
+) {
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for key, values := range md.HeaderMD {
+			for _, value := range values {
+				w.Header().Add(runtime.MetadataHeaderPrefix+key, value)
+			}
+		}
+	}
+
+	st := status.Convert(err)
+	problem.WriteStatus(w, r, st, runtime.HTTPStatusFromCode(st.Code()))
+}
+
+func serveOpenAPI(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(pb.OpenAPI)
+}
@@ -0,0 +1,114 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	"github.com/mp1947/ya-url-shortener/internal/gateway"
+	"github.com/mp1947/ya-url-shortener/internal/interceptor"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	pb "github.com/mp1947/ya-url-shortener/internal/proto"
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+func TestMain(m *testing.M) {
+	authtest.Init()
+	m.Run()
+}
+
+// echoServer answers ShortenURL with the caller resolved by the interceptors.
+type echoServer struct {
+	pb.UnimplementedShortenerServer
+}
+
+func (echoServer) ShortenURL(ctx context.Context, in *pb.ShortenURLReq) (*pb.ShortenURLResp, error) {
+	actor, _ := auth.ActorFromContext(ctx)
+	return &pb.ShortenURLResp{
+		ShortURL: in.Url + "|" + actor.ID + "|" + clientip.StringFromContext(ctx),
+	}, nil
+}
+
+func TestGateway(t *testing.T) {
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		interceptor.RequestIDUnaryInterceptor(zap.NewNop()),
+		interceptor.ClientIPUnaryInterceptor(clientip.NewResolver(nil)),
+		interceptor.AuthUnaryInterceptor,
+	))
+	pb.RegisterShortenerServer(grpcServer, echoServer{})
+
+	gw, err := gateway.New(context.Background())
+	require.NoError(t, err)
+	defer gw.Close()
+
+	go func() { _ = gw.Serve(grpcServer) }()
+	defer grpcServer.Stop()
+
+	userID := uuid.New()
+	token, err := auth.CreateToken(userID)
+	require.NoError(t, err)
+
+	t.Run("forwards token and client ip", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v2/shorten", strings.NewReader(`{"url":"https://example.com"}`))
+		req.Header.Set("Grpc-Metadata-"+gateway.ClientIPMetadataKey, "10.0.0.1")
+		ctx := clientip.WithIP(req.Context(), net.ParseIP("192.0.2.10"))
+		req = req.WithContext(gateway.WithToken(ctx, token))
+
+		w := httptest.NewRecorder()
+		gw.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "https://example.com|"+userID.String()+"|192.0.2.10", resp["short_url"])
+	})
+
+	t.Run("unimplemented method", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		gw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/user/urls", nil))
+		assert.Equal(t, http.StatusNotImplemented, w.Code)
+	})
+
+	t.Run("forwards request id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v2/user/urls", nil)
+		req = req.WithContext(requestid.WithID(req.Context(), "req-gateway"))
+
+		w := httptest.NewRecorder()
+		gw.ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotImplemented, w.Code)
+		assert.Equal(t, "req-gateway", w.Header().Get("Grpc-Metadata-"+requestid.MetadataKey))
+
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+		var resp problem.Details
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "req-gateway", resp.RequestID)
+		assert.Equal(t, http.StatusNotImplemented, resp.Status)
+		assert.Equal(t, "/v2/user/urls", resp.Instance)
+	})
+
+	t.Run("openapi document", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		gw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, gateway.OpenAPIPath, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var doc struct {
+			Paths map[string]any `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Contains(t, doc.Paths, "/v2/shorten")
+		assert.Contains(t, doc.Paths, "/v2/user/urls")
+	})
+}
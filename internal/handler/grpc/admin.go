@@ -0,0 +1,185 @@
+package handlegrpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/audit"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	pb "github.com/mp1947/ya-url-shortener/internal/proto"
+	"github.com/mp1947/ya-url-shortener/internal/service"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminService implements the gRPC Admin service used by operators.
+// Access control is enforced by interceptor.AdminUnaryInterceptor before any method is called.
+type AdminService struct {
+	pb.UnimplementedAdminServer
+	Service service.Service
+	Cfg     *config.Config
+}
+
+// NewAdminService creates and returns a new AdminService instance using the provided service.
+func NewAdminService(s service.Service, cfg *config.Config) *AdminService {
+	return &AdminService{Service: s, Cfg: cfg}
+}
+
+// GetURL looks up any short URL together with its owner and state flags.
+// Returns codes.NotFound if nothing is stored under the given short URL ID.
+func (a *AdminService) GetURL(
+	ctx context.Context,
+	in *pb.AdminGetURLReq,
+) (*pb.AdminURL, error) {
+	if in.ShortURLID == "" {
+		return nil, problem.Error(shrterr.InvalidInput("short_url_id", "short url id cannot be empty"))
+	}
+
+	url, err := a.Service.AdminGetURL(ctx, in.ShortURLID)
+	if err != nil {
+		return nil, problem.Error(err)
+	}
+
+	return toPBAdminURL(url), nil
+}
+
+// GetUserURLs lists every short URL owned by the given user, including deleted and disabled ones.
+func (a *AdminService) GetUserURLs(
+	ctx context.Context,
+	in *pb.AdminGetUserURLsReq,
+) (*pb.AdminGetUserURLsResp, error) {
+	if in.UserID == "" {
+		return nil, problem.Error(shrterr.InvalidInput("user_id", "user id cannot be empty"))
+	}
+
+	urls, err := a.Service.AdminGetUserURLs(ctx, in.UserID)
+	if err != nil {
+		return nil, problem.Error(err)
+	}
+
+	response := &pb.AdminGetUserURLsResp{
+		Urls: make([]*pb.AdminURL, 0, len(urls)),
+	}
+
+	for _, url := range urls {
+		response.Urls = append(response.Urls, toPBAdminURL(url))
+	}
+
+	return response, nil
+}
+
+// SetURLsDisabled disables or re-enables the given short URLs regardless of their owner.
+func (a *AdminService) SetURLsDisabled(
+	ctx context.Context,
+	in *pb.AdminSetURLsDisabledReq,
+) (*pb.AdminActionResp, error) {
+	if len(in.ShortURLIDs) == 0 {
+		return nil, problem.Error(shrterr.InvalidInput("short_url_ids", "no short URLs provided"))
+	}
+
+	affected, err := a.Service.AdminSetURLsDisabled(ctx, in.ShortURLIDs, in.Disabled)
+	if err != nil {
+		return nil, problem.Error(err)
+	}
+
+	return &pb.AdminActionResp{Affected: affected}, nil
+}
+
+// DeleteURLs synchronously deletes the given short URLs regardless of their owner.
+func (a *AdminService) DeleteURLs(
+	ctx context.Context,
+	in *pb.AdminDeleteURLsReq,
+) (*pb.AdminActionResp, error) {
+	if len(in.ShortURLIDs) == 0 {
+		return nil, problem.Error(shrterr.InvalidInput("short_url_ids", "no short URLs provided"))
+	}
+
+	affected, err := a.Service.AdminDeleteURLs(ctx, in.ShortURLIDs)
+	if err != nil {
+		return nil, problem.Error(err)
+	}
+
+	return &pb.AdminActionResp{Affected: affected}, nil
+}
+
+// ReassignURLs transfers the given short URLs to another user.
+func (a *AdminService) ReassignURLs(
+	ctx context.Context,
+	in *pb.AdminReassignURLsReq,
+) (*pb.AdminActionResp, error) {
+	if len(in.ShortURLIDs) == 0 {
+		return nil, problem.Error(shrterr.InvalidInput("short_url_ids", "no short URLs provided"))
+	}
+
+	if _, err := uuid.Parse(in.UserID); err != nil {
+		return nil, problem.Error(shrterr.InvalidInput("user_id", "user id is not a valid uuid"))
+	}
+
+	affected, err := a.Service.AdminReassignURLs(ctx, in.ShortURLIDs, in.UserID)
+	if err != nil {
+		return nil, problem.Error(err)
+	}
+
+	return &pb.AdminActionResp{Affected: affected}, nil
+}
+
+// QueryAudit returns the newest audit entries matching the request filter.
+func (a *AdminService) QueryAudit(
+	ctx context.Context,
+	in *pb.AdminQueryAuditReq,
+) (*pb.AdminQueryAuditResp, error) {
+	if in.Limit < 0 {
+		return nil, problem.Error(shrterr.InvalidInput("limit", "limit cannot be negative"))
+	}
+
+	filter := audit.Filter{
+		UserID:   in.UserID,
+		ShortURL: in.ShortURLID,
+		Limit:    int(in.Limit),
+	}
+	if in.From != nil {
+		filter.From = in.From.AsTime()
+	}
+	if in.To != nil {
+		filter.To = in.To.AsTime()
+	}
+
+	entries, err := a.Service.AdminQueryAudit(ctx, filter)
+	if err != nil {
+		return nil, problem.Error(err)
+	}
+
+	response := &pb.AdminQueryAuditResp{
+		Entries: make([]*pb.AuditEntry, 0, len(entries)),
+	}
+
+	for _, e := range entries {
+		response.Entries = append(response.Entries, &pb.AuditEntry{
+			Time:         timestamppb.New(e.Time),
+			ActorID:      e.ActorID,
+			AuthMethod:   e.AuthMethod,
+			ClientIP:     e.ClientIP,
+			Operation:    e.Operation,
+			ShortURLs:    e.ShortURLs,
+			TargetUserID: e.TargetUser,
+			Result:       e.Result,
+			Error:        e.Error,
+		})
+	}
+
+	return response, nil
+}
+
+// toPBAdminURL converts an admin URL DTO to its protobuf representation.
+func toPBAdminURL(url dto.AdminURL) *pb.AdminURL {
+	return &pb.AdminURL{
+		ShortURLID:  url.ShortURLID,
+		ShortURL:    url.ShortURL,
+		OriginalURL: url.OriginalURL,
+		UserID:      url.UserID,
+		IsDeleted:   url.IsDeleted,
+		IsDisabled:  url.IsDisabled,
+	}
+}
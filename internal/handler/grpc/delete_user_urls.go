@@ -3,16 +3,17 @@ package handlegrpc
 import (
 	"context"
 
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
 	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 	pb "github.com/mp1947/ya-url-shortener/internal/proto"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // DeleteUserURLS handles a gRPC request to delete a batch of user URLs.
 // It extracts the user ID from the incoming context metadata, then initiates
 // a batch deletion of the provided short URLs associated with the user.
-// The operation is performed asynchronously, and the response status is set to "pending".
+// The operation is performed asynchronously, and the response status is set to "pending"
+// along with the ID of the deletion job.
 // Returns an error if the user metadata is not found in the context.
 func (g *GRPCService) DeleteUserURLS(
 	ctx context.Context,
@@ -22,16 +23,16 @@ func (g *GRPCService) DeleteUserURLS(
 	userID, _, err := g.getDataFromMD(ctx)
 
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, problem.Error(err)
 	}
 
 	if len(in.ShortURLs) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "no short URLs provided")
+		return nil, problem.Error(shrterr.InvalidInput("short_urls", "no short URLs provided"))
 	}
 
 	var result pb.DeleteURLSResp
 
-	g.Service.DeleteURLsBatch(
+	result.JobID = g.Service.DeleteURLsBatch(
 		ctx,
 		model.BatchDeleteShortURLs{
 			ShortURLs: in.ShortURLs,
@@ -0,0 +1,58 @@
+package handlegrpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	pb "github.com/mp1947/ya-url-shortener/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorStatuses(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	t.Run("already shortened url", func(t *testing.T) {
+		created, err := client.ShortenURL(ctx, &pb.ShortenURLReq{Url: "https://example.com/conflict"})
+		require.NoError(t, err)
+
+		_, err = client.ShortenURL(ctx, &pb.ShortenURLReq{Url: "https://example.com/conflict"})
+		st := status.Convert(err)
+		assert.Equal(t, codes.AlreadyExists, st.Code())
+
+		var resource *errdetails.ResourceInfo
+		for _, detail := range st.Details() {
+			if info, ok := detail.(*errdetails.ResourceInfo); ok {
+				resource = info
+			}
+		}
+		require.NotNil(t, resource)
+		assert.Equal(t, created.ShortURL, resource.ResourceName)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := client.ShortenURL(ctx, &pb.ShortenURLReq{})
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+
+		require.Len(t, st.Details(), 2)
+		info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		assert.Equal(t, "INVALID_INPUT", info.Reason)
+		assert.Equal(t, problem.Domain, info.Domain)
+
+		badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+		require.True(t, ok)
+		assert.Equal(t, "url", badRequest.FieldViolations[0].Field)
+	})
+
+	t.Run("unknown short url", func(t *testing.T) {
+		_, err := client.GetOriginalURLByShort(ctx, &pb.GetOriginalURLByShortReq{ShortURL: "localhost:9090/unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -2,16 +2,18 @@ package handlegrpc
 
 import (
 	"context"
-	"errors"
 	"strings"
 
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 	pb "github.com/mp1947/ya-url-shortener/internal/proto"
 )
 
 // GetOriginalURLByShort retrieves the original URL corresponding to a given shortened URL.
 // It parses the short URL to extract the unique identifier, then queries the service layer
-// to obtain the original URL. Returns an error if the identifier is invalid or if the service
-// fails to find the original URL.
+// to obtain the original URL. Returns codes.InvalidArgument if the identifier is invalid, codes.NotFound
+// if no URL is stored under it or the URL has been deleted, and codes.PermissionDenied if the URL has
+// been disabled by an operator.
 //
 // Parameters:
 //   - ctx: The context for the request, used for cancellation and deadlines.
@@ -26,7 +28,7 @@ func (g *GRPCService) GetOriginalURLByShort(
 ) (*pb.GetOriginalURLByShortResp, error) {
 
 	if in.ShortURL == "" {
-		return nil, errors.New("short URL cannot be empty")
+		return nil, problem.Error(shrterr.InvalidInput("short_url", "short URL cannot be empty"))
 	}
 
 	var response pb.GetOriginalURLByShortResp
@@ -38,12 +40,19 @@ func (g *GRPCService) GetOriginalURLByShort(
 	if len(parsedURL) >= 1 {
 		id = parsedURL[len(parsedURL)-1]
 	} else {
-		return nil, errors.New("id not correct")
+		return nil, problem.Error(shrterr.InvalidInput("short_url", "id not correct"))
 	}
 
 	url, err := g.Service.GetOriginalURL(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, problem.Error(err)
+	}
+
+	switch {
+	case url.IsDeleted:
+		return nil, problem.Error(shrterr.ErrShortURLDeleted)
+	case url.IsDisabled:
+		return nil, problem.Error(shrterr.ErrShortURLDisabled)
 	}
 
 	response.OriginalURL = url.OriginalURL
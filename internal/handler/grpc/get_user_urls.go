@@ -4,9 +4,8 @@ import (
 	"context"
 	"strings"
 
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 	pb "github.com/mp1947/ya-url-shortener/internal/proto"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // GetUserURLS retrieves all URLs associated with the current user.
@@ -21,12 +20,12 @@ func (g *GRPCService) GetUserURLS(
 	userID, _, err := g.getDataFromMD(ctx)
 
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, problem.Error(err)
 	}
 
 	urls, err := g.Service.GetUserURLs(ctx, userID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error retrieving user URLs: %v", err)
+		return nil, problem.Error(err)
 	}
 
 	response := &pb.GetUserURLSResp{
@@ -0,0 +1,41 @@
+package handlegrpc
+
+import (
+	"context"
+
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	pb "github.com/mp1947/ya-url-shortener/internal/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// GetInternalStats returns the number of shortened URLs and users stored by the service,
+// along with the statistics of the cache of short URL lookups when it is enabled.
+// Access is restricted by interceptor.TrustedSubnetUnaryInterceptor before the method is called.
+func (g *GRPCService) GetInternalStats(
+	ctx context.Context,
+	in *pb.Empty,
+) (*pb.InternalStatsResp, error) {
+	stats, err := g.Service.GetInternalStats(ctx)
+	if err != nil {
+		return nil, problem.Error(err)
+	}
+
+	resp := &pb.InternalStatsResp{
+		Urls:  int64(stats.URLs),
+		Users: int64(stats.Users),
+	}
+
+	if stats.Cache != nil {
+		resp.Cache = &pb.InternalStatsResp_CacheStats{
+			Hits:         stats.Cache.Hits,
+			NegativeHits: stats.Cache.NegativeHits,
+			Misses:       stats.Cache.Misses,
+			HitRatio:     stats.Cache.HitRatio,
+		}
+		if stats.Cache.Entries != nil {
+			resp.Cache.Entries = proto.Int64(int64(*stats.Cache.Entries))
+		}
+	}
+
+	return resp, nil
+}
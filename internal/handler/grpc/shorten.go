@@ -2,30 +2,34 @@ package handlegrpc
 
 import (
 	"context"
+	"errors"
 	"strings"
 
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 	pb "github.com/mp1947/ya-url-shortener/internal/proto"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 )
 
 // ShortenURL handles the gRPC request to shorten a given URL.
 // It receives a ShortenURLReq containing the original URL, generates a unique identifier,
 // and calls the underlying service to create a shortened URL.
-// Returns a ShortenURLResp with the shortened URL or an error if the operation fails.
+// Returns a ShortenURLResp with the shortened URL or an error if the operation fails. A URL which is
+// already shortened fails with codes.AlreadyExists, the existing short URL being reported in a
+// google.rpc.ResourceInfo detail.
 func (g *GRPCService) ShortenURL(
 	ctx context.Context,
 	in *pb.ShortenURLReq,
 ) (*pb.ShortenURLResp, error) {
 
 	if in.Url == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid URL provided")
+		return nil, problem.Error(shrterr.InvalidInput("url", "invalid URL provided"))
 	}
 
 	userID, token, err := g.getDataFromMD(ctx)
 
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, problem.Error(err)
 	}
 
 	var response pb.ShortenURLResp
@@ -36,8 +40,10 @@ func (g *GRPCService) ShortenURL(
 		userID,
 	)
 
-	if err != nil {
-		return nil, status.Errorf(codes.AlreadyExists, "error while shortening URL: %v", err)
+	if errors.Is(err, shrterr.ErrOriginalURLAlreadyExists) {
+		return nil, alreadyShortened(err, strings.Replace(shortURL, *g.Cfg.BaseHTTPURL, *g.Cfg.BaseGRPCURL, 1))
+	} else if err != nil {
+		return nil, problem.Error(err)
 	}
 
 	response.ShortURL = strings.Replace(shortURL, *g.Cfg.BaseHTTPURL, *g.Cfg.BaseGRPCURL, 1)
@@ -45,3 +51,18 @@ func (g *GRPCService) ShortenURL(
 
 	return &response, nil
 }
+
+// alreadyShortened returns the status error reporting err, a conflict with the URL already shortened
+// as shortURL, which is reported in a google.rpc.ResourceInfo detail.
+func alreadyShortened(err error, shortURL string) error {
+	st := problem.Status(err)
+
+	withResource, detailsErr := st.WithDetails(&errdetails.ResourceInfo{
+		ResourceType: "short_url",
+		ResourceName: shortURL,
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return withResource.Err()
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 
 	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 	pb "github.com/mp1947/ya-url-shortener/internal/proto"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // BatchShortenURL handles a batch request to shorten multiple URLs via gRPC.
@@ -27,13 +27,13 @@ func (g *GRPCService) BatchShortenURL(
 ) (*pb.BatchShortenResp, error) {
 
 	if len(in.BatchShortenData) == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "no URLs provided to shorten")
+		return nil, problem.Error(shrterr.InvalidInput("batch_shorten_data", "no URLs provided to shorten"))
 	}
 
 	userID, token, err := g.getDataFromMD(ctx)
 
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, problem.Error(err)
 	}
 
 	batchData := make([]dto.BatchShortenRequest, 0, len(in.BatchShortenData))
@@ -0,0 +1,84 @@
+package handlegrpc
+
+import (
+	"errors"
+	"io"
+
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	pb "github.com/mp1947/ya-url-shortener/internal/proto"
+)
+
+// streamShortenChunkSize is the number of streamed URLs shortened together in one call to the service layer.
+const streamShortenChunkSize = 100
+
+// StreamShortenURLs handles a client-streaming request to shorten URLs via gRPC.
+// URLs are shortened in chunks of streamShortenChunkSize as they arrive, using the same service
+// logic as BatchShortenURL, so the size of a bulk import is not bound by the gRPC message size limit.
+// A chunk holding an already shortened URL is shortened one URL at a time, so that the conflict does not
+// fail the other URLs. When the client closes the stream, the method answers with one result per received
+// URL, in the order they were received: the shortened URL and the error which prevented shortening it, if any.
+//
+// Parameters:
+//   - stream: The stream of URLs and their correlation IDs sent by the client.
+//
+// Returns:
+//   - error: An error if the metadata is missing or the stream fails.
+func (g *GRPCService) StreamShortenURLs(stream pb.Shortener_StreamShortenURLsServer) error {
+	ctx := stream.Context()
+
+	userID, token, err := g.getDataFromMD(ctx)
+	if err != nil {
+		return problem.Error(err)
+	}
+
+	var results []*pb.StreamShortenResp_Result
+
+	chunk := make([]dto.BatchShortenRequest, 0, streamShortenChunkSize)
+	chunkResults := make([]*pb.StreamShortenResp_Result, 0, streamShortenChunkSize)
+
+	flush := func() {
+		for i, shortened := range g.Service.ShortenBulkURLs(ctx, chunk, userID) {
+			chunkResults[i].ShortURL = shortened.ShortURL
+			chunkResults[i].Error = shortened.Error
+		}
+
+		chunk = chunk[:0]
+		chunkResults = chunkResults[:0]
+	}
+
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		result := &pb.StreamShortenResp_Result{CorrelationID: in.CorrelationID}
+		results = append(results, result)
+
+		if in.OriginalURL == "" {
+			result.Error = "original url is empty"
+			continue
+		}
+
+		chunk = append(chunk, dto.BatchShortenRequest{
+			CorrelationID: in.CorrelationID,
+			OriginalURL:   in.OriginalURL,
+		})
+		chunkResults = append(chunkResults, result)
+
+		if len(chunk) == streamShortenChunkSize {
+			flush()
+		}
+	}
+
+	flush()
+
+	return stream.SendAndClose(&pb.StreamShortenResp{
+		Results:  results,
+		JwtToken: token,
+	})
+}
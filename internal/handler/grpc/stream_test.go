@@ -0,0 +1,129 @@
+package handlegrpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
+	handlegrpc "github.com/mp1947/ya-url-shortener/internal/handler/grpc"
+	"github.com/mp1947/ya-url-shortener/internal/interceptor"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	pb "github.com/mp1947/ya-url-shortener/internal/proto"
+	"github.com/mp1947/ya-url-shortener/internal/repository/inmemory"
+	"github.com/mp1947/ya-url-shortener/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestMain(m *testing.M) {
+	authtest.Init()
+	m.Run()
+}
+
+func newTestClient(t *testing.T) pb.ShortenerClient {
+	listenAddr := ":8080"
+	baseURL := "http://localhost:8080"
+	baseGRPCURL := "localhost:9090"
+	fileStoragePath := t.TempDir() + "/test.out"
+	cfg := config.Config{
+		HTTPServerAddress: &listenAddr,
+		BaseHTTPURL:       &baseURL,
+		BaseGRPCURL:       &baseGRPCURL,
+		FileStoragePath:   &fileStoragePath,
+	}
+
+	l, err := logger.InitLogger()
+	require.NoError(t, err)
+
+	storage := &inmemory.Memory{}
+	require.NoError(t, storage.Init(context.Background(), cfg, l))
+
+	s := &service.ShortenService{Storage: storage, Logger: l, Cfg: &cfg}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainStreamInterceptor(interceptor.AuthStreamInterceptor),
+		grpc.ChainUnaryInterceptor(interceptor.AuthUnaryInterceptor),
+	)
+	pb.RegisterShortenerServer(grpcServer, handlegrpc.NewGRPCService(s, &cfg))
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewShortenerClient(conn)
+}
+
+func TestStreamingRPCs(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	const total = 250
+
+	upload, err := client.StreamShortenURLs(ctx)
+	require.NoError(t, err)
+
+	for i := 0; i < total; i++ {
+		require.NoError(t, upload.Send(&pb.StreamShortenReq{
+			CorrelationID: fmt.Sprint(i),
+			OriginalURL:   fmt.Sprintf("https://example.com/%d", i),
+		}))
+	}
+	// The duplicate fails the batch of the last chunk, whose other URLs are then shortened one by one.
+	require.NoError(t, upload.Send(&pb.StreamShortenReq{CorrelationID: "duplicate", OriginalURL: "https://example.com/0"}))
+	require.NoError(t, upload.Send(&pb.StreamShortenReq{CorrelationID: "empty"}))
+
+	resp, err := upload.CloseAndRecv()
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.JwtToken)
+	require.Len(t, resp.Results, total+2)
+
+	for i, result := range resp.Results[:total] {
+		assert.Equal(t, fmt.Sprint(i), result.CorrelationID)
+		assert.NotEmpty(t, result.ShortURL)
+		assert.Empty(t, result.Error)
+	}
+
+	duplicate := resp.Results[total]
+	assert.Equal(t, "duplicate", duplicate.CorrelationID)
+	assert.Equal(t, resp.Results[0].ShortURL, duplicate.ShortURL, "the short url of an already shortened url is returned")
+	assert.NotEmpty(t, duplicate.Error)
+
+	empty := resp.Results[total+1]
+	assert.Equal(t, "empty", empty.CorrelationID)
+	assert.NotEmpty(t, empty.Error)
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", resp.JwtToken)
+
+	export, err := client.StreamUserURLs(ctx, &pb.Empty{})
+	require.NoError(t, err)
+
+	var exported int
+	for {
+		url, err := export.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		assert.Regexp(t, `^localhost:9090/[^/]+$`, url.ShortURL)
+		exported++
+	}
+	assert.Equal(t, total, exported)
+}
@@ -0,0 +1,42 @@
+package handlegrpc
+
+import (
+	"fmt"
+
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	pb "github.com/mp1947/ya-url-shortener/internal/proto"
+)
+
+// StreamUserURLs streams all URLs associated with the current user, one message per URL.
+// The URLs are sent as they are read from the storage, as for the bulk export, so exporting many links
+// is bound neither by the gRPC message size limit nor by memory. Short URLs are built from BASE_GRPC_URL.
+// Returns an error if metadata is missing, the URLs cannot be listed or the stream fails.
+func (g *GRPCService) StreamUserURLs(
+	in *pb.Empty,
+	stream pb.Shortener_StreamUserURLsServer,
+) error {
+	ctx := stream.Context()
+
+	userID, _, err := g.getDataFromMD(ctx)
+	if err != nil {
+		return problem.Error(err)
+	}
+
+	var sendErr error
+	err = g.Service.ExportUserURLs(ctx, userID, func(url dto.ExportedURL) error {
+		sendErr = stream.Send(&pb.GetUserURLSResp_UserURL{
+			ShortURL:    fmt.Sprintf("%s/%s", *g.Cfg.BaseGRPCURL, url.ShortURLID),
+			OriginalURL: url.OriginalURL,
+		})
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return problem.Error(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package handlehttp
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/audit"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+)
+
+// AdminGetURL handles an operator lookup of any shortened URL and its owner.
+//
+// @Summary      Look up a short URL
+// @Description  Returns the original URL, owner and state flags of any short URL.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Shortened URL ID"
+// @Success      200  {object}  dto.AdminURL
+// @Failure      403  {object}  problem.Details  "admin role required"
+// @Failure      404  {object}  problem.Details  "short url not found"
+// @Failure      500  {object}  problem.Details  "internal server error"
+// @Router       /api/admin/urls/{id} [get]
+// @Security     ApiKeyAuth
+func (s HandlerService) AdminGetURL(c *gin.Context) {
+	url, err := s.Service.AdminGetURL(c.Request.Context(), c.Param("id"))
+
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, url)
+}
+
+// AdminGetUserURLs handles an operator listing of every URL owned by a user.
+//
+// @Summary      List a user's URLs
+// @Description  Returns every short URL owned by the user, including deleted and disabled ones.
+// @Tags         admin
+// @Produce      json
+// @Param        user_id  path      string  true  "User ID"
+// @Success      200      {array}   dto.AdminURL
+// @Failure      403      {object}  problem.Details  "admin role required"
+// @Failure      500      {object}  problem.Details  "internal server error"
+// @Router       /api/admin/users/{user_id}/urls [get]
+// @Security     ApiKeyAuth
+func (s HandlerService) AdminGetUserURLs(c *gin.Context) {
+	urls, err := s.Service.AdminGetUserURLs(c.Request.Context(), c.Param("user_id"))
+
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, urls)
+}
+
+// AdminDisableURL handles an operator request to disable a short URL.
+//
+// @Summary      Disable a short URL
+// @Description  Disabled short URLs are kept but no longer redirected.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Shortened URL ID"
+// @Success      200  {object}  dto.AdminActionResponse
+// @Failure      403  {object}  problem.Details  "admin role required"
+// @Failure      500  {object}  problem.Details  "internal server error"
+// @Router       /api/admin/urls/{id}/disable [post]
+// @Security     ApiKeyAuth
+func (s HandlerService) AdminDisableURL(c *gin.Context) {
+	s.adminSetURLDisabled(c, true)
+}
+
+// AdminEnableURL handles an operator request to re-enable a disabled short URL.
+//
+// @Summary      Re-enable a short URL
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Shortened URL ID"
+// @Success      200  {object}  dto.AdminActionResponse
+// @Failure      403  {object}  problem.Details  "admin role required"
+// @Failure      500  {object}  problem.Details  "internal server error"
+// @Router       /api/admin/urls/{id}/enable [post]
+// @Security     ApiKeyAuth
+func (s HandlerService) AdminEnableURL(c *gin.Context) {
+	s.adminSetURLDisabled(c, false)
+}
+
+func (s HandlerService) adminSetURLDisabled(c *gin.Context, disabled bool) {
+	affected, err := s.Service.AdminSetURLsDisabled(
+		c.Request.Context(),
+		[]string{c.Param("id")},
+		disabled,
+	)
+
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AdminActionResponse{Affected: affected})
+}
+
+// AdminDeleteURLs handles an operator request to delete short URLs regardless of their owner.
+//
+// @Summary      Force-delete short URLs
+// @Description  Synchronously deletes the given short URLs. The request body must be a JSON array of short URL identifiers.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        shortURLs  body      []string  true  "Array of short URL identifiers to delete"
+// @Success      200        {object}  dto.AdminActionResponse
+// @Failure      400        {object}  problem.Details  "incorrect request body"
+// @Failure      403        {object}  problem.Details  "admin role required"
+// @Failure      500        {object}  problem.Details  "internal server error"
+// @Router       /api/admin/urls [delete]
+// @Security     ApiKeyAuth
+func (s HandlerService) AdminDeleteURLs(c *gin.Context) {
+	var shortURLs []string
+
+	if err := c.ShouldBindJSON(&shortURLs); err != nil || len(shortURLs) < 1 {
+		problem.Write(c, shrterr.InvalidInput("", "incorrect request body"))
+		return
+	}
+
+	affected, err := s.Service.AdminDeleteURLs(c.Request.Context(), shortURLs)
+
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AdminActionResponse{Affected: affected})
+}
+
+// AdminReassignURLs handles an operator request to transfer short URLs to another user.
+//
+// @Summary      Reassign short URLs
+// @Description  Transfers ownership of the given short URLs to the given user.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AdminReassignRequest  true  "Short URLs and the new owner"
+// @Success      200      {object}  dto.AdminActionResponse
+// @Failure      400      {object}  problem.Details  "incorrect request body"
+// @Failure      403      {object}  problem.Details  "admin role required"
+// @Failure      500      {object}  problem.Details  "internal server error"
+// @Router       /api/admin/urls/reassign [post]
+// @Security     ApiKeyAuth
+func (s HandlerService) AdminReassignURLs(c *gin.Context) {
+	var request dto.AdminReassignRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil || len(request.ShortURLs) < 1 {
+		problem.Write(c, shrterr.InvalidInput("", "incorrect request body"))
+		return
+	}
+
+	if _, err := uuid.Parse(request.UserID); err != nil {
+		problem.Write(c, shrterr.InvalidInput("user_id", "user_id is not a valid uuid"))
+		return
+	}
+
+	affected, err := s.Service.AdminReassignURLs(
+		c.Request.Context(),
+		request.ShortURLs,
+		request.UserID,
+	)
+
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AdminActionResponse{Affected: affected})
+}
+
+// AdminQueryAudit handles an operator query of the audit trail.
+//
+// @Summary      Query the audit trail
+// @Description  Returns the newest audit entries, optionally filtered by user (actor or target), short URL and time range.
+// @Tags         admin
+// @Produce      json
+// @Param        user_id    query     string  false  "Actor or target user ID"
+// @Param        short_url  query     string  false  "Shortened URL ID"
+// @Param        from       query     string  false  "Start of the time range, RFC 3339, inclusive"
+// @Param        to         query     string  false  "End of the time range, RFC 3339, exclusive"
+// @Param        limit      query     int     false  "Maximum number of entries, 100 by default"
+// @Success      200        {array}   audit.Entry
+// @Failure      400        {object}  problem.Details  "incorrect query parameters"
+// @Failure      403        {object}  problem.Details  "admin role required"
+// @Failure      500        {object}  problem.Details  "internal server error"
+// @Router       /api/admin/audit [get]
+// @Security     ApiKeyAuth
+func (s HandlerService) AdminQueryAudit(c *gin.Context) {
+	filter := audit.Filter{
+		UserID:   c.Query("user_id"),
+		ShortURL: c.Query("short_url"),
+	}
+
+	var err error
+
+	if from := c.Query("from"); from != "" {
+		filter.From, err = time.Parse(time.RFC3339, from)
+	}
+	if to := c.Query("to"); to != "" && err == nil {
+		filter.To, err = time.Parse(time.RFC3339, to)
+	}
+	if limit := c.Query("limit"); limit != "" && err == nil {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err == nil && filter.Limit < 0 {
+			err = strconv.ErrRange
+		}
+	}
+
+	if err != nil {
+		problem.Write(c, shrterr.InvalidInput("", "incorrect query parameters"))
+		return
+	}
+
+	entries, err := s.Service.AdminQueryAudit(c.Request.Context(), filter)
+
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,160 @@
+package handlehttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"github.com/mp1947/ya-url-shortener/internal/router"
+	"github.com/mp1947/ya-url-shortener/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAPI(t *testing.T) {
+	adminCfg := cfg
+	adminCfg.AdminAPIKeys = []string{"test-admin-key"}
+
+	r := router.CreateRouter(adminCfg, hs.Service, storage, l, nil, nil, nil, nil, nil, nil)
+
+	originalURL := "https://admin.example.com/" + uuid.NewString()
+	shortURLID := usecase.GenerateIDFromURL(originalURL)
+	ownerID := uuid.NewString()
+	require.NoError(t, storage.Save(context.Background(), shortURLID, originalURL, ownerID))
+
+	adminToken, err := auth.CreateTokenWithClaims(auth.Claims{UserID: uuid.New(), Role: auth.RoleAdmin})
+	require.NoError(t, err)
+
+	userToken, err := auth.CreateToken(uuid.New())
+	require.NoError(t, err)
+
+	send := func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	withKey := map[string]string{"X-API-Key": "test-admin-key"}
+
+	t.Run("reject missing admin role", func(t *testing.T) {
+		w := send(http.MethodGet, "/api/admin/urls/"+shortURLID, "", nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = send(http.MethodGet, "/api/admin/urls/"+shortURLID, "", map[string]string{
+			"Authorization": "Bearer " + userToken,
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("reject admin token cookie", func(t *testing.T) {
+		w := send(http.MethodGet, "/api/admin/urls/"+shortURLID, "", map[string]string{
+			"Cookie": "token=" + adminToken,
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("reject unknown api key", func(t *testing.T) {
+		w := send(http.MethodGet, "/api/admin/urls/"+shortURLID, "", map[string]string{
+			"X-API-Key": "wrong",
+		})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("look up url with bearer token", func(t *testing.T) {
+		w := send(http.MethodGet, "/api/admin/urls/"+shortURLID, "", map[string]string{
+			"Authorization": "Bearer " + adminToken,
+		})
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var url dto.AdminURL
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &url))
+		assert.Equal(t, originalURL, url.OriginalURL)
+		assert.Equal(t, ownerID, url.UserID)
+	})
+
+	t.Run("look up unknown url", func(t *testing.T) {
+		w := send(http.MethodGet, "/api/admin/urls/doesnotexist", "", withKey)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("disable and enable url", func(t *testing.T) {
+		w := send(http.MethodPost, "/api/admin/urls/"+shortURLID+"/disable", "", withKey)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"affected": 1}`, w.Body.String())
+
+		w = send(http.MethodGet, "/"+shortURLID, "", nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = send(http.MethodPost, "/api/admin/urls/"+shortURLID+"/enable", "", withKey)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		w = send(http.MethodGet, "/"+shortURLID, "", nil)
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+	})
+
+	t.Run("reassign and list user urls", func(t *testing.T) {
+		newOwnerID := uuid.NewString()
+
+		w := send(http.MethodPost, "/api/admin/urls/reassign", `{"short_urls": ["`+shortURLID+`"], "user_id": "not-a-uuid"}`, withKey)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = send(http.MethodPost, "/api/admin/urls/reassign", `{"short_urls": ["`+shortURLID+`"], "user_id": "`+newOwnerID+`"}`, withKey)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"affected": 1}`, w.Body.String())
+
+		w = send(http.MethodGet, "/api/admin/users/"+newOwnerID+"/urls", "", withKey)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var urls []dto.AdminURL
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &urls))
+		require.Len(t, urls, 1)
+		assert.Equal(t, shortURLID, urls[0].ShortURLID)
+	})
+
+	t.Run("force delete url", func(t *testing.T) {
+		w := send(http.MethodDelete, "/api/admin/urls", `[]`, withKey)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = send(http.MethodDelete, "/api/admin/urls", `["`+shortURLID+`"]`, withKey)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"affected": 1}`, w.Body.String())
+
+		w = send(http.MethodGet, "/api/admin/urls/"+shortURLID, "", withKey)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var url dto.AdminURL
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &url))
+		assert.True(t, url.IsDeleted)
+
+		w = send(http.MethodGet, "/api/admin/urls/unknown", "", withKey)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+		var details problem.Details
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+		assert.Equal(t, "NOT_FOUND", details.Reason)
+		assert.Equal(t, "short url not found", details.Detail)
+	})
+
+	t.Run("query audit with bad parameters", func(t *testing.T) {
+		w := send(http.MethodGet, "/api/admin/audit?from=yesterday", "", withKey)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = send(http.MethodGet, "/api/admin/audit?limit=-1", "", withKey)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = send(http.MethodGet, "/api/admin/audit?user_id="+ownerID+"&from=2025-01-01T00:00:00Z", "", withKey)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
 	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 )
 
 // DeleteUserURLs handles the deletion of a batch of user-specific short URLs.
@@ -16,9 +18,9 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        userURLsToDelete  body      []string  true  "Array of short URL identifiers to delete"
-// @Success      202  {object}  map[string]string  "in progress"
-// @Failure      400  {object}  map[string]string  "incorrect request body or no urls provided for deletion"
-// @Failure      401  {object}  nil                "unauthorized"
+// @Success      202  {object}  map[string]string  "in progress, with the id of the deletion job"
+// @Failure      400  {object}  problem.Details  "incorrect request body or no urls provided for deletion"
+// @Failure      401  {object}  problem.Details  "unauthorized"
 // @Router       /api/user/urls [delete]
 func (s HandlerService) DeleteUserURLs(c *gin.Context) {
 	var userURLsToDelete []string
@@ -26,25 +28,21 @@ func (s HandlerService) DeleteUserURLs(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 
 	if !exists {
-		c.Status(http.StatusUnauthorized)
+		problem.Write(c, errUnauthenticated)
 		return
 	}
 
 	if err := c.BindJSON(&userURLsToDelete); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "incorrect request body",
-		})
+		problem.Write(c, shrterr.InvalidInput("", "incorrect request body"))
 		return
 	}
 
 	if len(userURLsToDelete) < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "no urls provided for deletion",
-		})
+		problem.Write(c, shrterr.InvalidInput("", "no urls provided for deletion"))
 		return
 	}
 
-	s.Service.DeleteURLsBatch(
+	jobID := s.Service.DeleteURLsBatch(
 		c.Request.Context(),
 		model.BatchDeleteShortURLs{
 			ShortURLs: userURLsToDelete,
@@ -54,6 +52,36 @@ func (s HandlerService) DeleteUserURLs(c *gin.Context) {
 
 	c.JSON(http.StatusAccepted, gin.H{
 		"message": "in progress",
+		"job_id":  jobID,
 	})
 
 }
+
+// GetDeletionJob returns the status of a deletion job of the authenticated user.
+//
+// @Summary      Get the status of a deletion job
+// @Description  Returns the state of a deletion job started by DELETE /api/user/urls: pending, running, done or failed.
+// @Tags         urls
+// @Produce      json
+// @Param        id   path      string  true  "ID of the deletion job"
+// @Success      200  {object}  dto.DeletionJob
+// @Failure      401  {object}  problem.Details  "unauthorized"
+// @Failure      404  {object}  problem.Details  "deletion job not found"
+// @Router       /api/user/urls/deletions/{id} [get]
+func (s HandlerService) GetDeletionJob(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+
+	if !exists {
+		problem.Write(c, errUnauthenticated)
+		return
+	}
+
+	job, err := s.Service.GetDeletionJob(c.Request.Context(), c.Param("id"), fmt.Sprintf("%s", userID))
+
+	if err != nil {
+		problem.Write(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
@@ -1,10 +1,20 @@
 package handlehttp_test
 
 import (
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/mp1947/ya-url-shortener/internal/jobs"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/router"
+	"github.com/mp1947/ya-url-shortener/internal/service"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"resty.dev/v3"
 )
 
@@ -25,4 +35,51 @@ func TestDeleteUserURLS(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode())
 	})
+
+	t.Run("deletion job status", func(t *testing.T) {
+		svc := &service.ShortenService{
+			Storage: storage,
+			Logger:  l,
+			Cfg:     &cfg,
+			CommCh:  make(chan model.BatchDeleteShortURLs, 1),
+			Jobs:    jobs.NewLocal(10, time.Minute),
+		}
+		r := router.CreateRouter(cfg, svc, storage, l, nil, nil, nil, nil, nil, nil)
+		token := newUserToken(t)
+
+		do := func(method, path, token string, body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if token != "" {
+				req.AddCookie(&http.Cookie{Name: "token", Value: token})
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			return w
+		}
+
+		w := do(http.MethodDelete, "/api/user/urls", token, `["abc123"]`)
+		require.Equal(t, http.StatusAccepted, w.Code)
+
+		var accepted struct {
+			JobID string `json:"job_id"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &accepted))
+		require.NotEmpty(t, accepted.JobID)
+
+		w = do(http.MethodGet, "/api/user/urls/deletions/"+accepted.JobID, token, "")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var job dto.DeletionJob
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+		assert.Equal(t, accepted.JobID, job.ID)
+		assert.Equal(t, jobs.StatePending, job.State)
+		assert.Equal(t, 1, job.ShortURLs)
+
+		w = do(http.MethodGet, "/api/user/urls/deletions/"+accepted.JobID, newUserToken(t), "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = do(http.MethodGet, "/api/user/urls/deletions/unknown", token, "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
 }
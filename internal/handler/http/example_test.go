@@ -54,7 +54,7 @@ func ExampleHandlerService_GetOriginalURLByID() {
 
 	fmt.Println(w.Code)
 
-	// Output: 400
+	// Output: 404
 
 }
 
@@ -0,0 +1,185 @@
+package handlehttp
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+)
+
+// exportFlushInterval is the number of exported URLs written between two flushes of the response.
+const exportFlushInterval = 500
+
+// exportCSVHeader is the header row of a CSV export.
+var exportCSVHeader = []string{"short_url_id", "short_url", "original_url", "is_deleted", "is_disabled"}
+
+// ExportUserURLs handles a bulk export of the authenticated user's URLs.
+//
+// @Summary      Export URLs
+// @Description  Streams every URL of the user with its ID and deleted and disabled flags.
+// @Tags         urls
+// @Produce      json
+// @Produce      application/x-ndjson
+// @Produce      text/csv
+// @Param        format  query  string  false  "csv, ndjson or json, default json"
+// @Success      200  {array}   dto.ExportedURL
+// @Failure      400  {object}  problem.Details  "unsupported format"
+// @Failure      401  {object}  problem.Details  "Unauthorized"
+// @Failure      500  {object}  problem.Details  "internal server error"
+// @Router       /api/user/urls/export [get]
+// @Security     ApiKeyAuth
+//
+// URLs are written as they are read from the storage and the response is flushed every exportFlushInterval
+// URLs. The response starts with the first URL, so that a storage error occurring before is answered with
+// problem details. An error occurring later ends the export early, a JSON export then lacks its closing bracket.
+// A user without URLs gets an empty export rather than HTTP 204 No Content, so that exports can be processed uniformly.
+func (s HandlerService) ExportUserURLs(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+
+	if !exists {
+		problem.Write(c, errUnauthenticated)
+		return
+	}
+
+	var w exportWriter
+
+	switch c.DefaultQuery("format", "json") {
+	case "csv":
+		w = &csvExportWriter{}
+	case "ndjson":
+		w = &ndjsonExportWriter{}
+	case "json":
+		w = &jsonExportWriter{}
+	default:
+		problem.Write(c, shrterr.InvalidInput("", "format must be one of csv, ndjson or json"))
+		return
+	}
+
+	var written int
+
+	err := s.Service.ExportUserURLs(c.Request.Context(), userID.(string), func(u dto.ExportedURL) error {
+		if written == 0 {
+			w.begin(c)
+		}
+		if err := w.write(u); err != nil {
+			return err
+		}
+		written++
+		if written%exportFlushInterval == 0 {
+			w.flush()
+			c.Writer.Flush()
+		}
+		return nil
+	})
+
+	if err != nil && written == 0 {
+		problem.Write(c, err)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if written == 0 {
+		w.begin(c)
+	}
+	w.end()
+}
+
+// exportWriter writes the URLs of an export in one format.
+type exportWriter interface {
+	// begin sets the headers of the response and writes what precedes the first URL.
+	begin(c *gin.Context)
+	write(u dto.ExportedURL) error
+	// flush writes the URLs buffered by the writer to the response.
+	flush()
+	// end writes what follows the last URL.
+	end()
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (e *csvExportWriter) begin(c *gin.Context) {
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", `attachment; filename="urls.csv"`)
+	c.Status(http.StatusOK)
+
+	e.w = csv.NewWriter(c.Writer)
+	_ = e.w.Write(exportCSVHeader)
+}
+
+func (e *csvExportWriter) write(u dto.ExportedURL) error {
+	return e.w.Write([]string{
+		u.ShortURLID,
+		u.ShortURL,
+		u.OriginalURL,
+		strconv.FormatBool(u.IsDeleted),
+		strconv.FormatBool(u.IsDisabled),
+	})
+}
+
+func (e *csvExportWriter) flush() {
+	e.w.Flush()
+}
+
+func (e *csvExportWriter) end() {
+	e.w.Flush()
+}
+
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonExportWriter) begin(c *gin.Context) {
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	e.encoder = json.NewEncoder(c.Writer)
+}
+
+func (e *ndjsonExportWriter) write(u dto.ExportedURL) error {
+	return e.encoder.Encode(u)
+}
+
+func (e *ndjsonExportWriter) flush() {}
+
+func (e *ndjsonExportWriter) end() {}
+
+type jsonExportWriter struct {
+	w       gin.ResponseWriter
+	written bool
+}
+
+func (e *jsonExportWriter) begin(c *gin.Context) {
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	e.w = c.Writer
+	_, _ = e.w.WriteString("[")
+}
+
+func (e *jsonExportWriter) write(u dto.ExportedURL) error {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	if e.written {
+		_, _ = e.w.WriteString(",")
+	}
+	e.written = true
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonExportWriter) flush() {}
+
+func (e *jsonExportWriter) end() {
+	_, _ = e.w.WriteString("]")
+}
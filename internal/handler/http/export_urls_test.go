@@ -0,0 +1,80 @@
+package handlehttp_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportUserURLs(t *testing.T) {
+	baseURL, shutdown := setupTestServer()
+	defer shutdown()
+
+	token := newUserToken(t)
+
+	do := func(t *testing.T, method, path, contentType, body string) *http.Response {
+		req, err := http.NewRequest(method, baseURL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		req.AddCookie(&http.Cookie{Name: "token", Value: token})
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	resp := do(t, http.MethodPost, "/api/user/urls/import", "text/csv",
+		"original_url\nhttps://export.example.com/1\nhttps://export.example.com/2\n")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	t.Run("json", func(t *testing.T) {
+		resp := do(t, http.MethodGet, "/api/user/urls/export", "", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var urls []dto.ExportedURL
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&urls))
+		require.Len(t, urls, 2)
+		assert.Equal(t, "https://export.example.com/1", urls[0].OriginalURL)
+		assert.NotEmpty(t, urls[0].ShortURLID)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		resp := do(t, http.MethodGet, "/api/user/urls/export?format=ndjson", "", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		decoder := json.NewDecoder(resp.Body)
+		var count int
+		for decoder.More() {
+			var url dto.ExportedURL
+			require.NoError(t, decoder.Decode(&url))
+			count++
+		}
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		resp := do(t, http.MethodGet, "/api/user/urls/export?format=csv", "", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, "short_url_id", records[0][0])
+		assert.Equal(t, "https://export.example.com/2", records[2][2])
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		resp := do(t, http.MethodGet, "/api/user/urls/export?format=xml", "", "")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
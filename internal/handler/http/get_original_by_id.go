@@ -1,27 +1,35 @@
 package handlehttp
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 )
 
 // GetOriginalURLByID handles GET requests to retrieve the original URL by its shortened ID.
+// Unknown and deleted short URLs are answered with an HTML page to clients preferring HTML,
+// such as browsers, and with problem details to the other ones.
 //
 // @Summary      Get original URL by ID
 // @Description  Redirects to the original URL corresponding to the provided shortened ID.
 // @Tags         url
 // @Accept       plain
-// @Produce      plain
+// @Produce      plain,html,json
 // @Param        id   path      string  true  "Shortened URL ID"
 // @Success      307  {string}  string  "Temporary Redirect to the original URL"
-// @Failure      400  {string}  string  "Bad Request"
-// @Failure      410  {string}  string  "Gone - URL has been deleted"
-// @Failure      500  {string}  string  "Internal Server Error"
+// @Failure      400  {object}  problem.Details  "Bad Request"
+// @Failure      403  {object}  problem.Details  "Forbidden - URL has been disabled by an operator"
+// @Failure      404  {object}  problem.Details  "Not Found - no URL is stored under the ID"
+// @Failure      410  {object}  problem.Details  "Gone - URL has been deleted"
+// @Failure      500  {object}  problem.Details  "Internal Server Error"
 // @Router       /{id} [get]
 func (s HandlerService) GetOriginalURLByID(c *gin.Context) {
 	if c.Request.Method != http.MethodGet {
-		c.Data(http.StatusBadRequest, contentTypePlain, nil)
+		problem.Write(c, errMethodNotSupported)
 		return
 	}
 
@@ -29,19 +37,28 @@ func (s HandlerService) GetOriginalURLByID(c *gin.Context) {
 
 	url, err := s.Service.GetOriginalURL(c.Request.Context(), id)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		result := metrics.RedirectError
+		if errors.Is(err, shrterr.ErrShortURLNotFound) {
+			result = metrics.RedirectMiss
+		}
+		metrics.RedirectsTotal.WithLabelValues(result).Inc()
+		s.Pages.Write(c, err)
 		return
 	}
 
 	if url.IsDeleted {
-		c.Data(http.StatusGone, contentTypePlain, nil)
+		metrics.RedirectsTotal.WithLabelValues(metrics.RedirectGone).Inc()
+		s.Pages.Write(c, shrterr.ErrShortURLDeleted)
 		return
 	}
 
-	if url.OriginalURL != "" {
-		c.Header("Location", url.OriginalURL)
-		c.Data(http.StatusTemporaryRedirect, contentTypePlain, nil)
+	if url.IsDisabled {
+		metrics.RedirectsTotal.WithLabelValues(metrics.RedirectDisabled).Inc()
+		s.Pages.Write(c, shrterr.ErrShortURLDisabled)
 		return
 	}
-	c.Data(http.StatusBadRequest, contentTypePlain, nil)
+
+	metrics.RedirectsTotal.WithLabelValues(metrics.RedirectHit).Inc()
+	c.Header("Location", url.OriginalURL)
+	c.Data(http.StatusTemporaryRedirect, contentTypePlain, nil)
 }
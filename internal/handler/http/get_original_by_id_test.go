@@ -2,14 +2,25 @@ package handlehttp_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/errorpage"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	handler "github.com/mp1947/ya-url-shortener/internal/handler/http"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"github.com/mp1947/ya-url-shortener/internal/service"
 	"github.com/mp1947/ya-url-shortener/internal/usecase"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetOriginalURLByID(t *testing.T) {
@@ -37,7 +48,7 @@ func TestGetOriginalURLByID(t *testing.T) {
 				httpMethod:    http.MethodGet,
 				originalURLID: "/doesnotexists",
 			},
-			expectedStatusCode: http.StatusBadRequest,
+			expectedStatusCode: http.StatusNotFound,
 			expectedLocation:   "",
 		},
 		{
@@ -83,3 +94,99 @@ func TestGetOriginalURLByID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOriginalURLByIDPages(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	deletedID := usecase.GenerateIDFromURL("https://deleted.example.com")
+
+	require.NoError(t, storage.Save(ctx, deletedID, "https://deleted.example.com", userID))
+	_, err := storage.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: userID, ShortURLs: []string{deletedID}})
+	require.NoError(t, err)
+
+	h := handler.HandlerService{Service: hs.Service, Pages: errorpage.Default()}
+	r := gin.New()
+	r.GET("/:id", h.GetOriginalURLByID)
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	const browserAccept = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+
+	t.Run("browsers are answered with the not found page", func(t *testing.T) {
+		w := get("/doesnotexist", browserAccept)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "/doesnotexist")
+	})
+
+	t.Run("browsers are answered with the gone page", func(t *testing.T) {
+		w := get("/"+deletedID, browserAccept)
+		assert.Equal(t, http.StatusGone, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "has been deleted")
+	})
+
+	for _, accept := range []string{"", "*/*", "application/json"} {
+		t.Run("api clients are answered with problem details, accept "+accept, func(t *testing.T) {
+			w := get("/doesnotexist", accept)
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+			var d problem.Details
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &d))
+			assert.Equal(t, "NOT_FOUND", d.Reason)
+		})
+	}
+}
+
+// failingLookupService is a service.Service whose lookups of original URLs fail with err.
+type failingLookupService struct {
+	service.Service
+	err error
+}
+
+func (s failingLookupService) GetOriginalURL(ctx context.Context, shortURLID string) (model.URL, error) {
+	return model.URL{}, s.err
+}
+
+func TestGetOriginalURLByIDMetrics(t *testing.T) {
+	redirects := func(result string) float64 {
+		return testutil.ToFloat64(metrics.RedirectsTotal.WithLabelValues(result))
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMisses float64
+		wantErrors float64
+	}{
+		{name: "unknown short url", err: shrterr.ErrShortURLNotFound, wantStatus: http.StatusNotFound, wantMisses: 1},
+		{name: "storage error", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantErrors: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler.HandlerService{Service: failingLookupService{err: tt.err}, Pages: errorpage.Default()}
+			r := gin.New()
+			r.GET("/:id", h.GetOriginalURLByID)
+
+			misses, errs := redirects(metrics.RedirectMiss), redirects(metrics.RedirectError)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abc", nil))
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			assert.Equal(t, misses+tt.wantMisses, redirects(metrics.RedirectMiss))
+			assert.Equal(t, errs+tt.wantErrors, redirects(metrics.RedirectError))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 )
 
 // GetUserURLs handles the HTTP request to retrieve all URLs associated with the authenticated user.
@@ -14,8 +15,8 @@ import (
 // @Produce      json
 // @Success      200 {array} models.UserURL "List of user's URLs"
 // @NoContent    204 "No URLs found for the user"
-// @Failure      401 {object} gin.H "Unauthorized"
-// @Failure      500 {object} gin.H "Internal server error"
+// @Failure      401 {object} problem.Details "Unauthorized"
+// @Failure      500 {object} problem.Details "Internal server error"
 // @Router       /api/user/urls [get]
 // @Security     ApiKeyAuth
 //
@@ -28,7 +29,7 @@ func (s HandlerService) GetUserURLs(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 
 	if !exists {
-		c.Status(http.StatusUnauthorized)
+		problem.Write(c, errUnauthenticated)
 		return
 	}
 
@@ -40,9 +41,7 @@ func (s HandlerService) GetUserURLs(c *gin.Context) {
 	)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "internal server error while processing urls",
-		})
+		problem.Write(c, err)
 		return
 	}
 
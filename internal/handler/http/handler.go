@@ -3,6 +3,8 @@
 package handlehttp
 
 import (
+	"github.com/mp1947/ya-url-shortener/internal/errorpage"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
 	"github.com/mp1947/ya-url-shortener/internal/service"
 )
 
@@ -15,6 +17,17 @@ const (
 
 // HandlerService provides HTTP handler methods and holds a reference to the core service logic.
 // Service is the main business logic layer that HandlerService delegates requests to.
+// Pages renders the pages answering browsers following unknown or deleted short URLs,
+// other clients and a nil Pages are answered with problem details.
 type HandlerService struct {
 	Service service.Service
+	Pages   *errorpage.Pages
 }
+
+var (
+	// errUnauthenticated is reported to requests reaching a handler of the authenticated user without one.
+	errUnauthenticated = shrterr.Unauthorized("user is not authenticated")
+
+	// errMethodNotSupported is reported to requests of a method the handler does not serve.
+	errMethodNotSupported = shrterr.InvalidInput("", "request method is not supported")
+)
@@ -5,9 +5,11 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"testing"
 	"time"
 
 	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
 	handler "github.com/mp1947/ya-url-shortener/internal/handler/http"
 	"github.com/mp1947/ya-url-shortener/internal/logger"
 	"github.com/mp1947/ya-url-shortener/internal/model"
@@ -17,6 +19,11 @@ import (
 	"go.uber.org/zap"
 )
 
+func TestMain(m *testing.M) {
+	authtest.Init()
+	m.Run()
+}
+
 const (
 	testURL         = "https://console.yandex.cloud/"
 	testJSONRequest = `{"url": "https://console.yandex.cloud"}`
@@ -52,7 +59,7 @@ func initTestHandlerService() handler.HandlerService {
 }
 
 func setupTestServer() (string, func()) {
-	router := router.CreateRouter(cfg, hs.Service, storage, l)
+	router := router.CreateRouter(cfg, hs.Service, storage, l, nil, nil, nil, nil, nil, nil)
 	listener, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
 		l.Fatal("failed to start test server", zap.Error(err))
@@ -0,0 +1,43 @@
+package handlehttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/health"
+)
+
+// Healthz is a Gin handler reporting that the process is alive.
+//
+// @Summary      Liveness probe
+// @Description  Reports that the process is alive and serving HTTP requests.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  health.Report
+// @Router       /healthz [get]
+func (s HandlerService) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, health.Report{Status: health.StatusOK})
+}
+
+// Readyz is a Gin handler reporting whether the application is ready to serve traffic.
+//
+// @Summary      Readiness probe
+// @Description  Runs the readiness checks: storage reachable, migrations applied, deletion worker alive, not shutting down.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  health.Report
+// @Failure      503  {object}  health.Report
+// @Router       /readyz [get]
+//
+// It responds with HTTP 200 OK if every check passed and HTTP 503 Service Unavailable otherwise,
+// the body reports the result of each check.
+func (s HandlerService) Readyz(hc *health.Checker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := hc.Ready(c.Request.Context())
+		if !report.Ready() {
+			c.JSON(http.StatusServiceUnavailable, report)
+			return
+		}
+		c.JSON(http.StatusOK, report)
+	}
+}
@@ -0,0 +1,37 @@
+package handlehttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthEndpoints(t *testing.T) {
+	hc := health.NewChecker()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/healthz", hs.Healthz)
+	r.GET("/readyz", hs.Readyz(hc))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, get("/healthz").Code)
+	assert.Equal(t, http.StatusOK, get("/readyz").Code)
+
+	hc.SetShuttingDown()
+
+	assert.Equal(t, http.StatusOK, get("/healthz").Code)
+
+	w := get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"unavailable"`)
+}
@@ -0,0 +1,275 @@
+package handlehttp
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+)
+
+const (
+	// importChunkSize is the number of imported rows shortened together in one call to the service layer.
+	importChunkSize = 100
+
+	// maxNDJSONLineSize is the maximum size of one line of an imported NDJSON body.
+	maxNDJSONLineSize = 64 * 1024
+
+	defaultURLColumn         = "original_url"
+	defaultCorrelationColumn = "correlation_id"
+)
+
+var (
+	errEmptyOriginalURL   = errors.New("original url is empty")
+	errInvalidOriginalURL = errors.New("original url is not a valid http or https url")
+)
+
+// importRow is one data row of an imported body, err is set when the row cannot be shortened.
+type importRow struct {
+	request dto.BatchShortenRequest
+	err     error
+}
+
+// importReader reads the data rows of an imported body one by one, it returns io.EOF after the last row.
+type importReader interface {
+	next() (importRow, error)
+}
+
+// ImportUserURLs handles a bulk import of URLs for the authenticated user.
+//
+// @Summary      Import URLs
+// @Description  Shortens every URL of a CSV or NDJSON body and streams back one NDJSON result per row.
+// @Tags         urls
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      application/x-ndjson
+// @Param        url_column          query  string  false  "CSV column holding the original URL, default original_url"
+// @Param        correlation_column  query  string  false  "CSV column holding the correlation ID, default correlation_id"
+// @Success      200  {array}   dto.ImportResult
+// @Failure      400  {object}  problem.Details  "incorrect request body"
+// @Failure      401  {object}  problem.Details  "Unauthorized"
+// @Failure      415  {object}  problem.Details  "unsupported content type"
+// @Router       /api/user/urls/import [post]
+// @Security     ApiKeyAuth
+//
+// A CSV body starts with a header row, the columns holding the original URL and the correlation ID are
+// looked up by name and may be renamed with the url_column and correlation_column query parameters.
+// An NDJSON body holds one BatchShortenRequest object per line.
+// Rows without a correlation ID are identified by their row number. Each row is validated, valid rows
+// are shortened in chunks of importChunkSize as the body is read, and the results are written in row order
+// once their chunk is processed, so arbitrarily large bodies can be imported. A chunk holding an already
+// shortened URL is shortened one row at a time, so that the conflict does not fail the other rows.
+func (s HandlerService) ImportUserURLs(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+
+	if !exists {
+		problem.Write(c, errUnauthenticated)
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+
+	var reader importReader
+
+	switch mediaType {
+	case "text/csv":
+		csvReader, err := newCSVImportReader(
+			c.Request.Body,
+			c.DefaultQuery("url_column", defaultURLColumn),
+			c.DefaultQuery("correlation_column", defaultCorrelationColumn),
+		)
+		if err != nil {
+			problem.Write(c, shrterr.InvalidInput("", "incorrect request body: "+err.Error()))
+			return
+		}
+		reader = csvReader
+	case "application/x-ndjson", "application/ndjson":
+		reader = newNDJSONImportReader(c.Request.Body)
+	default:
+		problem.Write(c, shrterr.UnsupportedMediaType("content type must be text/csv or application/x-ndjson"))
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(c.Writer)
+
+	pending := make([]*dto.ImportResult, 0, importChunkSize)
+
+	chunk := make([]dto.BatchShortenRequest, 0, importChunkSize)
+	chunkResults := make([]*dto.ImportResult, 0, importChunkSize)
+
+	flush := func() {
+		for i, shortened := range s.Service.ShortenBulkURLs(c.Request.Context(), chunk, userID.(string)) {
+			chunkResults[i].ShortURL = shortened.ShortURL
+			chunkResults[i].Error = shortened.Error
+		}
+
+		for _, result := range pending {
+			_ = encoder.Encode(result)
+		}
+		c.Writer.Flush()
+
+		chunk = chunk[:0]
+		chunkResults = chunkResults[:0]
+		pending = pending[:0]
+	}
+
+	for rowNum := 1; ; rowNum++ {
+		row, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			pending = append(pending, &dto.ImportResult{Row: rowNum, Error: err.Error()})
+			break
+		}
+
+		if row.request.CorrelationID == "" {
+			row.request.CorrelationID = strconv.Itoa(rowNum)
+		}
+
+		result := &dto.ImportResult{Row: rowNum, CorrelationID: row.request.CorrelationID}
+		pending = append(pending, result)
+
+		if row.err == nil {
+			row.err = validateOriginalURL(row.request.OriginalURL)
+		}
+		if row.err != nil {
+			result.Error = row.err.Error()
+		} else {
+			chunk = append(chunk, row.request)
+			chunkResults = append(chunkResults, result)
+		}
+
+		// Every chunk is shortened once it is full, and pending results of invalid rows are not held longer.
+		if len(pending) == importChunkSize {
+			flush()
+		}
+	}
+
+	flush()
+}
+
+// validateOriginalURL checks that an imported URL is an absolute http or https URL.
+func validateOriginalURL(originalURL string) error {
+	if originalURL == "" {
+		return errEmptyOriginalURL
+	}
+
+	u, err := url.ParseRequestURI(originalURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errInvalidOriginalURL
+	}
+
+	return nil
+}
+
+// csvImportReader reads data rows from a CSV body with a header row.
+type csvImportReader struct {
+	reader            *csv.Reader
+	urlIndex          int
+	correlationIndex  int
+	correlationMapped bool
+}
+
+// newCSVImportReader reads the header row of r and maps the urlColumn and correlationColumn columns,
+// compared case-insensitively. Returns an error if the header cannot be read or has no urlColumn column.
+func newCSVImportReader(r io.Reader, urlColumn, correlationColumn string) (*csvImportReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("error reading csv header")
+	}
+
+	ir := &csvImportReader{reader: reader, urlIndex: -1, correlationIndex: -1}
+
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		switch {
+		case strings.EqualFold(column, urlColumn):
+			ir.urlIndex = i
+		case strings.EqualFold(column, correlationColumn):
+			ir.correlationIndex = i
+			ir.correlationMapped = true
+		}
+	}
+
+	if ir.urlIndex < 0 {
+		return nil, errors.New("csv header has no " + urlColumn + " column")
+	}
+
+	return ir, nil
+}
+
+func (ir *csvImportReader) next() (importRow, error) {
+	record, err := ir.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return importRow{}, io.EOF
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return importRow{err: errors.New("malformed csv row")}, nil
+	}
+	if err != nil {
+		return importRow{}, err
+	}
+
+	var row importRow
+	if ir.urlIndex < len(record) {
+		row.request.OriginalURL = strings.TrimSpace(record[ir.urlIndex])
+	}
+	if ir.correlationMapped && ir.correlationIndex < len(record) {
+		row.request.CorrelationID = strings.TrimSpace(record[ir.correlationIndex])
+	}
+
+	return row, nil
+}
+
+// ndjsonImportReader reads data rows from an NDJSON body, blank lines are skipped.
+type ndjsonImportReader struct {
+	scanner *bufio.Scanner
+}
+
+func newNDJSONImportReader(r io.Reader) *ndjsonImportReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxNDJSONLineSize)
+	return &ndjsonImportReader{scanner: scanner}
+}
+
+func (ir *ndjsonImportReader) next() (importRow, error) {
+	for ir.scanner.Scan() {
+		line := ir.scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+
+		var row importRow
+		if err := json.Unmarshal(line, &row.request); err != nil {
+			row.err = errors.New("malformed json row")
+		}
+		return row, nil
+	}
+
+	if err := ir.scanner.Err(); err != nil {
+		return importRow{}, err
+	}
+
+	return importRow{}, io.EOF
+}
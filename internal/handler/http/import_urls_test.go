@@ -0,0 +1,132 @@
+package handlehttp_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newUserToken issues a token for a new user.
+func newUserToken(t *testing.T) string {
+	token, err := auth.CreateToken(uuid.New())
+	require.NoError(t, err)
+	return token
+}
+
+// readImportResults decodes the NDJSON results of an import response.
+func readImportResults(t *testing.T, r io.Reader) []dto.ImportResult {
+	var results []dto.ImportResult
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var result dto.ImportResult
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &result))
+		results = append(results, result)
+	}
+	require.NoError(t, scanner.Err())
+	return results
+}
+
+func TestImportUserURLs(t *testing.T) {
+	baseURL, shutdown := setupTestServer()
+	defer shutdown()
+
+	importURLs := func(t *testing.T, contentType, query string, body io.Reader, header http.Header) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, baseURL+"/api/user/urls/import"+query, body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		req.AddCookie(&http.Cookie{Name: "token", Value: newUserToken(t)})
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("csv with header mapping", func(t *testing.T) {
+		body := "id,Link\n" +
+			"a,https://import.example.com/csv/1\n" +
+			"b,not a url\n" +
+			",https://import.example.com/csv/3\n"
+
+		resp := importURLs(t, "text/csv", "?url_column=link&correlation_column=id", strings.NewReader(body), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+		results := readImportResults(t, resp.Body)
+		require.Len(t, results, 3)
+
+		assert.Equal(t, dto.ImportResult{Row: 1, CorrelationID: "a", ShortURL: results[0].ShortURL}, results[0])
+		assert.NotEmpty(t, results[0].ShortURL)
+		assert.Equal(t, "b", results[1].CorrelationID)
+		assert.NotEmpty(t, results[1].Error)
+		assert.Equal(t, "3", results[2].CorrelationID)
+		assert.NotEmpty(t, results[2].ShortURL)
+	})
+
+	t.Run("already shortened rows do not fail the others", func(t *testing.T) {
+		body := "original_url\n" +
+			"https://import.example.com/dup/1\n" +
+			"https://import.example.com/dup/1\n" +
+			"https://import.example.com/dup/2\n"
+
+		resp := importURLs(t, "text/csv", "", strings.NewReader(body), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		results := readImportResults(t, resp.Body)
+		require.Len(t, results, 3)
+
+		assert.Empty(t, results[0].Error)
+		assert.Equal(t, results[0].ShortURL, results[1].ShortURL, "the short url of the row already shortened is returned")
+		assert.NotEmpty(t, results[1].Error)
+		assert.NotEmpty(t, results[2].ShortURL)
+		assert.Empty(t, results[2].Error)
+	})
+
+	t.Run("csv without url column", func(t *testing.T) {
+		resp := importURLs(t, "text/csv", "", strings.NewReader("id,link\na,https://example.com\n"), nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("gzipped ndjson", func(t *testing.T) {
+		var body bytes.Buffer
+		gzw := gzip.NewWriter(&body)
+		_, _ = io.WriteString(gzw, `{"correlation_id":"x","original_url":"https://import.example.com/ndjson/1"}`+"\n\n"+
+			`{"correlation_id":"y",`+"\n")
+		require.NoError(t, gzw.Close())
+
+		resp := importURLs(t, "application/x-ndjson", "", &body, http.Header{
+			"Content-Encoding": {"gzip"},
+			"Accept-Encoding":  {"gzip"},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+
+		reader, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+
+		results := readImportResults(t, reader)
+		require.Len(t, results, 2)
+		assert.Equal(t, "x", results[0].CorrelationID)
+		assert.NotEmpty(t, results[0].ShortURL)
+		assert.Equal(t, 2, results[1].Row)
+		assert.NotEmpty(t, results[1].Error)
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		resp := importURLs(t, "application/json", "", strings.NewReader("[]"), nil)
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 )
 
 // InternalStats handles the request to retrieve internal statistics of the service.
@@ -15,9 +16,7 @@ func (s HandlerService) InternalStats(c *gin.Context) {
 	resp, err := s.Service.GetInternalStats(c.Request.Context())
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "internal server error",
-		})
+		problem.Write(c, err)
 		return
 	}
 
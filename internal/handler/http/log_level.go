@@ -0,0 +1,69 @@
+package handlehttp
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AdminGetLogLevel returns a handler reporting the current level of the logger.
+//
+// @Summary      Get the log level
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  dto.LogLevel
+// @Failure      403  {object}  problem.Details  "admin role required"
+// @Router       /api/admin/log/level [get]
+// @Security     ApiKeyAuth
+func (s HandlerService) AdminGetLogLevel(level zap.AtomicLevel) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, dto.LogLevel{Level: level.String()})
+	}
+}
+
+// AdminSetLogLevel returns a handler changing the level of the logger at runtime.
+//
+// @Summary      Change the log level
+// @Description  The level is one of debug, info, warn, error, dpanic, panic or fatal and applies until the next restart.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.LogLevel  true  "New log level"
+// @Success      200      {object}  dto.LogLevel
+// @Failure      400      {object}  problem.Details  "incorrect request body"
+// @Failure      403      {object}  problem.Details  "admin role required"
+// @Router       /api/admin/log/level [put]
+// @Security     ApiKeyAuth
+//
+// The change is logged at the warn level, so that it is recorded whatever the old and new levels are.
+func (s HandlerService) AdminSetLogLevel(level zap.AtomicLevel) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request dto.LogLevel
+
+		if err := c.ShouldBindJSON(&request); err != nil {
+			problem.Write(c, shrterr.InvalidInput("", "incorrect request body"))
+			return
+		}
+
+		newLevel, err := zapcore.ParseLevel(request.Level)
+		if err != nil {
+			problem.Write(c, shrterr.InvalidInput("level", "unknown log level: "+request.Level))
+			return
+		}
+
+		logger.FromContext(c.Request.Context(), nil).Warn(
+			"changing log level",
+			zap.Stringer("from", level.Level()),
+			zap.Stringer("to", newLevel),
+		)
+		level.SetLevel(newLevel)
+
+		c.JSON(http.StatusOK, dto.LogLevel{Level: newLevel.String()})
+	}
+}
@@ -0,0 +1,62 @@
+package handlehttp_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/mp1947/ya-url-shortener/internal/reload"
+	"github.com/mp1947/ya-url-shortener/internal/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAdminLogLevel(t *testing.T) {
+	adminCfg := cfg
+	adminCfg.AdminAPIKeys = []string{"test-admin-key"}
+
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	r := router.CreateRouter(adminCfg, hs.Service, storage, l, nil, nil, nil, reload.NewSettings(&adminCfg, &level, nil), nil, nil)
+
+	send := func(method, body, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/admin/log/level", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("reject missing admin role", func(t *testing.T) {
+		w := send(http.MethodPut, `{"level":"debug"}`, "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, zapcore.InfoLevel, level.Level())
+	})
+
+	t.Run("get level", func(t *testing.T) {
+		w := send(http.MethodGet, "", "test-admin-key")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp dto.LogLevel
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "info", resp.Level)
+	})
+
+	t.Run("set level", func(t *testing.T) {
+		w := send(http.MethodPut, `{"level":"debug"}`, "test-admin-key")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, zapcore.DebugLevel, level.Level())
+	})
+
+	t.Run("reject unknown level", func(t *testing.T) {
+		w := send(http.MethodPut, `{"level":"verbose"}`, "test-admin-key")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, zapcore.DebugLevel, level.Level())
+	})
+}
@@ -0,0 +1,153 @@
+package handlehttp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/oidc"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+)
+
+const (
+	oidcStateCookie  = "oidc_state"
+	oidcNonceCookie  = "oidc_nonce"
+	oidcLinkCookie   = "oidc_link"
+	oidcCookiePath   = "/auth"
+	oidcCookieMaxAge = 600
+)
+
+// OIDCLogin returns a Gin handler that starts the OpenID Connect authorization code flow.
+//
+// @Summary      Sign in with the identity provider
+// @Description  Redirects to the identity provider. With link=true the URLs of the current anonymous user are linked to the signed in identity.
+// @Tags         auth
+// @Param        link  query     bool    false  "Link the current anonymous user's URLs"
+// @Success      302   {string}  string  "Redirect to the identity provider"
+// @Failure      500   {object}  problem.Details  "internal server error"
+// @Router       /auth/login [get]
+//
+// The state and nonce sent to the identity provider are stored in short-lived cookies
+// scoped to the /auth path and checked by OIDCCallback.
+func (s HandlerService) OIDCLogin(p *oidc.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state, err := randomToken()
+		if err != nil {
+			problem.Write(c, err)
+			return
+		}
+
+		nonce, err := randomToken()
+		if err != nil {
+			problem.Write(c, err)
+			return
+		}
+
+		link := "0"
+		if c.Query("link") == "true" {
+			link = "1"
+		}
+
+		setOIDCCookie(c, oidcStateCookie, state, oidcCookieMaxAge)
+		setOIDCCookie(c, oidcNonceCookie, nonce, oidcCookieMaxAge)
+		setOIDCCookie(c, oidcLinkCookie, link, oidcCookieMaxAge)
+
+		c.Redirect(http.StatusFound, p.AuthCodeURL(state, nonce))
+	}
+}
+
+// OIDCCallback returns a Gin handler that completes the OpenID Connect authorization code flow.
+//
+// @Summary      Identity provider callback
+// @Description  Exchanges the authorization code, issues a token for the signed in identity and optionally links anonymous URLs.
+// @Tags         auth
+// @Produce      json
+// @Param        code   query     string  true  "Authorization code"
+// @Param        state  query     string  true  "State issued by /auth/login"
+// @Success      200    {object}  dto.AuthResponse
+// @Failure      400    {object}  problem.Details  "invalid oidc state"
+// @Failure      401    {object}  problem.Details  "oidc login failed"
+// @Failure      500    {object}  problem.Details  "internal server error"
+// @Router       /auth/callback [get]
+//
+// The identity provider subject is mapped to a stable shortener user ID. If the login was
+// started with link=true and the request carries an anonymous token, the URLs of that
+// anonymous user are transferred to the signed in user. The issued token is set as the
+// "token" cookie and returned in the response body.
+func (s HandlerService) OIDCCallback(p *oidc.Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state, _ := c.Cookie(oidcStateCookie)
+		if state == "" || c.Query("state") != state {
+			problem.Write(c, shrterr.ErrOIDCStateMismatch)
+			return
+		}
+
+		nonce, _ := c.Cookie(oidcNonceCookie)
+		link, _ := c.Cookie(oidcLinkCookie)
+
+		setOIDCCookie(c, oidcStateCookie, "", -1)
+		setOIDCCookie(c, oidcNonceCookie, "", -1)
+		setOIDCCookie(c, oidcLinkCookie, "", -1)
+
+		if c.Query("error") != "" {
+			problem.Write(c, shrterr.Unauthorized("oidc login failed"))
+			return
+		}
+
+		identity, err := p.Exchange(c.Request.Context(), c.Query("code"), nonce)
+		if err != nil {
+			problem.Write(c, shrterr.Unauthorized("oidc login failed"))
+			return
+		}
+
+		userID := auth.UserIDFromSubject(identity.Issuer, identity.Subject)
+
+		claims := auth.NewClaims(userID)
+		claims.Issuer = identity.Issuer
+		claims.Subject = identity.Subject
+
+		token, err := auth.CreateTokenWithClaims(claims)
+		if err != nil {
+			problem.Write(c, err)
+			return
+		}
+
+		var linked int64
+
+		currentUserID := c.GetString("user_id")
+		if link == "1" && currentUserID != "" && c.GetString("auth_method") == auth.MethodAnonymous {
+			linked, err = s.Service.LinkUserURLs(c.Request.Context(), currentUserID, userID.String())
+			if err != nil {
+				problem.Write(c, err)
+				return
+			}
+		}
+
+		c.SetCookie("token", token, int(auth.TokenTTL.Seconds()), "/", "", c.Request.TLS != nil, false)
+
+		c.JSON(http.StatusOK, dto.AuthResponse{
+			UserID:     userID.String(),
+			Token:      token,
+			LinkedURLs: linked,
+		})
+	}
+}
+
+// setOIDCCookie sets a cookie of the login flow scoped to the /auth path. Over TLS the cookie is
+// marked Secure, so that the browser never sends it over plain HTTP.
+func setOIDCCookie(c *gin.Context, name, value string, maxAge int) {
+	c.SetCookie(name, value, maxAge, oidcCookiePath, "", c.Request.TLS != nil, true)
+}
+
+// randomToken returns a hex-encoded random value suitable for the OIDC state and nonce.
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
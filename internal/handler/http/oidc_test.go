@@ -0,0 +1,221 @@
+package handlehttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/mp1947/ya-url-shortener/internal/oidc"
+	"github.com/mp1947/ya-url-shortener/internal/oidc/oidctest"
+	"github.com/mp1947/ya-url-shortener/internal/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupOIDCTestServer starts the shortener signing in with idp, over TLS when useTLS is set, and returns
+// its URL along with a client trusting it which does not follow redirects.
+func setupOIDCTestServer(t *testing.T, idp *oidctest.Server, useTLS bool) (string, *http.Client) {
+	srv := httptest.NewUnstartedServer(nil)
+
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+	serverURL := scheme + "://" + srv.Listener.Addr().String()
+
+	provider, err := oidc.NewProvider(context.Background(), config.OIDC{
+		IssuerURL:   idp.URL,
+		ClientID:    idp.ClientID,
+		RedirectURL: serverURL + "/auth/callback",
+	})
+	require.NoError(t, err)
+
+	srv.Config.Handler = router.CreateRouter(cfg, hs.Service, storage, l, provider, nil, nil, nil, nil, nil)
+	if useTLS {
+		srv.StartTLS()
+	} else {
+		srv.Start()
+	}
+	t.Cleanup(srv.Close)
+
+	client := srv.Client()
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return serverURL, client
+}
+
+// doOIDCRequest sends a request with the given cookies using client.
+func doOIDCRequest(t *testing.T, client *http.Client, method, url, body string, cookies ...*http.Cookie) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	for _, c := range cookies {
+		req.AddCookie(&http.Cookie{Name: c.Name, Value: c.Value})
+	}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = resp.Body.Close()
+	})
+	return resp
+}
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, c := range cookies {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestOIDCLogin(t *testing.T) {
+	idp, err := oidctest.NewServer("shortener", "employee-"+uuid.NewString())
+	require.NoError(t, err)
+	defer idp.Close()
+
+	serverURL, client := setupOIDCTestServer(t, idp, false)
+
+	do := func(method, url, body string, cookies ...*http.Cookie) *http.Response {
+		return doOIDCRequest(t, client, method, url, body, cookies...)
+	}
+
+	t.Run("login links anonymous urls", func(t *testing.T) {
+		originalURL := "https://oidc.example.com/" + uuid.NewString()
+
+		resp := do(http.MethodPost, serverURL+"/", originalURL)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		anonymousToken := findCookie(resp.Cookies(), "token")
+		require.NotNil(t, anonymousToken)
+
+		resp = do(http.MethodGet, serverURL+"/auth/login?link=true", "", anonymousToken)
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.True(t, strings.HasPrefix(resp.Header.Get("Location"), idp.URL+"/authorize"))
+		loginCookies := resp.Cookies()
+
+		resp = do(http.MethodGet, resp.Header.Get("Location"), "")
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		callbackURL := resp.Header.Get("Location")
+		assert.True(t, strings.HasPrefix(callbackURL, serverURL+"/auth/callback"))
+
+		resp = do(http.MethodGet, callbackURL, "", append(loginCookies, anonymousToken)...)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var authResp dto.AuthResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&authResp))
+
+		assert.Equal(t, auth.UserIDFromSubject(idp.URL, idp.Subject).String(), authResp.UserID)
+		assert.Equal(t, int64(1), authResp.LinkedURLs)
+
+		claims, err := auth.Parse(authResp.Token)
+		require.NoError(t, err)
+		assert.NotNil(t, claims.ExpiresAt)
+		assert.Equal(t, auth.MethodOIDC, claims.Method())
+
+		resp = do(
+			http.MethodGet,
+			serverURL+"/api/user/urls",
+			"",
+			&http.Cookie{Name: "token", Value: authResp.Token},
+		)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var userURLs []dto.ShortenURLsByUserID
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&userURLs))
+		require.Len(t, userURLs, 1)
+		assert.Equal(t, originalURL, userURLs[0].OriginalURL)
+	})
+
+	t.Run("same identity maps to same user", func(t *testing.T) {
+		resp := do(http.MethodGet, serverURL+"/auth/login", "")
+		loginCookies := resp.Cookies()
+
+		resp = do(http.MethodGet, resp.Header.Get("Location"), "")
+		resp = do(http.MethodGet, resp.Header.Get("Location"), "", loginCookies...)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var authResp dto.AuthResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&authResp))
+
+		assert.Equal(t, auth.UserIDFromSubject(idp.URL, idp.Subject).String(), authResp.UserID)
+		assert.Zero(t, authResp.LinkedURLs)
+	})
+
+	t.Run("state mismatch", func(t *testing.T) {
+		resp := do(http.MethodGet, serverURL+"/auth/login", "")
+		loginCookies := resp.Cookies()
+
+		resp = do(
+			http.MethodGet,
+			serverURL+"/auth/callback?code=whatever&state=forged",
+			"",
+			loginCookies...,
+		)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		resp := do(http.MethodGet, serverURL+"/auth/login", "")
+		loginCookies := resp.Cookies()
+		state := findCookie(loginCookies, "oidc_state")
+		require.NotNil(t, state)
+
+		resp = do(
+			http.MethodGet,
+			serverURL+"/auth/callback?code=unknown&state="+state.Value,
+			"",
+			loginCookies...,
+		)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestOIDCCookies(t *testing.T) {
+	idp, err := oidctest.NewServer("shortener", "employee-"+uuid.NewString())
+	require.NoError(t, err)
+	defer idp.Close()
+
+	for _, useTLS := range []bool{false, true} {
+		t.Run(fmt.Sprintf("tls %t", useTLS), func(t *testing.T) {
+			serverURL, client := setupOIDCTestServer(t, idp, useTLS)
+
+			resp := doOIDCRequest(t, client, http.MethodGet, serverURL+"/auth/login", "")
+			require.Equal(t, http.StatusFound, resp.StatusCode)
+			loginCookies := resp.Cookies()
+			for _, name := range []string{"oidc_state", "oidc_nonce", "oidc_link"} {
+				c := findCookie(loginCookies, name)
+				require.NotNil(t, c, name)
+				assert.Equal(t, useTLS, c.Secure, name)
+				assert.True(t, c.HttpOnly, name)
+			}
+
+			resp = doOIDCRequest(t, client, http.MethodGet, resp.Header.Get("Location"), "")
+			resp = doOIDCRequest(t, client, http.MethodGet, resp.Header.Get("Location"), "", loginCookies...)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			for _, name := range []string{"oidc_state", "oidc_nonce", "oidc_link"} {
+				c := findCookie(resp.Cookies(), name)
+				require.NotNil(t, c, name)
+				assert.Equal(t, useTLS, c.Secure, name)
+				assert.Negative(t, c.MaxAge, name)
+			}
+
+			token := findCookie(resp.Cookies(), "token")
+			require.NotNil(t, token)
+			assert.Equal(t, int(auth.TokenTTL.Seconds()), token.MaxAge)
+			assert.Empty(t, token.Domain)
+			assert.Equal(t, useTLS, token.Secure)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 	"github.com/mp1947/ya-url-shortener/internal/repository/database"
 )
 
@@ -19,13 +20,13 @@ import (
 // @Tags         health
 // @Produce      plain
 // @Success      200  {string}  string  "OK"
-// @Failure      500  {string}  string  "Internal Server Error"
+// @Failure      500  {object}  problem.Details  "Internal Server Error"
 // @Router       /ping [get]
 func (s HandlerService) Ping(db *database.Database) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		err := db.Ping(c.Request.Context())
 		if err != nil {
-			c.Status(http.StatusInternalServerError)
+			problem.Write(c, err)
 			return
 		}
 		c.Status(http.StatusOK)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 )
 
 // ShortenURL handles the shortening of a given URL sent in the request body.
@@ -19,27 +20,27 @@ import (
 // @Param        url  body      string  true  "Original URL to shorten"
 // @Success      201  {string}  string  "Shortened URL"
 // @Conflict     409  {string}  string  "Shortened URL already exists"
-// @Failure      400  {string}  string  "Invalid request"
-// @Failure      500  {string}  string  "Internal server error"
+// @Failure      400  {object}  problem.Details  "Invalid request"
+// @Failure      500  {object}  problem.Details  "Internal server error"
 // @Router       /api/shorten [post]
 //
 // The handler expects a POST request with the original URL in the request body as plain text.
 // It returns the shortened URL in plain text format. If the URL has already been shortened,
-// it returns a 409 Conflict with the existing shortened URL. For invalid requests or internal
-// errors, appropriate HTTP status codes and messages are returned.
+// it returns a 409 Conflict with the existing shortened URL. Invalid requests and internal
+// errors are answered with problem details.
 func (s HandlerService) ShortenURL(c *gin.Context) {
 
 	userID, _ := c.Get("user_id")
 
 	if c.Request.Method != http.MethodPost {
-		c.Data(http.StatusBadRequest, contentTypePlain, nil)
+		problem.Write(c, errMethodNotSupported)
 		return
 	}
 
 	body, err := io.ReadAll(c.Request.Body)
 
 	if err != nil || string(body) == "" {
-		c.Data(http.StatusBadRequest, contentTypePlain, nil)
+		problem.Write(c, shrterr.InvalidInput("", "request body must hold the url to shorten"))
 		return
 	}
 
@@ -53,11 +54,7 @@ func (s HandlerService) ShortenURL(c *gin.Context) {
 		c.Data(http.StatusConflict, contentTypePlain, []byte(shortURL))
 		return
 	} else if err != nil {
-		c.Data(
-			http.StatusInternalServerError,
-			contentTypePlain,
-			[]byte("internal server error while shorten url"),
-		)
+		problem.Write(c, err)
 		return
 	}
 
@@ -6,6 +6,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 )
 
 // BatchShortenURL handles batch URL shortening requests.
@@ -17,8 +19,8 @@ import (
 // @Produce      json
 // @Param        body  body      []dto.BatchShortenRequest  true  "Batch shorten request"
 // @Success      201   {array}   dto.BatchShortenResponse
-// @Failure      400   {object}  map[string]string  "incorrect request body"
-// @Failure      500   {object}  map[string]string  "error while batch url shorten"
+// @Failure      400   {object}  problem.Details  "incorrect request body"
+// @Failure      500   {object}  problem.Details  "error while batch url shorten"
 // @Router       /api/shorten/batch [post]
 // @Security     ApiKeyAuth
 //
@@ -30,16 +32,12 @@ func (s HandlerService) BatchShortenURL(c *gin.Context) {
 	userID, _ := c.Get("user_id")
 
 	if err := c.ShouldBindJSON(&batchRequestData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "incorrect request body (error json binding)",
-		})
+		problem.Write(c, shrterr.InvalidInput("", "incorrect request body (error json binding)"))
 		return
 	}
 
 	if len(batchRequestData) < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "incorrect request body (items in array less than 1)",
-		})
+		problem.Write(c, shrterr.InvalidInput("", "incorrect request body (items in array less than 1)"))
 		return
 	}
 
@@ -50,9 +48,7 @@ func (s HandlerService) BatchShortenURL(c *gin.Context) {
 	)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "error while batch url shorten",
-		})
+		problem.Write(c, err)
 		return
 	}
 
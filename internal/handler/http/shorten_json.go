@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/mp1947/ya-url-shortener/internal/dto"
 	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 )
 
 // JSONShortenURL handles the shortening of a URL provided in JSON format.
@@ -21,8 +22,8 @@ import (
 // @Param        request  body      dto.ShortenRequest  true  "URL to shorten"
 // @Success      201      {object}  dto.ShortenResponse "Shortened URL created"
 // @Conflict     409      {object}  dto.ShortenResponse "URL already shortened"
-// @Failure      400      {object}  dto.ShortenResponse "Invalid request"
-// @Failure      500      {object}  gin.H               "Internal server error"
+// @Failure      400      {object}  problem.Details  "Invalid request"
+// @Failure      500      {object}  problem.Details  "Internal server error"
 // @Router       /api/shorten [post]
 // @Security     ApiKeyAuth
 //
@@ -36,19 +37,12 @@ func (s HandlerService) JSONShortenURL(c *gin.Context) {
 	userID, _ := c.Get("user_id")
 
 	if err != nil {
-		c.JSON(
-			http.StatusBadRequest,
-			dto.ShortenResponse{Result: requestBodyGetErr},
-		)
+		problem.Write(c, shrterr.InvalidInput("", requestBodyGetErr))
 		return
 	}
 
 	if unmarshalErr := json.Unmarshal(rawRequest, &request); unmarshalErr != nil {
-
-		c.JSON(
-			http.StatusBadRequest,
-			dto.ShortenResponse{Result: requestBindingErr},
-		)
+		problem.Write(c, shrterr.InvalidInput("", requestBindingErr))
 		return
 	}
 
@@ -62,9 +56,7 @@ func (s HandlerService) JSONShortenURL(c *gin.Context) {
 		c.JSON(http.StatusConflict, dto.ShortenResponse{Result: shortURL})
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "internal server error while shorten url",
-		})
+		problem.Write(c, err)
 		return
 	}
 
@@ -0,0 +1,153 @@
+// Package health evaluates the liveness and readiness of the URL shortener. Readiness is the
+// combination of named checks (storage reachable, migrations applied, background workers alive)
+// and is lost for good once the application starts shutting down. The result is exposed over
+// HTTP by the /healthz and /readyz endpoints and over gRPC by the grpc.health.v1 service.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// StatusOK is the status of a passing check and of a ready application.
+	StatusOK = "ok"
+	// StatusUnavailable is the status of an application which is not ready.
+	StatusUnavailable = "unavailable"
+
+	// checkTimeout bounds the duration of a single check.
+	checkTimeout = 2 * time.Second
+
+	// defaultWatchInterval is the interval between readiness evaluations used when none is configured.
+	defaultWatchInterval = 5 * time.Second
+)
+
+// CheckFunc reports an error when the checked dependency is not ready.
+type CheckFunc func(ctx context.Context) error
+
+type namedCheck struct {
+	name     string
+	check    CheckFunc
+	optional bool
+}
+
+// Report is the result of a readiness evaluation, Checks maps the name of every check to
+// StatusOK or to the error it reported. Failed optional checks are reported without making
+// the application unavailable.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Ready reports whether every check passed.
+func (r Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+// Checker holds the readiness checks of the application. The zero value is not usable, use NewChecker.
+type Checker struct {
+	mu     sync.RWMutex
+	checks []namedCheck
+
+	shuttingDown atomic.Bool
+}
+
+// NewChecker returns a Checker without checks, which is ready until SetShuttingDown is called.
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Add registers a readiness check under name.
+func (c *Checker) Add(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// AddOptional registers a check under name for a dependency the application can do without, such as a
+// database replica: its failure is reported but does not make the application unavailable.
+func (c *Checker) AddOptional(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, namedCheck{name: name, check: check, optional: true})
+}
+
+// SetShuttingDown marks the application as shutting down, every later evaluation reports it as not ready.
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Ready runs every check, each one bounded by checkTimeout, and returns the resulting report.
+func (c *Checker) Ready(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: map[string]string{}}
+
+	if c.shuttingDown.Load() {
+		report.Status = StatusUnavailable
+		report.Checks["shutdown"] = shrterr.ErrShuttingDown.Error()
+		return report
+	}
+
+	c.mu.RLock()
+	checks := c.checks
+	c.mu.RUnlock()
+
+	for _, nc := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err := nc.check(checkCtx)
+		cancel()
+
+		if err != nil {
+			if !nc.optional {
+				report.Status = StatusUnavailable
+			}
+			report.Checks[nc.name] = err.Error()
+			continue
+		}
+		report.Checks[nc.name] = StatusOK
+	}
+
+	return report
+}
+
+// Watch evaluates the readiness every interval until ctx is done and reports it as the serving
+// status of the given services, and of the whole server, on the gRPC health server hs.
+// A non-positive interval falls back to defaultWatchInterval.
+func (c *Checker) Watch(ctx context.Context, interval time.Duration, hs *health.Server, services ...string) {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	update := func() {
+		status := healthpb.HealthCheckResponse_SERVING
+		if !c.Ready(ctx).Ready() {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		hs.SetServingStatus("", status)
+		for _, service := range services {
+			hs.SetServingStatus(service, status)
+		}
+	}
+
+	update()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestChecker(t *testing.T) {
+	var storageErr error
+
+	hc := health.NewChecker()
+	hc.Add("storage", func(context.Context) error { return storageErr })
+
+	report := hc.Ready(context.Background())
+	assert.True(t, report.Ready())
+	assert.Equal(t, map[string]string{"storage": health.StatusOK}, report.Checks)
+
+	storageErr = errors.New("connection refused")
+	report = hc.Ready(context.Background())
+	assert.False(t, report.Ready())
+	assert.Equal(t, "connection refused", report.Checks["storage"])
+
+	storageErr = nil
+	hc.SetShuttingDown()
+	report = hc.Ready(context.Background())
+	assert.False(t, report.Ready())
+	assert.Contains(t, report.Checks, "shutdown")
+}
+
+func TestOptionalCheck(t *testing.T) {
+	hc := health.NewChecker()
+	hc.Add("storage", func(context.Context) error { return nil })
+	hc.AddOptional("storage_replica_1", func(context.Context) error { return errors.New("connection refused") })
+
+	report := hc.Ready(context.Background())
+	assert.True(t, report.Ready())
+	assert.Equal(t, map[string]string{
+		"storage":           health.StatusOK,
+		"storage_replica_1": "connection refused",
+	}, report.Checks)
+}
+
+func TestWatch(t *testing.T) {
+	var ready = make(chan error, 1)
+	ready <- nil
+
+	var lastErr error
+	hc := health.NewChecker()
+	hc.Add("storage", func(context.Context) error {
+		select {
+		case err := <-ready:
+			lastErr = err
+		default:
+		}
+		return lastErr
+	})
+
+	hs := grpchealth.NewServer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hc.Watch(ctx, 10*time.Millisecond, hs, "proto.Shortener")
+
+	status := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN
+		}
+		return resp.Status
+	}
+
+	require.Eventually(t, func() bool {
+		return status("proto.Shortener") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 5*time.Millisecond)
+
+	ready <- errors.New("connection refused")
+
+	require.Eventually(t, func() bool {
+		return status("") == healthpb.HealthCheckResponse_NOT_SERVING &&
+			status("proto.Shortener") == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,65 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mp1947/ya-url-shortener/internal/apikey"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// adminServicePrefix is the full method prefix of the Admin gRPC service.
+const adminServicePrefix = "/proto.Admin/"
+
+// AdminUnaryInterceptor returns a gRPC unary server interceptor guarding the Admin service.
+// Calls to other services are passed through unchanged. Admin calls must carry either an
+// "x-api-key" metadata value known to keys, or an "authorization" token with the admin role.
+// The authorized caller is stored as an auth.Actor in the context passed to the handler.
+// An unknown API key results in codes.Unauthenticated, a missing admin role in codes.PermissionDenied.
+func AdminUnaryInterceptor(keys apikey.Store) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if !strings.HasPrefix(info.FullMethod, adminServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		if rawKey := md.Get("x-api-key"); len(rawKey) > 0 {
+			key, err := keys.Lookup(ctx, rawKey[0])
+			if err != nil || key.Role != auth.RoleAdmin {
+				return nil, problem.Error(shrterr.ErrInvalidAPIKey)
+			}
+
+			return handler(auth.WithActor(ctx, auth.Actor{
+				ID:       "apikey:" + key.ID,
+				Method:   auth.MethodAPIKey,
+				Role:     key.Role,
+				ClientIP: clientip.StringFromContext(ctx),
+			}), req)
+		}
+
+		if token := md.Get("authorization"); len(token) > 0 {
+			claims, err := auth.Parse(strings.TrimPrefix(token[0], "Bearer "))
+			if err == nil && claims.IsAdmin() {
+				return handler(auth.WithActor(ctx, auth.Actor{
+					ID:       claims.UserID.String(),
+					Method:   claims.Method(),
+					Role:     claims.Role,
+					ClientIP: clientip.StringFromContext(ctx),
+				}), req)
+			}
+		}
+
+		return nil, problem.Error(shrterr.ErrAdminRoleRequired)
+	}
+}
@@ -9,16 +9,20 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 )
 
 // AuthUnaryInterceptor is a gRPC unary server interceptor that handles authentication via metadata tokens.
 // It checks for the presence of an "authorization" token in the incoming context metadata. If the token is valid,
 // it extracts the associated user information and appends it to the metadata. If the token is missing or invalid,
 // it generates a new user ID and token, appends them to the metadata, and updates the context accordingly.
+// The caller is also stored as an auth.Actor in the context and its user ID is added to the request-scoped logger.
 // The interceptor then calls the handler with the updated context. Returns an error if metadata is missing or
 // token creation fails.
 func AuthUnaryInterceptor(
@@ -27,22 +31,55 @@ func AuthUnaryInterceptor(
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (any, error) {
+	ctx, err := authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// AuthStreamInterceptor is the streaming counterpart of AuthUnaryInterceptor. The stream handler
+// receives a stream whose context carries the updated metadata and the auth.Actor.
+func AuthStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate resolves the caller from the "authorization" metadata of ctx, issuing a new user
+// and token when it is missing or invalid, and returns ctx updated as described for AuthUnaryInterceptor.
+func authenticate(ctx context.Context) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 
 	if !ok {
-		return nil, status.Errorf(codes.InvalidArgument, "metadata is not provided")
+		return nil, problem.Error(shrterr.InvalidInput("", "metadata is not provided"))
 	}
 
 	tokenFromMD := md.Get("authorization")
 	var newToken string
 	var user uuid.UUID
 	var isExists bool
+	actor := auth.Actor{Method: auth.MethodAnonymous, ClientIP: clientip.StringFromContext(ctx)}
 
 	if len(tokenFromMD) == 0 {
 		isExists = false
 	} else {
-		ok, user = auth.Validate(tokenFromMD[0])
-		isExists = ok
+		claims, err := auth.Parse(tokenFromMD[0])
+		if err == nil {
+			isExists = true
+			user = claims.UserID
+			actor.Method = claims.Method()
+			actor.Role = claims.Role
+		}
 	}
 
 	if !isExists {
@@ -51,7 +88,7 @@ func AuthUnaryInterceptor(
 		var err error
 		newToken, err = auth.CreateToken(user)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "error creating new token: %v", err)
+			return nil, problem.Error(err)
 		}
 		md.Set("user_id", user.String())
 		md.Set("token", newToken)
@@ -61,7 +98,11 @@ func AuthUnaryInterceptor(
 		md.Set("token", tokenFromMD[0])
 	}
 
+	actor.ID = user.String()
+
 	ctx = metadata.NewIncomingContext(ctx, md)
+	ctx = auth.WithActor(ctx, actor)
+	ctx = logger.WithFields(ctx, zap.String("user_id", actor.ID))
 
-	return handler(ctx, req)
+	return ctx, nil
 }
@@ -0,0 +1,59 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/mp1947/ya-url-shortener/internal/certs"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/gateway"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientCertUnaryInterceptor returns a gRPC unary server interceptor requiring the calls to be made
+// with a client certificate issued by one of clientCAs. It verifies the certificates of the gRPC clients
+// when the listener is shared with HTTP clients, whose TLS handshake only requests one.
+// Calls forwarded in-process by the JSON/HTTP gateway serve HTTP clients and are passed through.
+// Calls without a valid certificate are rejected with codes.Unauthenticated.
+func ClientCertUnaryInterceptor(clientCAs *x509.CertPool) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := checkClientCert(ctx, clientCAs); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ClientCertStreamInterceptor is the streaming counterpart of ClientCertUnaryInterceptor.
+func ClientCertStreamInterceptor(clientCAs *x509.CertPool) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := checkClientCert(ss.Context(), clientCAs); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// checkClientCert returns the status error of a call made without a client certificate issued by one of clientCAs.
+func checkClientCert(ctx context.Context, clientCAs *x509.CertPool) error {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil && p.Addr.Network() == gateway.Network {
+		return nil
+	}
+
+	if err := certs.VerifyClientCertificate(peerCertificates(ctx), clientCAs); err != nil {
+		return problem.Error(&shrterr.Error{Kind: shrterr.KindUnauthorized, Message: "client certificate rejected", Err: err})
+	}
+	return nil
+}
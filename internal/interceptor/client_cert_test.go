@@ -0,0 +1,81 @@
+package interceptor_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/internal/gateway"
+	"github.com/mp1947/ya-url-shortener/internal/interceptor"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// gatewayAddr is the address of the in-process connections of the gateway.
+type gatewayAddr struct{}
+
+func (gatewayAddr) Network() string { return gateway.Network }
+func (gatewayAddr) String() string  { return gateway.Network }
+
+func TestClientCertUnaryInterceptor(t *testing.T) {
+	ca, caKey := newCA(t, "clients-ca")
+	otherCA, otherCAKey := newCA(t, "other-ca")
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+
+	callCtx := func(addr net.Addr, peerCerts ...*x509.Certificate) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: addr,
+			AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{PeerCertificates: peerCerts},
+			},
+		})
+	}
+	clientAddr := &net.TCPAddr{IP: net.ParseIP("203.0.113.5"), Port: 40000}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{
+			name:     "client certificate from configured ca",
+			ctx:      callCtx(clientAddr, newClientCert(t, ca, caKey)),
+			wantCode: codes.OK,
+		},
+		{
+			name:     "no client certificate",
+			ctx:      callCtx(clientAddr),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "client certificate from unknown ca",
+			ctx:      callCtx(clientAddr, newClientCert(t, otherCA, otherCAKey)),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "call of the gateway",
+			ctx:      callCtx(gatewayAddr{}),
+			wantCode: codes.OK,
+		},
+	}
+
+	i := interceptor.ClientCertUnaryInterceptor(clientCAs)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := i(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/proto.Shortener/ShortenURL"}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
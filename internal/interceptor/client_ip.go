@@ -0,0 +1,61 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	"github.com/mp1947/ya-url-shortener/internal/gateway"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientIPUnaryInterceptor returns a gRPC unary server interceptor resolving the IP address of the
+// client with the given resolver and storing it in the context, where it can be read with
+// clientip.FromContext. The "forwarded", "x-forwarded-for" and "x-real-ip" metadata values are
+// honoured only for calls received from trusted proxies. Calls forwarded in-process by the JSON/HTTP gateway
+// carry the client IP already resolved by the HTTP server. It should precede every interceptor relying on the client IP.
+func ClientIPUnaryInterceptor(resolver *clientip.Resolver) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		return handler(clientip.WithIP(ctx, resolveClientIP(ctx, resolver)), req)
+	}
+}
+
+// ClientIPStreamInterceptor is the streaming counterpart of ClientIPUnaryInterceptor.
+// It should precede every stream interceptor relying on the client IP.
+func ClientIPStreamInterceptor(resolver *clientip.Resolver) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := clientip.WithIP(ss.Context(), resolveClientIP(ss.Context(), resolver))
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// resolveClientIP resolves the client IP of a call from its peer address and incoming metadata.
+func resolveClientIP(ctx context.Context, resolver *clientip.Resolver) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if p.Addr.Network() == gateway.Network {
+		if values := md.Get(gateway.ClientIPMetadataKey); len(values) > 0 {
+			return net.ParseIP(values[0])
+		}
+		return nil
+	}
+
+	return resolver.ResolveMetadata(p.Addr.String(), md.Get)
+}
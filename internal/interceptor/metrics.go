@@ -0,0 +1,52 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsUnaryInterceptor is a gRPC unary server interceptor counting calls and observing their latency
+// in the metrics.GRPCRequestsTotal and metrics.GRPCRequestDuration metrics, labelled by full method name
+// and status code. It should be the first interceptor in the chain so that rejected calls are counted too.
+func MetricsUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	t := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	observeCall(info.FullMethod, t, err)
+
+	return resp, err
+}
+
+// MetricsStreamInterceptor is the streaming counterpart of MetricsUnaryInterceptor,
+// the latency of a stream is the time until the handler returns.
+func MetricsStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	t := time.Now()
+
+	err := handler(srv, ss)
+
+	observeCall(info.FullMethod, t, err)
+
+	return err
+}
+
+func observeCall(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+
+	metrics.GRPCRequestsTotal.WithLabelValues(method, code).Inc()
+	metrics.GRPCRequestDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
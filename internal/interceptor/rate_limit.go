@@ -0,0 +1,82 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/gateway"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"github.com/mp1947/ya-url-shortener/internal/ratelimit"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// RateLimitUnaryInterceptor returns a gRPC unary server interceptor limiting the rate of the calls of every
+// client IP with limiter. Calls over the limit fail with codes.ResourceExhausted, a google.rpc.RetryInfo detail
+// and a "retry-after" trailer in seconds. Calls forwarded in-process by the JSON/HTTP gateway were already limited by the HTTP server and
+// are not limited again. When the store of the limiter fails, the error is logged and the call proceeds.
+// It must follow ClientIPUnaryInterceptor.
+func RateLimitUnaryInterceptor(l *zap.Logger, limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		setTrailer := func(md metadata.MD) { _ = grpc.SetTrailer(ctx, md) }
+		if err := checkRateLimit(ctx, l, limiter, setTrailer); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamInterceptor is the streaming counterpart of RateLimitUnaryInterceptor,
+// a stream takes a single token when it starts.
+func RateLimitStreamInterceptor(l *zap.Logger, limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := checkRateLimit(ss.Context(), l, limiter, ss.SetTrailer); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// checkRateLimit takes a token for the client of the call, returning the status error of
+// a call over the limit after setting its trailer with setTrailer.
+func checkRateLimit(ctx context.Context, l *zap.Logger, limiter *ratelimit.Limiter, setTrailer func(metadata.MD)) error {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil && p.Addr.Network() == gateway.Network {
+		return nil
+	}
+
+	allowed, wait, err := limiter.Allow(ctx, clientip.StringFromContext(ctx))
+	if err != nil {
+		logger.FromContext(ctx, l).Warn("error checking rate limit", zap.Error(err))
+	}
+	if allowed {
+		return nil
+	}
+
+	metrics.RateLimitedTotal.WithLabelValues("grpc").Inc()
+	setTrailer(metadata.Pairs("retry-after", retryAfterSeconds(wait)))
+
+	return problem.Error(shrterr.RateLimited(wait))
+}
+
+// retryAfterSeconds returns wait in whole seconds.
+func retryAfterSeconds(wait time.Duration) string {
+	return fmt.Sprint(int(math.Ceil(wait.Seconds())))
+}
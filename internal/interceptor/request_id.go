@@ -0,0 +1,86 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDUnaryInterceptor returns a gRPC unary server interceptor assigning a request ID to every call,
+// keeping the one of the "x-request-id" metadata when it is valid, and echoing it in the "x-request-id"
+// response header. The ID is stored in the context along with a request-scoped logger derived from l
+// carrying it, which can be read with logger.FromContext. Errors returned by the call carry the ID as an
+// errdetails.RequestInfo detail. It should precede every interceptor that logs.
+func RequestIDUnaryInterceptor(l *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx, id := withRequestID(ctx, l)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+
+		resp, err := handler(ctx, req)
+
+		return resp, withRequestInfo(err, id)
+	}
+}
+
+// RequestIDStreamInterceptor is the streaming counterpart of RequestIDUnaryInterceptor.
+func RequestIDStreamInterceptor(l *zap.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, id := withRequestID(ss.Context(), l)
+		_ = ss.SetHeader(metadata.Pairs(requestid.MetadataKey, id))
+
+		err := handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+
+		return withRequestInfo(err, id)
+	}
+}
+
+// withRequestID resolves the request ID of a call from its incoming metadata and returns ctx
+// carrying it and the request-scoped logger.
+func withRequestID(ctx context.Context, l *zap.Logger) (context.Context, string) {
+	var supplied string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestid.MetadataKey); len(values) > 0 {
+			supplied = values[0]
+		}
+	}
+
+	id := requestid.Resolve(supplied)
+
+	ctx = requestid.WithID(ctx, id)
+	ctx = logger.WithContext(ctx, l.With(zap.String("request_id", id)))
+
+	return ctx, id
+}
+
+// withRequestInfo adds the request ID to the details of the status of err. Errors which are not
+// gRPC statuses are converted with problem.Status, so that domain errors returned as is are reported
+// with the code of their kind.
+func withRequestInfo(err error, id string) error {
+	if err == nil {
+		return nil
+	}
+
+	st := problem.Status(err)
+	withDetails, detailsErr := st.WithDetails(&errdetails.RequestInfo{RequestId: id})
+	if detailsErr != nil {
+		return err
+	}
+
+	return withDetails.Err()
+}
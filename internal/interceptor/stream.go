@@ -0,0 +1,18 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// wrappedStream is a grpc.ServerStream whose context is replaced by a stream interceptor.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream set by the interceptor.
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
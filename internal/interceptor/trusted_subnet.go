@@ -0,0 +1,70 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/x509"
+	"slices"
+
+	"github.com/mp1947/ya-url-shortener/internal/certs"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// InternalStatsMethod is the full method name of the internal statistics RPC.
+const InternalStatsMethod = "/proto.Shortener/GetInternalStats"
+
+// TrustedSubnetUnaryInterceptor returns a gRPC unary server interceptor restricting the given
+// methods to clients from the trusted subnets. The client IP is the one resolved by
+// ClientIPUnaryInterceptor, so "x-real-ip" metadata is honoured only for calls from trusted proxies.
+// The subnets are read on every call, so replacing them applies to the next calls.
+// If clientCAs is not nil, the call must additionally be made over TLS with a client certificate
+// issued by one of those CAs, so calls made without TLS, those of the gateway included, are rejected.
+// Calls to other methods are passed through unchanged.
+// Unauthorized calls are rejected with codes.PermissionDenied.
+func TrustedSubnetUnaryInterceptor(
+	subnets *clientip.Networks,
+	clientCAs *x509.CertPool,
+	methods ...string,
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if !slices.Contains(methods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		if !subnets.Contains(clientip.FromContext(ctx)) {
+			return nil, problem.Error(shrterr.Forbidden("not authorized to access"))
+		}
+
+		if clientCAs != nil {
+			if err := certs.VerifyClientCertificate(peerCertificates(ctx), clientCAs); err != nil {
+				return nil, problem.Error(&shrterr.Error{Kind: shrterr.KindForbidden, Message: "client certificate rejected", Err: err})
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// peerCertificates returns the certificates presented by the TLS peer of the call, if any.
+func peerCertificates(ctx context.Context) []*x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+
+	return tlsInfo.State.PeerCertificates
+}
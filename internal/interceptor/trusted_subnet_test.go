@@ -0,0 +1,156 @@
+package interceptor_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	"github.com/mp1947/ya-url-shortener/internal/interceptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func newCertificate(
+	t *testing.T,
+	template *x509.Certificate,
+	parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey,
+) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func newCA(t *testing.T, name string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	return newCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+}
+
+func newClientCert(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey) *x509.Certificate {
+	cert, _ := newCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "ops-tooling"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	return cert
+}
+
+func TestTrustedSubnetUnaryInterceptor(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("192.168.1.0/24")
+	subnets := clientip.NewNetworks([]*net.IPNet{subnet})
+
+	ca, caKey := newCA(t, "ops-ca")
+	otherCA, otherCAKey := newCA(t, "other-ca")
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+
+	callCtx := func(ip string, peerCerts ...*x509.Certificate) context.Context {
+		ctx := clientip.WithIP(context.Background(), net.ParseIP(ip))
+		if len(peerCerts) == 0 {
+			return ctx
+		}
+		return peer.NewContext(ctx, &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000},
+			AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{PeerCertificates: peerCerts},
+			},
+		})
+	}
+
+	tests := []struct {
+		name      string
+		clientCAs *x509.CertPool
+		method    string
+		ctx       context.Context
+		wantCode  codes.Code
+	}{
+		{
+			name:     "other methods are not guarded",
+			method:   "/proto.Shortener/ShortenURL",
+			ctx:      callCtx("203.0.113.5"),
+			wantCode: codes.OK,
+		},
+		{
+			name:     "client in trusted subnet",
+			method:   interceptor.InternalStatsMethod,
+			ctx:      callCtx("192.168.1.10"),
+			wantCode: codes.OK,
+		},
+		{
+			name:     "client outside trusted subnet",
+			method:   interceptor.InternalStatsMethod,
+			ctx:      callCtx("203.0.113.5"),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:      "client certificate required",
+			clientCAs: clientCAs,
+			method:    interceptor.InternalStatsMethod,
+			ctx:       callCtx("192.168.1.10"),
+			wantCode:  codes.PermissionDenied,
+		},
+		{
+			name:      "client certificate from configured ca",
+			clientCAs: clientCAs,
+			method:    interceptor.InternalStatsMethod,
+			ctx:       callCtx("192.168.1.10", newClientCert(t, ca, caKey)),
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "client certificate from unknown ca",
+			clientCAs: clientCAs,
+			method:    interceptor.InternalStatsMethod,
+			ctx:       callCtx("192.168.1.10", newClientCert(t, otherCA, otherCAKey)),
+			wantCode:  codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := interceptor.TrustedSubnetUnaryInterceptor(subnets, tt.clientCAs, interceptor.InternalStatsMethod)
+
+			_, err := i(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package jobs records the status of the deletion jobs, the batches of short URLs deleted asynchronously
+// on behalf of users. Statuses are kept by a Store for a bounded time: Local keeps them in the process,
+// a store shared by the instances of the service, such as the Redis one, lets a user query the status of
+// a job from any instance.
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/cache"
+)
+
+// States of a deletion job.
+const (
+	StatePending = "pending"
+	StateRunning = "running"
+	StateDone    = "done"
+	StateFailed  = "failed"
+)
+
+// Status is the status of a deletion job of ShortURLs short URLs requested by UserID. Deleted is the
+// number of deleted short URLs once the job is done, Error is the message of the failure as reported to
+// clients, which does not disclose unexpected causes.
+type Status struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	State     string    `json:"state"`
+	ShortURLs int       `json:"short_urls"`
+	Deleted   int64     `json:"deleted"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Store keeps the statuses of the deletion jobs.
+type Store interface {
+	// Put stores s, replacing the status of the same job.
+	Put(ctx context.Context, s Status) error
+	// Get returns the status of the job with the given ID and whether it was found.
+	Get(ctx context.Context, id string) (Status, bool, error)
+}
+
+// Local is a Store keeping at most a given number of statuses in the process, each for a given time.
+type Local struct {
+	c   *cache.Cache[string, Status]
+	ttl time.Duration
+}
+
+// NewLocal returns a Local store keeping at most size statuses, each for ttl after its last update.
+// The least recently used statuses are dropped first.
+func NewLocal(size int, ttl time.Duration) *Local {
+	c, _ := cache.New[string, Status](size, cache.LRU)
+	return &Local{c: c, ttl: ttl}
+}
+
+// Put stores s.
+func (l *Local) Put(ctx context.Context, s Status) error {
+	l.c.Set(s.ID, s, l.ttl)
+	return nil
+}
+
+// Get returns the status of the job with the given ID.
+func (l *Local) Get(ctx context.Context, id string) (Status, bool, error) {
+	s, ok := l.c.Get(id)
+	return s, ok, nil
+}
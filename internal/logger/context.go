@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type loggerCtxKey struct{}
+
+// WithContext returns a copy of ctx carrying l, the logger scoped to the request ctx belongs to.
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// FromContext returns the request-scoped logger stored in ctx. If there is none it returns fallback,
+// or a no-op logger when fallback is nil, so that the result can always be used.
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if l, ok := ctx.Value(loggerCtxKey{}).(*zap.Logger); ok {
+		return l
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return zap.NewNop()
+}
+
+// WithFields returns a copy of ctx whose request-scoped logger carries the given fields in addition
+// to its own. It returns ctx unchanged when ctx carries no logger.
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	l, ok := ctx.Value(loggerCtxKey{}).(*zap.Logger)
+	if !ok {
+		return ctx
+	}
+	return WithContext(ctx, l.With(fields...))
+}
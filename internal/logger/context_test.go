@@ -0,0 +1,38 @@
+package logger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFromContext(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	fallback := zap.New(core)
+
+	t.Run("fallback without logger", func(t *testing.T) {
+		assert.Same(t, fallback, logger.FromContext(context.Background(), fallback))
+		assert.NotNil(t, logger.FromContext(context.Background(), nil))
+	})
+
+	t.Run("request-scoped fields", func(t *testing.T) {
+		ctx := logger.WithContext(context.Background(), fallback.With(zap.String("request_id", "req-1")))
+		ctx = logger.WithFields(ctx, zap.String("user_id", "user-1"))
+
+		logger.FromContext(ctx, nil).Info("message")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		assert.Equal(t, map[string]any{"request_id": "req-1", "user_id": "user-1"}, entries[0].ContextMap())
+	})
+
+	t.Run("fields ignored without logger", func(t *testing.T) {
+		ctx := context.Background()
+		assert.Equal(t, ctx, logger.WithFields(ctx, zap.String("user_id", "user-1")))
+	})
+}
@@ -2,29 +2,125 @@
 package logger
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/mp1947/ya-url-shortener/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+// Supported values of the LOG_FORMAT setting.
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// samplingTick is the interval over which entries with the same level and message are sampled.
+const samplingTick = time.Second
+
+// ErrUnknownFormat is returned by New when the configured log format is not supported.
+var ErrUnknownFormat = errors.New("unknown log format")
+
+// defaultConfig mirrors the defaults of the logging configuration and is used when none is given.
+var defaultConfig = config.Logging{
+	Level:              "info",
+	Format:             FormatJSON,
+	OutputPaths:        "stdout",
+	MaxSizeMB:          100,
+	MaxBackups:         5,
+	MaxAgeDays:         30,
+	SamplingInitial:    100,
+	SamplingThereafter: 100,
+	RedactPII:          true,
+}
+
 // InitLogger initializes and returns a new zap.Logger instance configured for production use.
 // The logger uses RFC3339 time format and disables caller information in log entries.
 // It logs a message upon successful initialization.
 // Returns the configured *zap.Logger and an error if initialization fails.
 func InitLogger() (*zap.Logger, error) {
+	l, _, err := New(nil)
+	return l, err
+}
 
-	config := zap.NewProductionConfig()
-	config.EncoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout(time.RFC3339)
-	config.DisableCaller = true
-	config.EncoderConfig.TimeKey = "time"
+// New builds the logger described by cfg, or by the default logging configuration when cfg is nil,
+// and returns it along with its level, which can be changed at runtime. Entries are written with RFC3339
+// times and without caller information to every configured output, files being rotated by size. When
+// enabled, PII is redacted from the entries as described by RedactPII, before they are sampled.
+func New(cfg *config.Logging) (*zap.Logger, zap.AtomicLevel, error) {
+	if cfg == nil {
+		cfg = &defaultConfig
+	}
 
-	logger, err := config.Build()
+	level, err := zap.ParseAtomicLevel(cfg.Level)
 	if err != nil {
-		return nil, err
+		return nil, level, err
+	}
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = zapcore.TimeEncoderOfLayout(time.RFC3339)
+	encoderConfig.TimeKey = "time"
+
+	var encoder zapcore.Encoder
+	switch cfg.Format {
+	case FormatJSON, "":
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	case FormatConsole:
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return nil, level, fmt.Errorf("%w: %s", ErrUnknownFormat, cfg.Format)
+	}
+
+	core := zapcore.NewCore(encoder, openOutputs(cfg), level)
+
+	if cfg.RedactPII {
+		core = Redact(core)
+	}
+
+	if cfg.SamplingThereafter > 0 {
+		core = zapcore.NewSamplerWithOptions(core, samplingTick, cfg.SamplingInitial, cfg.SamplingThereafter)
+	}
+
+	logger := zap.New(core, zap.AddStacktrace(zapcore.ErrorLevel))
+
+	logger.Info("logger has been successfully initialized", zap.String("level", level.String()))
+
+	return logger, level, nil
+}
+
+// openOutputs returns the write syncer writing to every output path of cfg. "stdout" and "stderr"
+// are the standard streams, any other path is a file rotated according to cfg.
+func openOutputs(cfg *config.Logging) zapcore.WriteSyncer {
+	var outputs []zapcore.WriteSyncer
+
+	for _, path := range strings.Split(cfg.OutputPaths, ",") {
+		switch path = strings.TrimSpace(path); path {
+		case "":
+			continue
+		case "stdout":
+			outputs = append(outputs, zapcore.Lock(os.Stdout))
+		case "stderr":
+			outputs = append(outputs, zapcore.Lock(os.Stderr))
+		default:
+			outputs = append(outputs, zapcore.AddSync(&lumberjack.Logger{
+				Filename:   path,
+				MaxSize:    cfg.MaxSizeMB,
+				MaxBackups: cfg.MaxBackups,
+				MaxAge:     cfg.MaxAgeDays,
+				Compress:   cfg.Compress,
+			}))
+		}
 	}
 
-	logger.Info("logger has been successfully initialized")
+	if len(outputs) == 0 {
+		return zapcore.Lock(os.Stdout)
+	}
 
-	return logger, nil
+	return zapcore.NewMultiWriteSyncer(outputs...)
 }
@@ -1,11 +1,17 @@
 package logger_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/mp1947/ya-url-shortener/config"
 	"github.com/mp1947/ya-url-shortener/internal/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func TestInitLogger(t *testing.T) {
@@ -15,3 +21,48 @@ func TestInitLogger(t *testing.T) {
 		assert.IsType(t, &zap.Logger{}, l)
 	})
 }
+
+func TestNew(t *testing.T) {
+	t.Run("unknown format", func(t *testing.T) {
+		_, _, err := logger.New(&config.Logging{Level: "info", Format: "xml"})
+		assert.ErrorIs(t, err, logger.ErrUnknownFormat)
+	})
+
+	t.Run("unknown level", func(t *testing.T) {
+		_, _, err := logger.New(&config.Logging{Level: "verbose"})
+		assert.Error(t, err)
+	})
+
+	t.Run("file output with runtime level and redaction", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "shortener.log")
+
+		l, level, err := logger.New(&config.Logging{
+			Level:       "warn",
+			Format:      logger.FormatConsole,
+			OutputPaths: path,
+			MaxSizeMB:   1,
+			RedactPII:   true,
+		})
+		require.NoError(t, err)
+
+		l.Info("dropped message")
+		level.SetLevel(zapcore.DebugLevel)
+		l.Debug("kept message",
+			zap.String("original_url", "https://example.com/path?token=secret"),
+			zap.String("user_id", "6f1c1d2e-0000-4000-8000-000000000000"),
+		)
+		require.NoError(t, l.Sync())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		content := string(data)
+
+		assert.NotContains(t, content, "dropped message")
+		assert.Contains(t, content, "DEBUG")
+		assert.Contains(t, content, "kept message")
+		assert.Contains(t, content, "https://example.com/path?REDACTED")
+		assert.NotContains(t, content, "secret")
+		assert.NotContains(t, content, "6f1c1d2e")
+		assert.True(t, strings.Contains(content, logger.HashUserID("6f1c1d2e-0000-4000-8000-000000000000")))
+	})
+}
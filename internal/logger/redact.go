@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// redactedQuery replaces the query string and fragment of redacted URLs.
+const redactedQuery = "?REDACTED"
+
+// urlFields are the keys of the fields holding URLs whose query strings are redacted.
+var urlFields = map[string]bool{
+	"original_url": true,
+	"request_uri":  true,
+	"redirect_url": true,
+	"url":          true,
+}
+
+// userIDFields are the keys of the fields holding user IDs which are replaced with their hash.
+var userIDFields = map[string]bool{
+	"user_id":        true,
+	"target_user_id": true,
+	"from_user_id":   true,
+	"to_user_id":     true,
+}
+
+// redactingCore is a zapcore.Core redacting the PII of string fields before they reach the wrapped core.
+type redactingCore struct {
+	zapcore.Core
+}
+
+// Redact wraps core so that the query strings and fragments of URL fields are dropped and user ID
+// fields are replaced with a stable hash, which keeps the log lines of one user correlated without
+// exposing the ID. Only string fields with the well-known keys of urlFields and userIDFields are redacted.
+func Redact(core zapcore.Core) zapcore.Core {
+	return &redactingCore{Core: core}
+}
+
+// With redacts the fields before adding them to the wrapped core.
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(redactFields(fields))}
+}
+
+// Check adds the redacting core to the checked entry when the entry is enabled.
+func (c *redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write redacts the fields before writing the entry to the wrapped core.
+func (c *redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, redactFields(fields))
+}
+
+// redactFields returns fields with the PII redacted, fields is left untouched.
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+
+	for i, f := range fields {
+		if f.Type != zapcore.StringType {
+			continue
+		}
+
+		var value string
+		switch {
+		case urlFields[f.Key]:
+			value = RedactURL(f.String)
+		case userIDFields[f.Key]:
+			value = HashUserID(f.String)
+		default:
+			continue
+		}
+
+		if redacted == nil {
+			redacted = make([]zapcore.Field, len(fields))
+			copy(redacted, fields)
+		}
+		redacted[i].String = value
+	}
+
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+// RedactURL returns rawURL without its query string and fragment, which may carry tokens or
+// personal data. A marker replaces them so that redacted URLs can be told apart.
+func RedactURL(rawURL string) string {
+	i := strings.IndexAny(rawURL, "?#")
+	if i < 0 {
+		return rawURL
+	}
+	return rawURL[:i] + redactedQuery
+}
+
+// HashUserID returns a short stable hash of userID, or an empty string for an empty ID.
+func HashUserID(userID string) string {
+	if userID == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(userID))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
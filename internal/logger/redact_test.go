@@ -0,0 +1,40 @@
+package logger_test
+
+import (
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedact(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	l := zap.New(logger.Redact(core)).With(zap.String("user_id", "user-1"))
+
+	l.Info("message",
+		zap.String("request_uri", "/abc?utm_source=mail#top"),
+		zap.String("original_url", "https://example.com/plain"),
+		zap.String("to_user_id", "user-2"),
+		zap.String("short_url_id", "abc"),
+	)
+
+	entries := logs.TakeAll()
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, map[string]any{
+		"user_id":      logger.HashUserID("user-1"),
+		"request_uri":  "/abc?REDACTED",
+		"original_url": "https://example.com/plain",
+		"to_user_id":   logger.HashUserID("user-2"),
+		"short_url_id": "abc",
+	}, entries[0].ContextMap())
+}
+
+func TestHashUserID(t *testing.T) {
+	assert.Empty(t, logger.HashUserID(""))
+	assert.Equal(t, logger.HashUserID("user-1"), logger.HashUserID("user-1"))
+	assert.NotEqual(t, logger.HashUserID("user-1"), logger.HashUserID("user-2"))
+}
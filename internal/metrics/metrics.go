@@ -0,0 +1,211 @@
+// Package metrics defines the Prometheus metrics of the URL shortener and the registry serving them.
+// Metrics are package-level collectors registered on Registry, instrumented code updates them directly.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "shortener"
+
+// Results of a redirect lookup, used as the "result" label of RedirectsTotal.
+const (
+	RedirectHit      = "hit"
+	RedirectMiss     = "miss"
+	RedirectGone     = "gone"
+	RedirectDisabled = "disabled"
+	RedirectError    = "error"
+)
+
+// Results of a lookup of the cache of short URLs, used as the "result" label of CacheLookupsTotal.
+const (
+	CacheHit         = "hit"
+	CacheNegativeHit = "negative_hit"
+	CacheMiss        = "miss"
+	CacheError       = "error"
+)
+
+// Targets of a database read, used as the "target" label of DatabaseReadsTotal.
+const (
+	ReadPrimary  = "primary"
+	ReadReplica  = "replica"
+	ReadFallback = "fallback"
+)
+
+// Results of a configuration reload, used as the "result" label of ConfigReloadsTotal.
+const (
+	ReloadSuccess = "success"
+	ReloadFailure = "failure"
+)
+
+// Registry holds every metric of the application along with the Go runtime and process collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestsTotal counts HTTP requests by method, route template and status code.
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes the latency of HTTP requests by method, route template and status code.
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// GRPCRequestsTotal counts gRPC calls by full method name and status code.
+	GRPCRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	// GRPCRequestDuration observes the latency of gRPC calls by full method name and status code.
+	GRPCRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of gRPC calls by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	// RedirectsTotal counts short URL lookups of the redirect endpoint by result.
+	RedirectsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redirects_total",
+		Help:      "Number of short URL redirects by result: hit, miss, gone, disabled or error.",
+	}, []string{"result"})
+
+	// ShortenConflictsTotal counts shorten requests for an original URL which is already shortened.
+	ShortenConflictsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "shorten_conflicts_total",
+		Help:      "Number of shorten requests for an already shortened original URL.",
+	})
+
+	// DeletionQueueDepth is the number of deletion batches waiting for the deletion worker.
+	DeletionQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "deletion",
+		Name:      "queue_depth",
+		Help:      "Number of deletion batches waiting for the deletion worker.",
+	})
+
+	// DeletionDuration observes the time the deletion worker takes to process a batch.
+	DeletionDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "deletion",
+		Name:      "duration_seconds",
+		Help:      "Time taken by the deletion worker to process a batch.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	// EventLogWriteDuration observes the latency of writes to the event log of the in-memory storage.
+	EventLogWriteDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "eventlog",
+		Name:      "write_duration_seconds",
+		Help:      "Latency of event log writes.",
+		Buckets:   []float64{.00005, .0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1},
+	})
+
+	// ConfigReloadsTotal counts configuration reloads triggered by SIGHUP by result.
+	ConfigReloadsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "config",
+		Name:      "reloads_total",
+		Help:      "Number of configuration reloads by result: success or failure.",
+	}, []string{"result"})
+
+	// ConfigLastReloadSuccess is the time of the last successful configuration reload.
+	ConfigLastReloadSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "config",
+		Name:      "last_reload_success_timestamp_seconds",
+		Help:      "Unix time of the last successful configuration reload.",
+	})
+
+	// ConfigRestartRequired is the number of changed settings which are applied only after a restart.
+	ConfigRestartRequired = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "config",
+		Name:      "restart_required_settings",
+		Help:      "Number of settings changed by the reloaded configuration which require a restart.",
+	})
+
+	// CacheLookupsTotal counts lookups of the cache of short URLs by result, failed operations
+	// of the store of the cache being counted as errors.
+	CacheLookupsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "lookups_total",
+		Help:      "Number of short URL cache lookups by result: hit, negative_hit, miss or error.",
+	}, []string{"result"})
+
+	// RateLimitedTotal counts the requests rejected by the rate limit by transport, http or grpc.
+	RateLimitedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Number of requests rejected by the rate limit by transport: http or grpc.",
+	}, []string{"transport"})
+
+	// DatabaseReadsTotal counts the reads of the database by target: the primary, a replica, or the
+	// primary after the read failed on a replica.
+	DatabaseReadsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "database",
+		Name:      "reads_total",
+		Help:      "Number of database reads by target: primary, replica or fallback.",
+	}, []string{"target"})
+
+	buildInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "build_info",
+		Help:      "Build information of the running binary, the value is always 1.",
+	}, []string{"version", "commit", "date"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		GRPCRequestsTotal,
+		GRPCRequestDuration,
+		RedirectsTotal,
+		ShortenConflictsTotal,
+		DeletionQueueDepth,
+		DeletionDuration,
+		EventLogWriteDuration,
+		ConfigReloadsTotal,
+		ConfigLastReloadSuccess,
+		ConfigRestartRequired,
+		CacheLookupsTotal,
+		RateLimitedTotal,
+		DatabaseReadsTotal,
+		buildInfo,
+	)
+}
+
+// SetBuildInfo publishes the build version, commit and date as the labels of the build info metric.
+func SetBuildInfo(version, commit, date string) {
+	buildInfo.Reset()
+	buildInfo.WithLabelValues(version, commit, date).Set(1)
+}
+
+// Handler returns an http.Handler serving the metrics of Registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/apikey"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"go.uber.org/zap"
+)
+
+// AdminOnly is a middleware that restricts access to callers holding the admin role.
+// The role is granted either by an API key sent in the "X-API-Key" header or by a token with the
+// admin role sent as "Authorization: Bearer <token>". The "token" cookie processed by AuthMiddleware
+// never grants it: browsers send cookies along with cross-site requests, which would let any page
+// visited by an admin act on their behalf. The authorized caller is stored as an auth.Actor in the
+// request context.
+// An unknown API key is rejected with HTTP 401 Unauthorized, any other caller without the
+// admin role is rejected with HTTP 403 Forbidden.
+func AdminOnly(l *zap.Logger, keys apikey.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		if rawKey := c.GetHeader("X-API-Key"); rawKey != "" {
+			key, err := keys.Lookup(ctx, rawKey)
+			if err != nil || key.Role != auth.RoleAdmin {
+				logger.FromContext(c.Request.Context(), l).Info("rejected admin request with unknown api key")
+				problem.Write(c, shrterr.ErrInvalidAPIKey)
+				return
+			}
+
+			c.Request = c.Request.WithContext(auth.WithActor(ctx, auth.Actor{
+				ID:       "apikey:" + key.ID,
+				Method:   auth.MethodAPIKey,
+				Role:     key.Role,
+				ClientIP: clientip.StringFromContext(c.Request.Context()),
+			}))
+			c.Next()
+			return
+		}
+
+		if bearer, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			claims, err := auth.Parse(bearer)
+			if err == nil && claims.IsAdmin() {
+				c.Request = c.Request.WithContext(auth.WithActor(ctx, auth.Actor{
+					ID:       claims.UserID.String(),
+					Method:   claims.Method(),
+					Role:     claims.Role,
+					ClientIP: clientip.StringFromContext(c.Request.Context()),
+				}))
+				c.Next()
+				return
+			}
+		}
+
+		logger.FromContext(c.Request.Context(), l).Info("rejected admin request without admin role")
+
+		problem.Write(c, shrterr.ErrAdminRoleRequired)
+	}
+}
@@ -7,34 +7,56 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
 	"go.uber.org/zap"
 )
 
 // AuthMiddleware is a Gin middleware that handles user authentication via a "token" cookie.
-// If the token is valid, it extracts the user ID and sets it in the request context.
+// If the token is valid, it extracts the user ID, the authentication method and the role and sets them
+// in the Gin context along with the token itself. The caller is also stored as an auth.Actor in the request context.
 // If the token is missing or invalid, it generates a new user ID, creates a new token,
-// sets it as a cookie, and stores the new user ID in the context.
-// The middleware logs relevant events using the provided zap.Logger.
+// sets it as a cookie, and stores the new user ID in the context as an anonymous user.
+// The user ID is added to the request-scoped logger set by RequestIDMiddleware.
+// The middleware logs relevant events using the request-scoped logger, or the provided zap.Logger without one.
 func AuthMiddleware(log *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		cookie, _ := c.Cookie("token")
 
-		ok, userID := auth.Validate(cookie)
+		claims, err := auth.Parse(cookie)
 
-		if !ok {
+		if err != nil {
 			generatedUserID := uuid.New()
 			token, err := auth.CreateToken(generatedUserID)
 			if err != nil {
-				log.Warn("error creating new cookie", zap.Error(err))
+				logger.FromContext(c.Request.Context(), log).Warn("error creating new cookie", zap.Error(err))
 			}
 			c.SetCookie("token", token, int(time.Second)*3600, "/", "localhost", false, false)
 			c.Set("user_id", generatedUserID.String())
+			c.Set("token", token)
+			c.Set("auth_method", auth.MethodAnonymous)
+			ctx := logger.WithFields(c.Request.Context(), zap.String("user_id", generatedUserID.String()))
+			c.Request = c.Request.WithContext(auth.WithActor(ctx, auth.Actor{
+				ID:       generatedUserID.String(),
+				Method:   auth.MethodAnonymous,
+				ClientIP: clientip.StringFromContext(ctx),
+			}))
 			c.Next()
 			return
 		}
-		userIDStr := userID.String()
-		log.Info("processing request from user", zap.String("user_id", userIDStr))
+		userIDStr := claims.UserID.String()
+		ctx := logger.WithFields(c.Request.Context(), zap.String("user_id", userIDStr))
+		logger.FromContext(ctx, log.With(zap.String("user_id", userIDStr))).Debug("processing request from user")
 		c.Set("user_id", userIDStr)
+		c.Set("token", cookie)
+		c.Set("auth_method", claims.Method())
+		c.Set("role", claims.Role)
+		c.Request = c.Request.WithContext(auth.WithActor(ctx, auth.Actor{
+			ID:       userIDStr,
+			Method:   claims.Method(),
+			Role:     claims.Role,
+			ClientIP: clientip.StringFromContext(ctx),
+		}))
 		c.Next()
 	}
 }
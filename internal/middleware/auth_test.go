@@ -8,12 +8,18 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
 	"github.com/mp1947/ya-url-shortener/internal/middleware"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+func TestMain(m *testing.M) {
+	authtest.Init()
+	m.Run()
+}
+
 func TestAuthMiddleware(t *testing.T) {
 	userID := uuid.New()
 	validToken, _ := auth.CreateToken(userID)
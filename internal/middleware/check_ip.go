@@ -1,23 +1,25 @@
 package middleware
 
 import (
-	"net"
-	"net/http"
-
 	"github.com/gin-gonic/gin"
-	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 	"go.uber.org/zap"
 )
 
 // WithAuthorizedIP is a middleware that restricts access to requests coming from authorized IP addresses.
-// It checks the "X-Real-IP" header of the incoming request and verifies if the IP is within the trusted subnet
-// specified in the configuration. If the IP is authorized, the request proceeds to the next handler.
-// Otherwise, the middleware logs the unauthorized access attempt and responds with HTTP 401 Unauthorized.
+// It takes the client IP resolved by ClientIPMiddleware and verifies if the IP is within one of the trusted
+// subnets, which are read on every request so that replacing them applies to the next requests. Forwarding
+// headers such as "X-Real-IP" only affect the resolved IP when the request comes from a trusted proxy.
+// If the IP is authorized, the request proceeds to the next handler. Otherwise, the middleware logs the
+// unauthorized access attempt and responds with HTTP 401 Unauthorized.
 //
 // Parameters:
 //
-//	l      - zap.Logger for logging unauthorized access attempts.
-//	cfg    - config.Config containing the trusted subnet information.
+//	l       - zap.Logger for logging unauthorized access attempts.
+//	subnets - clientip.Networks holding the trusted subnets.
 //	handler - gin.HandlerFunc to be executed if the IP is authorized.
 //
 // Returns:
@@ -25,23 +27,19 @@ import (
 //	gin.HandlerFunc - a middleware function for Gin that enforces IP-based authorization.
 func WithAuthorizedIP(
 	l *zap.Logger,
-	cfg config.Config,
+	subnets *clientip.Networks,
 	handler gin.HandlerFunc,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		headerData := c.GetHeader("X-Real-IP")
-
-		ipAddr := net.ParseIP(headerData)
+		ipAddr := clientip.FromContext(c.Request.Context())
 
-		if ipAddr != nil && cfg.TrustedSubnet.Contains(ipAddr) {
+		if subnets.Contains(ipAddr) {
 			handler(c)
 			return
 		}
 
-		l.Info("received unauthorized request from ip", zap.String("ip", headerData))
+		logger.FromContext(c.Request.Context(), l).Info("received unauthorized request from ip", zap.String("ip", clientip.StringFromContext(c.Request.Context())))
 
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-			"message": "not authorized to access",
-		})
+		problem.Write(c, shrterr.Unauthorized("not authorized to access"))
 	}
 }
@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	"github.com/mp1947/ya-url-shortener/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestWithAuthorizedIP(t *testing.T) {
+	_, trustedV4, _ := net.ParseCIDR("192.168.1.0/24")
+	_, trustedV6, _ := net.ParseCIDR("2001:db8::/32")
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+
+	subnets := clientip.NewNetworks([]*net.IPNet{trustedV4, trustedV6})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ClientIPMiddleware(clientip.NewResolver([]*net.IPNet{proxies})))
+	r.GET("/stats", middleware.WithAuthorizedIP(zap.NewNop(), subnets, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		realIP     string
+		want       int
+	}{
+		{
+			name:       "direct client in trusted subnet",
+			remoteAddr: "192.168.1.10:40000",
+			want:       http.StatusOK,
+		},
+		{
+			name:       "direct ipv6 client in trusted subnet",
+			remoteAddr: "[2001:db8::10]:40000",
+			want:       http.StatusOK,
+		},
+		{
+			name:       "spoofed x-real-ip from untrusted peer",
+			remoteAddr: "203.0.113.5:40000",
+			realIP:     "192.168.1.10",
+			want:       http.StatusUnauthorized,
+		},
+		{
+			name:       "x-real-ip from trusted proxy",
+			remoteAddr: "10.0.0.1:40000",
+			realIP:     "192.168.1.10",
+			want:       http.StatusOK,
+		},
+		{
+			name:       "untrusted client behind trusted proxy",
+			remoteAddr: "10.0.0.1:40000",
+			realIP:     "203.0.113.5",
+			want:       http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
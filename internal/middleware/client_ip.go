@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+)
+
+// ClientIPMiddleware resolves the IP address of the client using the given resolver and stores it
+// in the request context, where it can be read with clientip.FromContext. Forwarding headers are
+// honoured only for requests received from trusted proxies. It should be registered before any
+// middleware or handler relying on the client IP.
+func ClientIPMiddleware(resolver *clientip.Resolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := resolver.Resolve(c.Request.RemoteAddr, c.Request.Header)
+		c.Request = c.Request.WithContext(clientip.WithIP(c.Request.Context(), ip))
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"github.com/mp1947/ya-url-shortener/internal/ratelimit"
+	"go.uber.org/zap"
+)
+
+// RateLimitMiddleware limits the rate of the requests of every client IP resolved by ClientIPMiddleware
+// with limiter. Requests over the limit are answered with HTTP 429 Too Many Requests and a Retry-After
+// header, requests to the routes in exempt, such as the health checks, are never limited. When the
+// store of the limiter fails, the error is logged and the request proceeds.
+func RateLimitMiddleware(l *zap.Logger, limiter *ratelimit.Limiter, exempt ...string) gin.HandlerFunc {
+	exempted := make(map[string]bool, len(exempt))
+	for _, route := range exempt {
+		exempted[route] = true
+	}
+
+	return func(c *gin.Context) {
+		if exempted[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		allowed, wait, err := limiter.Allow(ctx, clientip.StringFromContext(ctx))
+		if err != nil {
+			logger.FromContext(ctx, l).Warn("error checking rate limit", zap.Error(err))
+		}
+
+		if !allowed {
+			metrics.RateLimitedTotal.WithLabelValues("http").Inc()
+			problem.Write(c, shrterr.RateLimited(wait))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,50 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	"github.com/mp1947/ya-url-shortener/internal/middleware"
+	"github.com/mp1947/ya-url-shortener/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := ratelimit.New(ratelimit.NewLocal(), ratelimit.Limit{Rate: 0.001, Burst: 2})
+
+	r := gin.New()
+	r.Use(middleware.ClientIPMiddleware(clientip.NewResolver(nil)))
+	r.Use(middleware.RateLimitMiddleware(zap.NewNop(), limiter, "/healthz"))
+	r.GET("/api", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("limits a client over its burst", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, get("/api", "192.0.2.1:1234").Code)
+		assert.Equal(t, http.StatusOK, get("/api", "192.0.2.1:1234").Code)
+
+		w := get("/api", "192.0.2.1:1234")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("limits clients separately", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, get("/api", "192.0.2.2:1234").Code)
+	})
+
+	t.Run("exempts routes", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, get("/healthz", "192.0.2.1:1234").Code)
+	})
+}
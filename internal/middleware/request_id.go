@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
+	"go.uber.org/zap"
+)
+
+// RequestIDMiddleware assigns a request ID to every request, keeping the one of the X-Request-ID
+// header when it is valid, and echoes it in the X-Request-ID response header. The ID is stored in the
+// request context along with a request-scoped logger derived from l carrying it, which can be read
+// with logger.FromContext. It should be registered before any middleware or handler that logs.
+func RequestIDMiddleware(l *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := requestid.Resolve(c.GetHeader(requestid.Header))
+		c.Header(requestid.Header, id)
+
+		ctx := requestid.WithID(c.Request.Context(), id)
+		ctx = logger.WithContext(ctx, l.With(zap.String("request_id", id)))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
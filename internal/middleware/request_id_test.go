@@ -0,0 +1,98 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/middleware"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.RequestIDMiddleware(zap.New(core)))
+	r.Use(middleware.AuthMiddleware(zap.NewNop()))
+	r.GET("/log", func(c *gin.Context) {
+		logger.FromContext(c.Request.Context(), nil).Info("handled")
+		c.String(http.StatusOK, requestid.FromContext(c.Request.Context()))
+	})
+	r.GET("/stats", middleware.WithAuthorizedIP(zap.NewNop(), clientip.NewNetworks(nil), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	}))
+
+	t.Run("generates request id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/log", nil))
+
+		id := w.Header().Get(requestid.Header)
+		assert.True(t, requestid.Valid(id))
+		assert.Equal(t, id, w.Body.String())
+	})
+
+	t.Run("keeps supplied request id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/log", nil)
+		req.Header.Set(requestid.Header, "req-123")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, "req-123", w.Header().Get(requestid.Header))
+		assert.Equal(t, "req-123", w.Body.String())
+	})
+
+	t.Run("replaces invalid request id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/log", nil)
+		req.Header.Set(requestid.Header, "req 123")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.NotEqual(t, "req 123", w.Header().Get(requestid.Header))
+	})
+
+	t.Run("request-scoped logger", func(t *testing.T) {
+		logs.TakeAll()
+
+		req := httptest.NewRequest(http.MethodGet, "/log", nil)
+		req.Header.Set(requestid.Header, "req-log")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		entries := logs.FilterMessage("handled").TakeAll()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "req-log", fields["request_id"])
+		assert.NotEmpty(t, fields["user_id"])
+	})
+
+	t.Run("request id in error body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+		req.Header.Set(requestid.Header, "req-error")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+		var body problem.Details
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "req-error", body.RequestID)
+		assert.Equal(t, http.StatusUnauthorized, body.Status)
+		assert.Equal(t, "/stats", body.Instance)
+	})
+}
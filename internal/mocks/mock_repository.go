@@ -59,6 +59,21 @@ func (mr *MockRepositoryMockRecorder) DeleteBatch(ctx, shortURLs any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatch", reflect.TypeOf((*MockRepository)(nil).DeleteBatch), ctx, shortURLs)
 }
 
+// ForceDeleteBatch mocks base method.
+func (m *MockRepository) ForceDeleteBatch(ctx context.Context, shortURLs []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceDeleteBatch", ctx, shortURLs)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceDeleteBatch indicates an expected call of ForceDeleteBatch.
+func (mr *MockRepositoryMockRecorder) ForceDeleteBatch(ctx, shortURLs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceDeleteBatch", reflect.TypeOf((*MockRepository)(nil).ForceDeleteBatch), ctx, shortURLs)
+}
+
 // Get mocks base method.
 func (m *MockRepository) Get(ctx context.Context, shortURL string) (model.URL, error) {
 	m.ctrl.T.Helper()
@@ -132,6 +147,49 @@ func (mr *MockRepositoryMockRecorder) Init(ctx, cfg, l any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockRepository)(nil).Init), ctx, cfg, l)
 }
 
+// ListURLs mocks base method.
+func (m *MockRepository) ListURLs(ctx context.Context, fn func(model.URL) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListURLs", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListURLs indicates an expected call of ListURLs.
+func (mr *MockRepositoryMockRecorder) ListURLs(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListURLs", reflect.TypeOf((*MockRepository)(nil).ListURLs), ctx, fn)
+}
+
+// ListUserURLs mocks base method.
+func (m *MockRepository) ListUserURLs(ctx context.Context, userID string, fn func(model.UserURL) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserURLs", ctx, userID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListUserURLs indicates an expected call of ListUserURLs.
+func (mr *MockRepositoryMockRecorder) ListUserURLs(ctx, userID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserURLs", reflect.TypeOf((*MockRepository)(nil).ListUserURLs), ctx, userID, fn)
+}
+
+// ReassignURLs mocks base method.
+func (m *MockRepository) ReassignURLs(ctx context.Context, fromUserID, toUserID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignURLs", ctx, fromUserID, toUserID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignURLs indicates an expected call of ReassignURLs.
+func (mr *MockRepositoryMockRecorder) ReassignURLs(ctx, fromUserID, toUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignURLs", reflect.TypeOf((*MockRepository)(nil).ReassignURLs), ctx, fromUserID, toUserID)
+}
+
 // Save mocks base method.
 func (m *MockRepository) Save(ctx context.Context, shortURLID, originalURL, userID string) error {
 	m.ctrl.T.Helper()
@@ -160,3 +218,33 @@ func (mr *MockRepositoryMockRecorder) SaveBatch(ctx, urls, userID any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBatch", reflect.TypeOf((*MockRepository)(nil).SaveBatch), ctx, urls, userID)
 }
+
+// SetDisabled mocks base method.
+func (m *MockRepository) SetDisabled(ctx context.Context, shortURLs []string, disabled bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDisabled", ctx, shortURLs, disabled)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDisabled indicates an expected call of SetDisabled.
+func (mr *MockRepositoryMockRecorder) SetDisabled(ctx, shortURLs, disabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisabled", reflect.TypeOf((*MockRepository)(nil).SetDisabled), ctx, shortURLs, disabled)
+}
+
+// TransferURLs mocks base method.
+func (m *MockRepository) TransferURLs(ctx context.Context, shortURLs []string, toUserID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferURLs", ctx, shortURLs, toUserID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferURLs indicates an expected call of TransferURLs.
+func (mr *MockRepositoryMockRecorder) TransferURLs(ctx, shortURLs, toUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferURLs", reflect.TypeOf((*MockRepository)(nil).TransferURLs), ctx, shortURLs, toUserID)
+}
@@ -1,6 +1,8 @@
 // Package model defines data structures for representing shortened URLs, user associations, and batch operations in the URL shortener service.
 package model
 
+import "github.com/mp1947/ya-url-shortener/internal/auth"
+
 // URLWithCorrelation represents a URL mapping with an associated correlation ID.
 // It contains the shortened URL identifier, the original URL, and a correlation ID
 // used for tracking or associating requests.
@@ -10,24 +12,35 @@ type URLWithCorrelation struct {
 	CorrelationID string
 }
 
-// UserURL represents a mapping between a shortened URL identifier and its original URL.
+// UserURL represents a mapping between a shortened URL identifier and its original URL,
+// along with the deletion and disabled flags of the entry.
 type UserURL struct {
 	ShortURLID  string
 	OriginalURL string
+	IsDeleted   bool
+	IsDisabled  bool
 }
 
 // URL represents a shortened URL entry with its unique identifier, the original URL,
-// and a flag indicating whether the URL has been deleted.
+// the ID of the owning user, a flag indicating whether the URL has been deleted and
+// a flag indicating whether the URL has been disabled by an operator.
 type URL struct {
 	ShortURLID  string
 	OriginalURL string
+	UserID      string
 	IsDeleted   bool
+	IsDisabled  bool
 }
 
 // BatchDeleteShortURLs represents a request to delete a batch of shortened URLs
-// associated with a specific user. It contains a slice of short URL identifiers
-// and the user ID of the owner.
+// associated with a specific user. It contains a slice of short URL identifiers,
+// the user ID of the owner and the actor and ID of the request that asked for the
+// deletion, which are kept for the audit trail and the logs since the deletion is
+// processed asynchronously, and the ID of the deletion job reporting its status.
 type BatchDeleteShortURLs struct {
 	UserID    string
 	ShortURLs []string
+	Actor     auth.Actor
+	RequestID string
+	JobID     string
 }
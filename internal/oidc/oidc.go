@@ -0,0 +1,91 @@
+// Package oidc implements the OpenID Connect authorization code flow used to sign users in
+// with an external identity provider.
+package oidc
+
+import (
+	"context"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/mp1947/ya-url-shortener/config"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"golang.org/x/oauth2"
+)
+
+// Provider holds the discovered identity provider endpoints, the OAuth2 client settings
+// and the ID token verifier for a single OpenID Connect issuer.
+type Provider struct {
+	issuer   string
+	oauth    oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// Identity holds the identity provider data extracted from a verified ID token.
+type Identity struct {
+	Issuer  string
+	Subject string
+	Email   string
+}
+
+// NewProvider discovers the identity provider configured in cfg and prepares the OAuth2
+// client used for the authorization code flow.
+// Returns an error if the discovery document cannot be fetched or is invalid.
+func NewProvider(ctx context.Context, cfg config.OIDC) (*Provider, error) {
+	provider, err := gooidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		issuer: cfg.IssuerURL,
+		oauth: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       []string{gooidc.ScopeOpenID, "profile", "email"},
+		},
+		verifier: provider.Verifier(&gooidc.Config{ClientID: cfg.ClientID}),
+	}, nil
+}
+
+// AuthCodeURL returns the identity provider URL the user agent is redirected to in order
+// to sign in. The state and nonce are echoed back and checked on the callback.
+func (p *Provider) AuthCodeURL(state, nonce string) string {
+	return p.oauth.AuthCodeURL(state, gooidc.Nonce(nonce))
+}
+
+// Exchange trades the authorization code for tokens, verifies the returned ID token
+// against the expected nonce and returns the identity it holds.
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (Identity, error) {
+	token, err := p.oauth.Exchange(ctx, code)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return Identity{}, shrterr.ErrOIDCMissingIDToken
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if idToken.Nonce != nonce {
+		return Identity{}, shrterr.ErrOIDCNonceMismatch
+	}
+
+	var claims struct {
+		Email string `json:"email"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return Identity{}, err
+	}
+
+	return Identity{
+		Issuer:  idToken.Issuer,
+		Subject: idToken.Subject,
+		Email:   claims.Email,
+	}, nil
+}
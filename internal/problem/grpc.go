@@ -0,0 +1,50 @@
+package problem
+
+import (
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Status returns the gRPC status reporting err, nil when err is nil. Errors which already are gRPC
+// statuses are returned unchanged. The other ones are given the code of their kind and a
+// google.rpc.ErrorInfo detail with the reason of their kind, along with a google.rpc.BadRequest detail
+// for invalid input and a google.rpc.RetryInfo detail for calls which may be retried later. The causes
+// of the errors, and the messages of the unexpected ones, are not disclosed.
+func Status(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	e := shrterr.Classify(err)
+	m := mappingOf(e.Kind)
+
+	st := status.New(m.code, e.Message)
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: m.reason, Domain: Domain},
+	}
+	if e.Kind == shrterr.KindInvalidInput && e.Field != "" {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: e.Field, Description: e.Message}},
+		})
+	}
+	if e.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)})
+	}
+
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st
+	}
+	return withDetails
+}
+
+// Error returns the error of the gRPC status reporting err, nil when err is nil.
+func Error(err error) error {
+	return Status(err).Err()
+}
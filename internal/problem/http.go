@@ -0,0 +1,82 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// Write aborts the request with the problem details reporting err, with the request path as instance
+// and the request ID. Rate limited and unavailable requests which may be retried later are given a
+// Retry-After header. Unexpected errors are attached to the context so that they are logged.
+func Write(c *gin.Context, err error) {
+	e := shrterr.Classify(err)
+	if e.Kind == shrterr.KindInternal {
+		_ = c.Error(err)
+	}
+	if e.RetryAfter > 0 {
+		c.Header("Retry-After", retryAfter(e.RetryAfter))
+	}
+
+	d := New(e)
+	d.Instance = c.Request.URL.Path
+	d.RequestID = requestid.FromContext(c.Request.Context())
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(d.Status, d)
+}
+
+// WriteStatus writes the problem details reporting the gRPC status st of a call forwarded from r,
+// such as by the JSON/HTTP gateway. The HTTP status is the one of the reason of the google.rpc.ErrorInfo
+// details of st, or httpStatus if it has none. The google.rpc.BadRequest, RetryInfo and RequestInfo
+// details are reported as invalid parameters, Retry-After header and request ID.
+func WriteStatus(w http.ResponseWriter, r *http.Request, st *status.Status, httpStatus int) {
+	d := Details{
+		Type:      blankType,
+		Status:    httpStatus,
+		Detail:    st.Message(),
+		Instance:  r.URL.Path,
+		RequestID: requestid.FromContext(r.Context()),
+	}
+
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if detail.GetDomain() != Domain {
+				continue
+			}
+			d.Reason = detail.GetReason()
+			for _, m := range mappings {
+				if m.reason == d.Reason {
+					d.Status = m.status
+				}
+			}
+		case *errdetails.BadRequest:
+			for _, v := range detail.GetFieldViolations() {
+				d.InvalidParams = append(d.InvalidParams, InvalidParam{Name: v.GetField(), Reason: v.GetDescription()})
+			}
+		case *errdetails.RetryInfo:
+			if delay := detail.GetRetryDelay(); delay != nil {
+				w.Header().Set("Retry-After", retryAfter(delay.AsDuration()))
+			}
+		case *errdetails.RequestInfo:
+			d.RequestID = detail.GetRequestId()
+		}
+	}
+	d.Title = http.StatusText(d.Status)
+
+	body, err := json.Marshal(d)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(d.Status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,100 @@
+// Package problem reports the domain errors of package shrterr to clients, as RFC 9457 problem
+// details (application/problem+json) over HTTP and as statuses with google.rpc error details over
+// gRPC. Every handler reports its errors through this package, so that an error of a given kind is
+// answered with the same HTTP status, gRPC code and reason whatever the endpoint.
+package problem
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// ContentType is the media type of the problem details documents.
+	ContentType = "application/problem+json"
+
+	// Domain is the domain of the google.rpc.ErrorInfo details of the gRPC statuses.
+	Domain = "ya-url-shortener"
+
+	// blankType is the problem type of problems with no semantics beyond their HTTP status.
+	blankType = "about:blank"
+)
+
+// mapping describes how the errors of a kind are reported.
+type mapping struct {
+	kind   shrterr.Kind
+	status int
+	code   codes.Code
+	reason string
+}
+
+// mappings lists the way every kind of error is reported.
+var mappings = []mapping{
+	{shrterr.KindInternal, http.StatusInternalServerError, codes.Internal, "INTERNAL"},
+	{shrterr.KindNotFound, http.StatusNotFound, codes.NotFound, "NOT_FOUND"},
+	{shrterr.KindGone, http.StatusGone, codes.NotFound, "GONE"},
+	{shrterr.KindConflict, http.StatusConflict, codes.AlreadyExists, "CONFLICT"},
+	{shrterr.KindInvalidInput, http.StatusBadRequest, codes.InvalidArgument, "INVALID_INPUT"},
+	{shrterr.KindUnsupportedMediaType, http.StatusUnsupportedMediaType, codes.InvalidArgument, "UNSUPPORTED_MEDIA_TYPE"},
+	{shrterr.KindUnauthorized, http.StatusUnauthorized, codes.Unauthenticated, "UNAUTHORIZED"},
+	{shrterr.KindForbidden, http.StatusForbidden, codes.PermissionDenied, "FORBIDDEN"},
+	{shrterr.KindRateLimited, http.StatusTooManyRequests, codes.ResourceExhausted, "RATE_LIMITED"},
+	{shrterr.KindUnavailable, http.StatusServiceUnavailable, codes.Unavailable, "UNAVAILABLE"},
+}
+
+// mappingOf returns the mapping of kind.
+func mappingOf(kind shrterr.Kind) mapping {
+	for _, m := range mappings {
+		if m.kind == kind {
+			return m
+		}
+	}
+	return mappings[0]
+}
+
+// Details is an RFC 9457 problem details document. Reason, RequestID and InvalidParams are extension members.
+type Details struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	Reason        string         `json:"reason,omitempty"`
+	RequestID     string         `json:"request_id,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+}
+
+// InvalidParam describes an invalid request field.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// New returns the problem details reporting err. The causes of the errors, and the messages of the
+// unexpected ones, are not disclosed.
+func New(err error) Details {
+	e := shrterr.Classify(err)
+	m := mappingOf(e.Kind)
+
+	d := Details{
+		Type:   blankType,
+		Title:  http.StatusText(m.status),
+		Status: m.status,
+		Detail: e.Message,
+		Reason: m.reason,
+	}
+	if e.Kind == shrterr.KindInvalidInput && e.Field != "" {
+		d.InvalidParams = []InvalidParam{{Name: e.Field, Reason: e.Message}}
+	}
+	return d
+}
+
+// retryAfter returns the value of the Retry-After header for wait, in whole seconds.
+func retryAfter(wait time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(wait.Seconds())))
+}
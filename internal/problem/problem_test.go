@@ -0,0 +1,171 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMapping(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{shrterr.ErrShortURLNotFound, http.StatusNotFound, codes.NotFound, "NOT_FOUND"},
+		{shrterr.ErrShortURLDeleted, http.StatusGone, codes.NotFound, "GONE"},
+		{shrterr.ErrOriginalURLAlreadyExists, http.StatusConflict, codes.AlreadyExists, "CONFLICT"},
+		{shrterr.InvalidInput("url", "url is empty"), http.StatusBadRequest, codes.InvalidArgument, "INVALID_INPUT"},
+		{shrterr.UnsupportedMediaType("text/csv only"), http.StatusUnsupportedMediaType, codes.InvalidArgument, "UNSUPPORTED_MEDIA_TYPE"},
+		{shrterr.ErrInvalidAPIKey, http.StatusUnauthorized, codes.Unauthenticated, "UNAUTHORIZED"},
+		{shrterr.ErrShortURLDisabled, http.StatusForbidden, codes.PermissionDenied, "FORBIDDEN"},
+		{shrterr.RateLimited(time.Second), http.StatusTooManyRequests, codes.ResourceExhausted, "RATE_LIMITED"},
+		{shrterr.ErrShuttingDown, http.StatusServiceUnavailable, codes.Unavailable, "UNAVAILABLE"},
+		{fmt.Errorf("listing: %w", shrterr.ErrDeletionJobNotFound), http.StatusNotFound, codes.NotFound, "NOT_FOUND"},
+		{errors.New("connection refused"), http.StatusInternalServerError, codes.Internal, "INTERNAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			d := problem.New(tt.err)
+			assert.Equal(t, tt.wantStatus, d.Status)
+			assert.Equal(t, http.StatusText(tt.wantStatus), d.Title)
+			assert.Equal(t, tt.wantReason, d.Reason)
+
+			st := problem.Status(tt.err)
+			assert.Equal(t, tt.wantCode, st.Code())
+			require.NotEmpty(t, st.Details())
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantReason, info.Reason)
+			assert.Equal(t, problem.Domain, info.Domain)
+		})
+	}
+}
+
+func TestUnexpectedErrorsAreNotDisclosed(t *testing.T) {
+	err := errors.New("dial tcp 10.0.0.1:5432: connection refused")
+
+	assert.Equal(t, "internal server error", problem.New(err).Detail)
+	assert.Equal(t, "internal server error", problem.Status(err).Message())
+
+	wrapped := shrterr.Unavailable("storage is unavailable", err)
+	assert.Equal(t, "storage is unavailable", problem.New(wrapped).Detail)
+	assert.Equal(t, "storage is unavailable", problem.Status(wrapped).Message())
+}
+
+func TestStatus(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.Nil(t, problem.Status(nil))
+		assert.NoError(t, problem.Error(nil))
+	})
+
+	t.Run("status errors are kept", func(t *testing.T) {
+		err := status.Error(codes.Unimplemented, "not implemented")
+		assert.Equal(t, err, problem.Error(err))
+	})
+
+	t.Run("invalid input and retry details", func(t *testing.T) {
+		st := problem.Status(shrterr.InvalidInput("user_id", "user id is not a valid uuid"))
+		require.Len(t, st.Details(), 2)
+		badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+		require.True(t, ok)
+		assert.Equal(t, "user_id", badRequest.FieldViolations[0].Field)
+
+		st = problem.Status(shrterr.RateLimited(1500 * time.Millisecond))
+		require.Len(t, st.Details(), 2)
+		retry, ok := st.Details()[1].(*errdetails.RetryInfo)
+		require.True(t, ok)
+		assert.Equal(t, 1500*time.Millisecond, retry.RetryDelay.AsDuration())
+	})
+}
+
+func TestWrite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/api/:id", func(c *gin.Context) {
+		switch c.Param("id") {
+		case "invalid":
+			problem.Write(c, shrterr.InvalidInput("id", "id is not valid"))
+		case "limited":
+			problem.Write(c, shrterr.RateLimited(1500*time.Millisecond))
+		default:
+			problem.Write(c, errors.New("boom"))
+		}
+	})
+
+	get := func(path string) (*httptest.ResponseRecorder, problem.Details) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req = req.WithContext(requestid.WithID(req.Context(), "req-problem"))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		var d problem.Details
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &d))
+		return w, d
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		w, d := get("/api/invalid")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, problem.Details{
+			Type:          "about:blank",
+			Title:         "Bad Request",
+			Status:        http.StatusBadRequest,
+			Detail:        "id is not valid",
+			Instance:      "/api/invalid",
+			Reason:        "INVALID_INPUT",
+			RequestID:     "req-problem",
+			InvalidParams: []problem.InvalidParam{{Name: "id", Reason: "id is not valid"}},
+		}, d)
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		w, d := get("/api/limited")
+		assert.Equal(t, http.StatusTooManyRequests, d.Status)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("unexpected error", func(t *testing.T) {
+		w, d := get("/api/boom")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "internal server error", d.Detail)
+	})
+}
+
+func TestWriteStatus(t *testing.T) {
+	st := problem.Status(shrterr.ErrShortURLDeleted)
+	st, err := st.WithDetails(&errdetails.RequestInfo{RequestId: "req-status"})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	problem.WriteStatus(w, httptest.NewRequest(http.MethodGet, "/v2/urls/abc", nil), st, http.StatusNotFound)
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+	var d problem.Details
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &d))
+	assert.Equal(t, "GONE", d.Reason)
+	assert.Equal(t, "Gone", d.Title)
+	assert.Equal(t, "short url has been deleted", d.Detail)
+	assert.Equal(t, "req-status", d.RequestID)
+	assert.Equal(t, "/v2/urls/abc", d.Instance)
+}
@@ -0,0 +1,8 @@
+package proto
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI document of the JSON/HTTP gateway generated from shortener.proto.
+//
+//go:embed shortener.swagger.json
+var OpenAPI []byte
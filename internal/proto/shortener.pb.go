@@ -7,8 +7,10 @@
 package proto
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -427,7 +429,8 @@ func (x *DeleteURLSReq) GetShortURLs() []string {
 
 type DeleteURLSResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,json=deleted_short_urls,proto3" json:"status,omitempty"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	JobID         string                 `protobuf:"bytes,2,opt,name=jobID,json=job_id,proto3" json:"jobID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -469,6 +472,813 @@ func (x *DeleteURLSResp) GetStatus() string {
 	return ""
 }
 
+func (x *DeleteURLSResp) GetJobID() string {
+	if x != nil {
+		return x.JobID
+	}
+	return ""
+}
+
+type StreamShortenReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CorrelationID string                 `protobuf:"bytes,1,opt,name=correlationID,json=correlation_id,proto3" json:"correlationID,omitempty"`
+	OriginalURL   string                 `protobuf:"bytes,2,opt,name=originalURL,json=original_url,proto3" json:"originalURL,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamShortenReq) Reset() {
+	*x = StreamShortenReq{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamShortenReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamShortenReq) ProtoMessage() {}
+
+func (x *StreamShortenReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamShortenReq.ProtoReflect.Descriptor instead.
+func (*StreamShortenReq) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StreamShortenReq) GetCorrelationID() string {
+	if x != nil {
+		return x.CorrelationID
+	}
+	return ""
+}
+
+func (x *StreamShortenReq) GetOriginalURL() string {
+	if x != nil {
+		return x.OriginalURL
+	}
+	return ""
+}
+
+type StreamShortenResp struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Results       []*StreamShortenResp_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	JwtToken      string                      `protobuf:"bytes,2,opt,name=jwtToken,json=jwt_token,proto3" json:"jwtToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamShortenResp) Reset() {
+	*x = StreamShortenResp{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamShortenResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamShortenResp) ProtoMessage() {}
+
+func (x *StreamShortenResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamShortenResp.ProtoReflect.Descriptor instead.
+func (*StreamShortenResp) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StreamShortenResp) GetResults() []*StreamShortenResp_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *StreamShortenResp) GetJwtToken() string {
+	if x != nil {
+		return x.JwtToken
+	}
+	return ""
+}
+
+type InternalStatsResp struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Urls          int64                         `protobuf:"varint,1,opt,name=urls,proto3" json:"urls,omitempty"`
+	Users         int64                         `protobuf:"varint,2,opt,name=users,proto3" json:"users,omitempty"`
+	Cache         *InternalStatsResp_CacheStats `protobuf:"bytes,3,opt,name=cache,proto3" json:"cache,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InternalStatsResp) Reset() {
+	*x = InternalStatsResp{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InternalStatsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InternalStatsResp) ProtoMessage() {}
+
+func (x *InternalStatsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InternalStatsResp.ProtoReflect.Descriptor instead.
+func (*InternalStatsResp) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *InternalStatsResp) GetUrls() int64 {
+	if x != nil {
+		return x.Urls
+	}
+	return 0
+}
+
+func (x *InternalStatsResp) GetUsers() int64 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+func (x *InternalStatsResp) GetCache() *InternalStatsResp_CacheStats {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+type AdminURL struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortURLID    string                 `protobuf:"bytes,1,opt,name=shortURLID,json=short_url_id,proto3" json:"shortURLID,omitempty"`
+	ShortURL      string                 `protobuf:"bytes,2,opt,name=shortURL,json=short_url,proto3" json:"shortURL,omitempty"`
+	OriginalURL   string                 `protobuf:"bytes,3,opt,name=originalURL,json=original_url,proto3" json:"originalURL,omitempty"`
+	UserID        string                 `protobuf:"bytes,4,opt,name=userID,json=user_id,proto3" json:"userID,omitempty"`
+	IsDeleted     bool                   `protobuf:"varint,5,opt,name=isDeleted,json=is_deleted,proto3" json:"isDeleted,omitempty"`
+	IsDisabled    bool                   `protobuf:"varint,6,opt,name=isDisabled,json=is_disabled,proto3" json:"isDisabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminURL) Reset() {
+	*x = AdminURL{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminURL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminURL) ProtoMessage() {}
+
+func (x *AdminURL) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminURL.ProtoReflect.Descriptor instead.
+func (*AdminURL) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AdminURL) GetShortURLID() string {
+	if x != nil {
+		return x.ShortURLID
+	}
+	return ""
+}
+
+func (x *AdminURL) GetShortURL() string {
+	if x != nil {
+		return x.ShortURL
+	}
+	return ""
+}
+
+func (x *AdminURL) GetOriginalURL() string {
+	if x != nil {
+		return x.OriginalURL
+	}
+	return ""
+}
+
+func (x *AdminURL) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *AdminURL) GetIsDeleted() bool {
+	if x != nil {
+		return x.IsDeleted
+	}
+	return false
+}
+
+func (x *AdminURL) GetIsDisabled() bool {
+	if x != nil {
+		return x.IsDisabled
+	}
+	return false
+}
+
+type AdminGetURLReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortURLID    string                 `protobuf:"bytes,1,opt,name=shortURLID,json=short_url_id,proto3" json:"shortURLID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminGetURLReq) Reset() {
+	*x = AdminGetURLReq{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminGetURLReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminGetURLReq) ProtoMessage() {}
+
+func (x *AdminGetURLReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminGetURLReq.ProtoReflect.Descriptor instead.
+func (*AdminGetURLReq) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AdminGetURLReq) GetShortURLID() string {
+	if x != nil {
+		return x.ShortURLID
+	}
+	return ""
+}
+
+type AdminGetUserURLsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,json=user_id,proto3" json:"userID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminGetUserURLsReq) Reset() {
+	*x = AdminGetUserURLsReq{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminGetUserURLsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminGetUserURLsReq) ProtoMessage() {}
+
+func (x *AdminGetUserURLsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminGetUserURLsReq.ProtoReflect.Descriptor instead.
+func (*AdminGetUserURLsReq) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AdminGetUserURLsReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type AdminGetUserURLsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          []*AdminURL            `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminGetUserURLsResp) Reset() {
+	*x = AdminGetUserURLsResp{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminGetUserURLsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminGetUserURLsResp) ProtoMessage() {}
+
+func (x *AdminGetUserURLsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminGetUserURLsResp.ProtoReflect.Descriptor instead.
+func (*AdminGetUserURLsResp) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AdminGetUserURLsResp) GetUrls() []*AdminURL {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+type AdminSetURLsDisabledReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortURLIDs   []string               `protobuf:"bytes,1,rep,name=shortURLIDs,json=short_url_ids,proto3" json:"shortURLIDs,omitempty"`
+	Disabled      bool                   `protobuf:"varint,2,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminSetURLsDisabledReq) Reset() {
+	*x = AdminSetURLsDisabledReq{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminSetURLsDisabledReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminSetURLsDisabledReq) ProtoMessage() {}
+
+func (x *AdminSetURLsDisabledReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminSetURLsDisabledReq.ProtoReflect.Descriptor instead.
+func (*AdminSetURLsDisabledReq) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AdminSetURLsDisabledReq) GetShortURLIDs() []string {
+	if x != nil {
+		return x.ShortURLIDs
+	}
+	return nil
+}
+
+func (x *AdminSetURLsDisabledReq) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+type AdminDeleteURLsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortURLIDs   []string               `protobuf:"bytes,1,rep,name=shortURLIDs,json=short_url_ids,proto3" json:"shortURLIDs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminDeleteURLsReq) Reset() {
+	*x = AdminDeleteURLsReq{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDeleteURLsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDeleteURLsReq) ProtoMessage() {}
+
+func (x *AdminDeleteURLsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminDeleteURLsReq.ProtoReflect.Descriptor instead.
+func (*AdminDeleteURLsReq) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AdminDeleteURLsReq) GetShortURLIDs() []string {
+	if x != nil {
+		return x.ShortURLIDs
+	}
+	return nil
+}
+
+type AdminReassignURLsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortURLIDs   []string               `protobuf:"bytes,1,rep,name=shortURLIDs,json=short_url_ids,proto3" json:"shortURLIDs,omitempty"`
+	UserID        string                 `protobuf:"bytes,2,opt,name=userID,json=user_id,proto3" json:"userID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminReassignURLsReq) Reset() {
+	*x = AdminReassignURLsReq{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminReassignURLsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminReassignURLsReq) ProtoMessage() {}
+
+func (x *AdminReassignURLsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminReassignURLsReq.ProtoReflect.Descriptor instead.
+func (*AdminReassignURLsReq) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AdminReassignURLsReq) GetShortURLIDs() []string {
+	if x != nil {
+		return x.ShortURLIDs
+	}
+	return nil
+}
+
+func (x *AdminReassignURLsReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type AdminActionResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Affected      int64                  `protobuf:"varint,1,opt,name=affected,proto3" json:"affected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminActionResp) Reset() {
+	*x = AdminActionResp{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminActionResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminActionResp) ProtoMessage() {}
+
+func (x *AdminActionResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminActionResp.ProtoReflect.Descriptor instead.
+func (*AdminActionResp) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AdminActionResp) GetAffected() int64 {
+	if x != nil {
+		return x.Affected
+	}
+	return 0
+}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	ActorID       string                 `protobuf:"bytes,2,opt,name=actorID,json=actor_id,proto3" json:"actorID,omitempty"`
+	AuthMethod    string                 `protobuf:"bytes,3,opt,name=authMethod,json=auth_method,proto3" json:"authMethod,omitempty"`
+	ClientIP      string                 `protobuf:"bytes,4,opt,name=clientIP,json=client_ip,proto3" json:"clientIP,omitempty"`
+	Operation     string                 `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
+	ShortURLs     []string               `protobuf:"bytes,6,rep,name=shortURLs,json=short_urls,proto3" json:"shortURLs,omitempty"`
+	TargetUserID  string                 `protobuf:"bytes,7,opt,name=targetUserID,json=target_user_id,proto3" json:"targetUserID,omitempty"`
+	Result        string                 `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AuditEntry) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetActorID() string {
+	if x != nil {
+		return x.ActorID
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetAuthMethod() string {
+	if x != nil {
+		return x.AuthMethod
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetClientIP() string {
+	if x != nil {
+		return x.ClientIP
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetShortURLs() []string {
+	if x != nil {
+		return x.ShortURLs
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetTargetUserID() string {
+	if x != nil {
+		return x.TargetUserID
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AdminQueryAuditReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,json=user_id,proto3" json:"userID,omitempty"`
+	ShortURLID    string                 `protobuf:"bytes,2,opt,name=shortURLID,json=short_url_id,proto3" json:"shortURLID,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminQueryAuditReq) Reset() {
+	*x = AdminQueryAuditReq{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminQueryAuditReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminQueryAuditReq) ProtoMessage() {}
+
+func (x *AdminQueryAuditReq) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminQueryAuditReq.ProtoReflect.Descriptor instead.
+func (*AdminQueryAuditReq) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AdminQueryAuditReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *AdminQueryAuditReq) GetShortURLID() string {
+	if x != nil {
+		return x.ShortURLID
+	}
+	return ""
+}
+
+func (x *AdminQueryAuditReq) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *AdminQueryAuditReq) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *AdminQueryAuditReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AdminQueryAuditResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminQueryAuditResp) Reset() {
+	*x = AdminQueryAuditResp{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminQueryAuditResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminQueryAuditResp) ProtoMessage() {}
+
+func (x *AdminQueryAuditResp) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminQueryAuditResp.ProtoReflect.Descriptor instead.
+func (*AdminQueryAuditResp) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AdminQueryAuditResp) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
 type BatchShortenReq_BatchShorten struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CorrelationID string                 `protobuf:"bytes,1,opt,name=correlationID,json=correlation_id,proto3" json:"correlationID,omitempty"`
@@ -479,7 +1289,7 @@ type BatchShortenReq_BatchShorten struct {
 
 func (x *BatchShortenReq_BatchShorten) Reset() {
 	*x = BatchShortenReq_BatchShorten{}
-	mi := &file_internal_proto_shortener_proto_msgTypes[10]
+	mi := &file_internal_proto_shortener_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -491,7 +1301,7 @@ func (x *BatchShortenReq_BatchShorten) String() string {
 func (*BatchShortenReq_BatchShorten) ProtoMessage() {}
 
 func (x *BatchShortenReq_BatchShorten) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_shortener_proto_msgTypes[10]
+	mi := &file_internal_proto_shortener_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -531,7 +1341,7 @@ type BatchShortenResp_BatchShorten struct {
 
 func (x *BatchShortenResp_BatchShorten) Reset() {
 	*x = BatchShortenResp_BatchShorten{}
-	mi := &file_internal_proto_shortener_proto_msgTypes[11]
+	mi := &file_internal_proto_shortener_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -543,7 +1353,7 @@ func (x *BatchShortenResp_BatchShorten) String() string {
 func (*BatchShortenResp_BatchShorten) ProtoMessage() {}
 
 func (x *BatchShortenResp_BatchShorten) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_shortener_proto_msgTypes[11]
+	mi := &file_internal_proto_shortener_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -583,7 +1393,7 @@ type GetUserURLSResp_UserURL struct {
 
 func (x *GetUserURLSResp_UserURL) Reset() {
 	*x = GetUserURLSResp_UserURL{}
-	mi := &file_internal_proto_shortener_proto_msgTypes[12]
+	mi := &file_internal_proto_shortener_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -595,7 +1405,7 @@ func (x *GetUserURLSResp_UserURL) String() string {
 func (*GetUserURLSResp_UserURL) ProtoMessage() {}
 
 func (x *GetUserURLSResp_UserURL) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_shortener_proto_msgTypes[12]
+	mi := &file_internal_proto_shortener_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -625,11 +1435,147 @@ func (x *GetUserURLSResp_UserURL) GetOriginalURL() string {
 	return ""
 }
 
+type StreamShortenResp_Result struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CorrelationID string                 `protobuf:"bytes,1,opt,name=correlationID,json=correlation_id,proto3" json:"correlationID,omitempty"`
+	ShortURL      string                 `protobuf:"bytes,2,opt,name=shortURL,json=short_url,proto3" json:"shortURL,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamShortenResp_Result) Reset() {
+	*x = StreamShortenResp_Result{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamShortenResp_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamShortenResp_Result) ProtoMessage() {}
+
+func (x *StreamShortenResp_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamShortenResp_Result.ProtoReflect.Descriptor instead.
+func (*StreamShortenResp_Result) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *StreamShortenResp_Result) GetCorrelationID() string {
+	if x != nil {
+		return x.CorrelationID
+	}
+	return ""
+}
+
+func (x *StreamShortenResp_Result) GetShortURL() string {
+	if x != nil {
+		return x.ShortURL
+	}
+	return ""
+}
+
+func (x *StreamShortenResp_Result) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type InternalStatsResp_CacheStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          int64                  `protobuf:"varint,1,opt,name=hits,proto3" json:"hits,omitempty"`
+	NegativeHits  int64                  `protobuf:"varint,2,opt,name=negativeHits,json=negative_hits,proto3" json:"negativeHits,omitempty"`
+	Misses        int64                  `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	Entries       *int64                 `protobuf:"varint,4,opt,name=entries,proto3,oneof" json:"entries,omitempty"`
+	HitRatio      float64                `protobuf:"fixed64,5,opt,name=hitRatio,json=hit_ratio,proto3" json:"hitRatio,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InternalStatsResp_CacheStats) Reset() {
+	*x = InternalStatsResp_CacheStats{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InternalStatsResp_CacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InternalStatsResp_CacheStats) ProtoMessage() {}
+
+func (x *InternalStatsResp_CacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InternalStatsResp_CacheStats.ProtoReflect.Descriptor instead.
+func (*InternalStatsResp_CacheStats) Descriptor() ([]byte, []int) {
+	return file_internal_proto_shortener_proto_rawDescGZIP(), []int{12, 0}
+}
+
+func (x *InternalStatsResp_CacheStats) GetHits() int64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *InternalStatsResp_CacheStats) GetNegativeHits() int64 {
+	if x != nil {
+		return x.NegativeHits
+	}
+	return 0
+}
+
+func (x *InternalStatsResp_CacheStats) GetMisses() int64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *InternalStatsResp_CacheStats) GetEntries() int64 {
+	if x != nil && x.Entries != nil {
+		return *x.Entries
+	}
+	return 0
+}
+
+func (x *InternalStatsResp_CacheStats) GetHitRatio() float64 {
+	if x != nil {
+		return x.HitRatio
+	}
+	return 0
+}
+
 var File_internal_proto_shortener_proto protoreflect.FileDescriptor
 
 const file_internal_proto_shortener_proto_rawDesc = "" +
 	"\n" +
-	"\x1einternal/proto/shortener.proto\x12\x05proto\"!\n" +
+	"\x1einternal/proto/shortener.proto\x12\x05proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"!\n" +
 	"\rShortenURLReq\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\"J\n" +
 	"\x0eShortenURLResp\x12\x1b\n" +
@@ -658,16 +1604,101 @@ const file_internal_proto_shortener_proto_rawDesc = "" +
 	"\x05Empty\".\n" +
 	"\rDeleteURLSReq\x12\x1d\n" +
 	"\tshortURLs\x18\x01 \x03(\tR\n" +
-	"short_urls\"4\n" +
-	"\x0eDeleteURLSResp\x12\"\n" +
-	"\x06status\x18\x01 \x01(\tR\x12deleted_short_urls2\xda\x02\n" +
-	"\tShortener\x129\n" +
+	"short_urls\"?\n" +
+	"\x0eDeleteURLSResp\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x15\n" +
+	"\x05jobID\x18\x02 \x01(\tR\x06job_id\"\\\n" +
+	"\x10StreamShortenReq\x12%\n" +
+	"\rcorrelationID\x18\x01 \x01(\tR\x0ecorrelation_id\x12!\n" +
+	"\voriginalURL\x18\x02 \x01(\tR\foriginal_url\"\xcf\x01\n" +
+	"\x11StreamShortenResp\x129\n" +
+	"\aresults\x18\x01 \x03(\v2\x1f.proto.StreamShortenResp.ResultR\aresults\x12\x1b\n" +
+	"\bjwtToken\x18\x02 \x01(\tR\tjwt_token\x1ab\n" +
+	"\x06Result\x12%\n" +
+	"\rcorrelationID\x18\x01 \x01(\tR\x0ecorrelation_id\x12\x1b\n" +
+	"\bshortURL\x18\x02 \x01(\tR\tshort_url\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xa0\x02\n" +
+	"\x11InternalStatsResp\x12\x12\n" +
+	"\x04urls\x18\x01 \x01(\x03R\x04urls\x12\x14\n" +
+	"\x05users\x18\x02 \x01(\x03R\x05users\x129\n" +
+	"\x05cache\x18\x03 \x01(\v2#.proto.InternalStatsResp.CacheStatsR\x05cache\x1a\xa5\x01\n" +
+	"\n" +
+	"CacheStats\x12\x12\n" +
+	"\x04hits\x18\x01 \x01(\x03R\x04hits\x12#\n" +
+	"\fnegativeHits\x18\x02 \x01(\x03R\rnegative_hits\x12\x16\n" +
+	"\x06misses\x18\x03 \x01(\x03R\x06misses\x12\x1d\n" +
+	"\aentries\x18\x04 \x01(\x03H\x00R\aentries\x88\x01\x01\x12\x1b\n" +
+	"\bhitRatio\x18\x05 \x01(\x01R\thit_ratioB\n" +
+	"\n" +
+	"\b_entries\"\xc5\x01\n" +
+	"\bAdminURL\x12 \n" +
+	"\n" +
+	"shortURLID\x18\x01 \x01(\tR\fshort_url_id\x12\x1b\n" +
+	"\bshortURL\x18\x02 \x01(\tR\tshort_url\x12!\n" +
+	"\voriginalURL\x18\x03 \x01(\tR\foriginal_url\x12\x17\n" +
+	"\x06userID\x18\x04 \x01(\tR\auser_id\x12\x1d\n" +
+	"\tisDeleted\x18\x05 \x01(\bR\n" +
+	"is_deleted\x12\x1f\n" +
+	"\n" +
+	"isDisabled\x18\x06 \x01(\bR\vis_disabled\"2\n" +
+	"\x0eAdminGetURLReq\x12 \n" +
+	"\n" +
+	"shortURLID\x18\x01 \x01(\tR\fshort_url_id\".\n" +
+	"\x13AdminGetUserURLsReq\x12\x17\n" +
+	"\x06userID\x18\x01 \x01(\tR\auser_id\";\n" +
+	"\x14AdminGetUserURLsResp\x12#\n" +
+	"\x04urls\x18\x01 \x03(\v2\x0f.proto.AdminURLR\x04urls\"Y\n" +
+	"\x17AdminSetURLsDisabledReq\x12\"\n" +
+	"\vshortURLIDs\x18\x01 \x03(\tR\rshort_url_ids\x12\x1a\n" +
+	"\bdisabled\x18\x02 \x01(\bR\bdisabled\"8\n" +
+	"\x12AdminDeleteURLsReq\x12\"\n" +
+	"\vshortURLIDs\x18\x01 \x03(\tR\rshort_url_ids\"S\n" +
+	"\x14AdminReassignURLsReq\x12\"\n" +
+	"\vshortURLIDs\x18\x01 \x03(\tR\rshort_url_ids\x12\x17\n" +
+	"\x06userID\x18\x02 \x01(\tR\auser_id\"-\n" +
+	"\x0fAdminActionResp\x12\x1a\n" +
+	"\baffected\x18\x01 \x01(\x03R\baffected\"\xa6\x02\n" +
+	"\n" +
+	"AuditEntry\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x19\n" +
+	"\aactorID\x18\x02 \x01(\tR\bactor_id\x12\x1f\n" +
+	"\n" +
+	"authMethod\x18\x03 \x01(\tR\vauth_method\x12\x1b\n" +
+	"\bclientIP\x18\x04 \x01(\tR\tclient_ip\x12\x1c\n" +
+	"\toperation\x18\x05 \x01(\tR\toperation\x12\x1d\n" +
+	"\tshortURLs\x18\x06 \x03(\tR\n" +
+	"short_urls\x12$\n" +
+	"\ftargetUserID\x18\a \x01(\tR\x0etarget_user_id\x12\x16\n" +
+	"\x06result\x18\b \x01(\tR\x06result\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"\xc1\x01\n" +
+	"\x12AdminQueryAuditReq\x12\x17\n" +
+	"\x06userID\x18\x01 \x01(\tR\auser_id\x12 \n" +
+	"\n" +
+	"shortURLID\x18\x02 \x01(\tR\fshort_url_id\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"B\n" +
+	"\x13AdminQueryAuditResp\x12+\n" +
+	"\aentries\x18\x01 \x03(\v2\x11.proto.AuditEntryR\aentries2\xc2\x05\n" +
+	"\tShortener\x12Q\n" +
+	"\n" +
+	"ShortenURL\x12\x14.proto.ShortenURLReq\x1a\x15.proto.ShortenURLResp\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v2/shorten\x12`\n" +
+	"\x0fBatchShortenURL\x12\x16.proto.BatchShortenReq\x1a\x17.proto.BatchShortenResp\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v2/shorten/batch\x12w\n" +
+	"\x15GetOriginalURLByShort\x12\x1f.proto.GetOriginalURLByShortReq\x1a .proto.GetOriginalURLByShortResp\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v2/urls/{shortURL}\x12J\n" +
+	"\vGetUserURLS\x12\f.proto.Empty\x1a\x16.proto.GetUserURLSResp\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v2/user/urls\x12W\n" +
+	"\x0eDeleteUserURLS\x12\x14.proto.DeleteURLSReq\x1a\x15.proto.DeleteURLSResp\"\x18\x82\xd3\xe4\x93\x02\x12:\x01**\r/v2/user/urls\x12V\n" +
+	"\x10GetInternalStats\x12\f.proto.Empty\x1a\x18.proto.InternalStatsResp\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v2/internal/stats\x12H\n" +
+	"\x11StreamShortenURLs\x12\x17.proto.StreamShortenReq\x1a\x18.proto.StreamShortenResp(\x01\x12@\n" +
+	"\x0eStreamUserURLs\x12\f.proto.Empty\x1a\x1e.proto.GetUserURLSResp.UserURL0\x012\x97\x03\n" +
+	"\x05Admin\x120\n" +
+	"\x06GetURL\x12\x15.proto.AdminGetURLReq\x1a\x0f.proto.AdminURL\x12F\n" +
+	"\vGetUserURLs\x12\x1a.proto.AdminGetUserURLsReq\x1a\x1b.proto.AdminGetUserURLsResp\x12I\n" +
+	"\x0fSetURLsDisabled\x12\x1e.proto.AdminSetURLsDisabledReq\x1a\x16.proto.AdminActionResp\x12?\n" +
+	"\n" +
+	"DeleteURLs\x12\x19.proto.AdminDeleteURLsReq\x1a\x16.proto.AdminActionResp\x12C\n" +
+	"\fReassignURLs\x12\x1b.proto.AdminReassignURLsReq\x1a\x16.proto.AdminActionResp\x12C\n" +
 	"\n" +
-	"ShortenURL\x12\x14.proto.ShortenURLReq\x1a\x15.proto.ShortenURLResp\x12B\n" +
-	"\x0fBatchShortenURL\x12\x16.proto.BatchShortenReq\x1a\x17.proto.BatchShortenResp\x12Z\n" +
-	"\x15GetOriginalURLByShort\x12\x1f.proto.GetOriginalURLByShortReq\x1a .proto.GetOriginalURLByShortResp\x123\n" +
-	"\vGetUserURLS\x12\f.proto.Empty\x1a\x16.proto.GetUserURLSResp\x12=\n" +
-	"\x0eDeleteUserURLS\x12\x14.proto.DeleteURLSReq\x1a\x15.proto.DeleteURLSRespB3Z1github.com/mp1947/ya-url-shortener/internal/protob\x06proto3"
+	"QueryAudit\x12\x19.proto.AdminQueryAuditReq\x1a\x1a.proto.AdminQueryAuditRespB3Z1github.com/mp1947/ya-url-shortener/internal/protob\x06proto3"
 
 var (
 	file_internal_proto_shortener_proto_rawDescOnce sync.Once
@@ -681,7 +1712,7 @@ func file_internal_proto_shortener_proto_rawDescGZIP() []byte {
 	return file_internal_proto_shortener_proto_rawDescData
 }
 
-var file_internal_proto_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_internal_proto_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_internal_proto_shortener_proto_goTypes = []any{
 	(*ShortenURLReq)(nil),                 // 0: proto.ShortenURLReq
 	(*ShortenURLResp)(nil),                // 1: proto.ShortenURLResp
@@ -693,29 +1724,71 @@ var file_internal_proto_shortener_proto_goTypes = []any{
 	(*Empty)(nil),                         // 7: proto.Empty
 	(*DeleteURLSReq)(nil),                 // 8: proto.DeleteURLSReq
 	(*DeleteURLSResp)(nil),                // 9: proto.DeleteURLSResp
-	(*BatchShortenReq_BatchShorten)(nil),  // 10: proto.BatchShortenReq.BatchShorten
-	(*BatchShortenResp_BatchShorten)(nil), // 11: proto.BatchShortenResp.BatchShorten
-	(*GetUserURLSResp_UserURL)(nil),       // 12: proto.GetUserURLSResp.UserURL
+	(*StreamShortenReq)(nil),              // 10: proto.StreamShortenReq
+	(*StreamShortenResp)(nil),             // 11: proto.StreamShortenResp
+	(*InternalStatsResp)(nil),             // 12: proto.InternalStatsResp
+	(*AdminURL)(nil),                      // 13: proto.AdminURL
+	(*AdminGetURLReq)(nil),                // 14: proto.AdminGetURLReq
+	(*AdminGetUserURLsReq)(nil),           // 15: proto.AdminGetUserURLsReq
+	(*AdminGetUserURLsResp)(nil),          // 16: proto.AdminGetUserURLsResp
+	(*AdminSetURLsDisabledReq)(nil),       // 17: proto.AdminSetURLsDisabledReq
+	(*AdminDeleteURLsReq)(nil),            // 18: proto.AdminDeleteURLsReq
+	(*AdminReassignURLsReq)(nil),          // 19: proto.AdminReassignURLsReq
+	(*AdminActionResp)(nil),               // 20: proto.AdminActionResp
+	(*AuditEntry)(nil),                    // 21: proto.AuditEntry
+	(*AdminQueryAuditReq)(nil),            // 22: proto.AdminQueryAuditReq
+	(*AdminQueryAuditResp)(nil),           // 23: proto.AdminQueryAuditResp
+	(*BatchShortenReq_BatchShorten)(nil),  // 24: proto.BatchShortenReq.BatchShorten
+	(*BatchShortenResp_BatchShorten)(nil), // 25: proto.BatchShortenResp.BatchShorten
+	(*GetUserURLSResp_UserURL)(nil),       // 26: proto.GetUserURLSResp.UserURL
+	(*StreamShortenResp_Result)(nil),      // 27: proto.StreamShortenResp.Result
+	(*InternalStatsResp_CacheStats)(nil),  // 28: proto.InternalStatsResp.CacheStats
+	(*timestamppb.Timestamp)(nil),         // 29: google.protobuf.Timestamp
 }
 var file_internal_proto_shortener_proto_depIdxs = []int32{
-	10, // 0: proto.BatchShortenReq.batchShortenData:type_name -> proto.BatchShortenReq.BatchShorten
-	11, // 1: proto.BatchShortenResp.batchShortenData:type_name -> proto.BatchShortenResp.BatchShorten
-	12, // 2: proto.GetUserURLSResp.userURLs:type_name -> proto.GetUserURLSResp.UserURL
-	0,  // 3: proto.Shortener.ShortenURL:input_type -> proto.ShortenURLReq
-	2,  // 4: proto.Shortener.BatchShortenURL:input_type -> proto.BatchShortenReq
-	4,  // 5: proto.Shortener.GetOriginalURLByShort:input_type -> proto.GetOriginalURLByShortReq
-	7,  // 6: proto.Shortener.GetUserURLS:input_type -> proto.Empty
-	8,  // 7: proto.Shortener.DeleteUserURLS:input_type -> proto.DeleteURLSReq
-	1,  // 8: proto.Shortener.ShortenURL:output_type -> proto.ShortenURLResp
-	3,  // 9: proto.Shortener.BatchShortenURL:output_type -> proto.BatchShortenResp
-	5,  // 10: proto.Shortener.GetOriginalURLByShort:output_type -> proto.GetOriginalURLByShortResp
-	6,  // 11: proto.Shortener.GetUserURLS:output_type -> proto.GetUserURLSResp
-	9,  // 12: proto.Shortener.DeleteUserURLS:output_type -> proto.DeleteURLSResp
-	8,  // [8:13] is the sub-list for method output_type
-	3,  // [3:8] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	24, // 0: proto.BatchShortenReq.batchShortenData:type_name -> proto.BatchShortenReq.BatchShorten
+	25, // 1: proto.BatchShortenResp.batchShortenData:type_name -> proto.BatchShortenResp.BatchShorten
+	26, // 2: proto.GetUserURLSResp.userURLs:type_name -> proto.GetUserURLSResp.UserURL
+	27, // 3: proto.StreamShortenResp.results:type_name -> proto.StreamShortenResp.Result
+	28, // 4: proto.InternalStatsResp.cache:type_name -> proto.InternalStatsResp.CacheStats
+	13, // 5: proto.AdminGetUserURLsResp.urls:type_name -> proto.AdminURL
+	29, // 6: proto.AuditEntry.time:type_name -> google.protobuf.Timestamp
+	29, // 7: proto.AdminQueryAuditReq.from:type_name -> google.protobuf.Timestamp
+	29, // 8: proto.AdminQueryAuditReq.to:type_name -> google.protobuf.Timestamp
+	21, // 9: proto.AdminQueryAuditResp.entries:type_name -> proto.AuditEntry
+	0,  // 10: proto.Shortener.ShortenURL:input_type -> proto.ShortenURLReq
+	2,  // 11: proto.Shortener.BatchShortenURL:input_type -> proto.BatchShortenReq
+	4,  // 12: proto.Shortener.GetOriginalURLByShort:input_type -> proto.GetOriginalURLByShortReq
+	7,  // 13: proto.Shortener.GetUserURLS:input_type -> proto.Empty
+	8,  // 14: proto.Shortener.DeleteUserURLS:input_type -> proto.DeleteURLSReq
+	7,  // 15: proto.Shortener.GetInternalStats:input_type -> proto.Empty
+	10, // 16: proto.Shortener.StreamShortenURLs:input_type -> proto.StreamShortenReq
+	7,  // 17: proto.Shortener.StreamUserURLs:input_type -> proto.Empty
+	14, // 18: proto.Admin.GetURL:input_type -> proto.AdminGetURLReq
+	15, // 19: proto.Admin.GetUserURLs:input_type -> proto.AdminGetUserURLsReq
+	17, // 20: proto.Admin.SetURLsDisabled:input_type -> proto.AdminSetURLsDisabledReq
+	18, // 21: proto.Admin.DeleteURLs:input_type -> proto.AdminDeleteURLsReq
+	19, // 22: proto.Admin.ReassignURLs:input_type -> proto.AdminReassignURLsReq
+	22, // 23: proto.Admin.QueryAudit:input_type -> proto.AdminQueryAuditReq
+	1,  // 24: proto.Shortener.ShortenURL:output_type -> proto.ShortenURLResp
+	3,  // 25: proto.Shortener.BatchShortenURL:output_type -> proto.BatchShortenResp
+	5,  // 26: proto.Shortener.GetOriginalURLByShort:output_type -> proto.GetOriginalURLByShortResp
+	6,  // 27: proto.Shortener.GetUserURLS:output_type -> proto.GetUserURLSResp
+	9,  // 28: proto.Shortener.DeleteUserURLS:output_type -> proto.DeleteURLSResp
+	12, // 29: proto.Shortener.GetInternalStats:output_type -> proto.InternalStatsResp
+	11, // 30: proto.Shortener.StreamShortenURLs:output_type -> proto.StreamShortenResp
+	26, // 31: proto.Shortener.StreamUserURLs:output_type -> proto.GetUserURLSResp.UserURL
+	13, // 32: proto.Admin.GetURL:output_type -> proto.AdminURL
+	16, // 33: proto.Admin.GetUserURLs:output_type -> proto.AdminGetUserURLsResp
+	20, // 34: proto.Admin.SetURLsDisabled:output_type -> proto.AdminActionResp
+	20, // 35: proto.Admin.DeleteURLs:output_type -> proto.AdminActionResp
+	20, // 36: proto.Admin.ReassignURLs:output_type -> proto.AdminActionResp
+	23, // 37: proto.Admin.QueryAudit:output_type -> proto.AdminQueryAuditResp
+	24, // [24:38] is the sub-list for method output_type
+	10, // [10:24] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_shortener_proto_init() }
@@ -723,15 +1796,16 @@ func file_internal_proto_shortener_proto_init() {
 	if File_internal_proto_shortener_proto != nil {
 		return
 	}
+	file_internal_proto_shortener_proto_msgTypes[28].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_proto_shortener_proto_rawDesc), len(file_internal_proto_shortener_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   29,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_internal_proto_shortener_proto_goTypes,
 		DependencyIndexes: file_internal_proto_shortener_proto_depIdxs,
@@ -24,6 +24,9 @@ const (
 	Shortener_GetOriginalURLByShort_FullMethodName = "/proto.Shortener/GetOriginalURLByShort"
 	Shortener_GetUserURLS_FullMethodName           = "/proto.Shortener/GetUserURLS"
 	Shortener_DeleteUserURLS_FullMethodName        = "/proto.Shortener/DeleteUserURLS"
+	Shortener_GetInternalStats_FullMethodName      = "/proto.Shortener/GetInternalStats"
+	Shortener_StreamShortenURLs_FullMethodName     = "/proto.Shortener/StreamShortenURLs"
+	Shortener_StreamUserURLs_FullMethodName        = "/proto.Shortener/StreamUserURLs"
 )
 
 // ShortenerClient is the client API for Shortener service.
@@ -35,6 +38,9 @@ type ShortenerClient interface {
 	GetOriginalURLByShort(ctx context.Context, in *GetOriginalURLByShortReq, opts ...grpc.CallOption) (*GetOriginalURLByShortResp, error)
 	GetUserURLS(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetUserURLSResp, error)
 	DeleteUserURLS(ctx context.Context, in *DeleteURLSReq, opts ...grpc.CallOption) (*DeleteURLSResp, error)
+	GetInternalStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*InternalStatsResp, error)
+	StreamShortenURLs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StreamShortenReq, StreamShortenResp], error)
+	StreamUserURLs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetUserURLSResp_UserURL], error)
 }
 
 type shortenerClient struct {
@@ -95,6 +101,48 @@ func (c *shortenerClient) DeleteUserURLS(ctx context.Context, in *DeleteURLSReq,
 	return out, nil
 }
 
+func (c *shortenerClient) GetInternalStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*InternalStatsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InternalStatsResp)
+	err := c.cc.Invoke(ctx, Shortener_GetInternalStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerClient) StreamShortenURLs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StreamShortenReq, StreamShortenResp], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Shortener_ServiceDesc.Streams[0], Shortener_StreamShortenURLs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamShortenReq, StreamShortenResp]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Shortener_StreamShortenURLsClient = grpc.ClientStreamingClient[StreamShortenReq, StreamShortenResp]
+
+func (c *shortenerClient) StreamUserURLs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetUserURLSResp_UserURL], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Shortener_ServiceDesc.Streams[1], Shortener_StreamUserURLs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Empty, GetUserURLSResp_UserURL]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Shortener_StreamUserURLsClient = grpc.ServerStreamingClient[GetUserURLSResp_UserURL]
+
 // ShortenerServer is the server API for Shortener service.
 // All implementations must embed UnimplementedShortenerServer
 // for forward compatibility.
@@ -104,6 +152,9 @@ type ShortenerServer interface {
 	GetOriginalURLByShort(context.Context, *GetOriginalURLByShortReq) (*GetOriginalURLByShortResp, error)
 	GetUserURLS(context.Context, *Empty) (*GetUserURLSResp, error)
 	DeleteUserURLS(context.Context, *DeleteURLSReq) (*DeleteURLSResp, error)
+	GetInternalStats(context.Context, *Empty) (*InternalStatsResp, error)
+	StreamShortenURLs(grpc.ClientStreamingServer[StreamShortenReq, StreamShortenResp]) error
+	StreamUserURLs(*Empty, grpc.ServerStreamingServer[GetUserURLSResp_UserURL]) error
 	mustEmbedUnimplementedShortenerServer()
 }
 
@@ -129,6 +180,15 @@ func (UnimplementedShortenerServer) GetUserURLS(context.Context, *Empty) (*GetUs
 func (UnimplementedShortenerServer) DeleteUserURLS(context.Context, *DeleteURLSReq) (*DeleteURLSResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserURLS not implemented")
 }
+func (UnimplementedShortenerServer) GetInternalStats(context.Context, *Empty) (*InternalStatsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInternalStats not implemented")
+}
+func (UnimplementedShortenerServer) StreamShortenURLs(grpc.ClientStreamingServer[StreamShortenReq, StreamShortenResp]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamShortenURLs not implemented")
+}
+func (UnimplementedShortenerServer) StreamUserURLs(*Empty, grpc.ServerStreamingServer[GetUserURLSResp_UserURL]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamUserURLs not implemented")
+}
 func (UnimplementedShortenerServer) mustEmbedUnimplementedShortenerServer() {}
 func (UnimplementedShortenerServer) testEmbeddedByValue()                   {}
 
@@ -240,6 +300,42 @@ func _Shortener_DeleteUserURLS_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Shortener_GetInternalStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServer).GetInternalStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shortener_GetInternalStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServer).GetInternalStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shortener_StreamShortenURLs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShortenerServer).StreamShortenURLs(&grpc.GenericServerStream[StreamShortenReq, StreamShortenResp]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Shortener_StreamShortenURLsServer = grpc.ClientStreamingServer[StreamShortenReq, StreamShortenResp]
+
+func _Shortener_StreamUserURLs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShortenerServer).StreamUserURLs(m, &grpc.GenericServerStream[Empty, GetUserURLSResp_UserURL]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Shortener_StreamUserURLsServer = grpc.ServerStreamingServer[GetUserURLSResp_UserURL]
+
 // Shortener_ServiceDesc is the grpc.ServiceDesc for Shortener service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +363,313 @@ var Shortener_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUserURLS",
 			Handler:    _Shortener_DeleteUserURLS_Handler,
 		},
+		{
+			MethodName: "GetInternalStats",
+			Handler:    _Shortener_GetInternalStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamShortenURLs",
+			Handler:       _Shortener_StreamShortenURLs_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamUserURLs",
+			Handler:       _Shortener_StreamUserURLs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/proto/shortener.proto",
+}
+
+const (
+	Admin_GetURL_FullMethodName          = "/proto.Admin/GetURL"
+	Admin_GetUserURLs_FullMethodName     = "/proto.Admin/GetUserURLs"
+	Admin_SetURLsDisabled_FullMethodName = "/proto.Admin/SetURLsDisabled"
+	Admin_DeleteURLs_FullMethodName      = "/proto.Admin/DeleteURLs"
+	Admin_ReassignURLs_FullMethodName    = "/proto.Admin/ReassignURLs"
+	Admin_QueryAudit_FullMethodName      = "/proto.Admin/QueryAudit"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	GetURL(ctx context.Context, in *AdminGetURLReq, opts ...grpc.CallOption) (*AdminURL, error)
+	GetUserURLs(ctx context.Context, in *AdminGetUserURLsReq, opts ...grpc.CallOption) (*AdminGetUserURLsResp, error)
+	SetURLsDisabled(ctx context.Context, in *AdminSetURLsDisabledReq, opts ...grpc.CallOption) (*AdminActionResp, error)
+	DeleteURLs(ctx context.Context, in *AdminDeleteURLsReq, opts ...grpc.CallOption) (*AdminActionResp, error)
+	ReassignURLs(ctx context.Context, in *AdminReassignURLsReq, opts ...grpc.CallOption) (*AdminActionResp, error)
+	QueryAudit(ctx context.Context, in *AdminQueryAuditReq, opts ...grpc.CallOption) (*AdminQueryAuditResp, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) GetURL(ctx context.Context, in *AdminGetURLReq, opts ...grpc.CallOption) (*AdminURL, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminURL)
+	err := c.cc.Invoke(ctx, Admin_GetURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetUserURLs(ctx context.Context, in *AdminGetUserURLsReq, opts ...grpc.CallOption) (*AdminGetUserURLsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminGetUserURLsResp)
+	err := c.cc.Invoke(ctx, Admin_GetUserURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetURLsDisabled(ctx context.Context, in *AdminSetURLsDisabledReq, opts ...grpc.CallOption) (*AdminActionResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResp)
+	err := c.cc.Invoke(ctx, Admin_SetURLsDisabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DeleteURLs(ctx context.Context, in *AdminDeleteURLsReq, opts ...grpc.CallOption) (*AdminActionResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResp)
+	err := c.cc.Invoke(ctx, Admin_DeleteURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ReassignURLs(ctx context.Context, in *AdminReassignURLsReq, opts ...grpc.CallOption) (*AdminActionResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResp)
+	err := c.cc.Invoke(ctx, Admin_ReassignURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) QueryAudit(ctx context.Context, in *AdminQueryAuditReq, opts ...grpc.CallOption) (*AdminQueryAuditResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminQueryAuditResp)
+	err := c.cc.Invoke(ctx, Admin_QueryAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility.
+type AdminServer interface {
+	GetURL(context.Context, *AdminGetURLReq) (*AdminURL, error)
+	GetUserURLs(context.Context, *AdminGetUserURLsReq) (*AdminGetUserURLsResp, error)
+	SetURLsDisabled(context.Context, *AdminSetURLsDisabledReq) (*AdminActionResp, error)
+	DeleteURLs(context.Context, *AdminDeleteURLsReq) (*AdminActionResp, error)
+	ReassignURLs(context.Context, *AdminReassignURLsReq) (*AdminActionResp, error)
+	QueryAudit(context.Context, *AdminQueryAuditReq) (*AdminQueryAuditResp, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServer struct{}
+
+func (UnimplementedAdminServer) GetURL(context.Context, *AdminGetURLReq) (*AdminURL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetURL not implemented")
+}
+func (UnimplementedAdminServer) GetUserURLs(context.Context, *AdminGetUserURLsReq) (*AdminGetUserURLsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserURLs not implemented")
+}
+func (UnimplementedAdminServer) SetURLsDisabled(context.Context, *AdminSetURLsDisabledReq) (*AdminActionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetURLsDisabled not implemented")
+}
+func (UnimplementedAdminServer) DeleteURLs(context.Context, *AdminDeleteURLsReq) (*AdminActionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteURLs not implemented")
+}
+func (UnimplementedAdminServer) ReassignURLs(context.Context, *AdminReassignURLsReq) (*AdminActionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReassignURLs not implemented")
+}
+func (UnimplementedAdminServer) QueryAudit(context.Context, *AdminQueryAuditReq) (*AdminQueryAuditResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAudit not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+func (UnimplementedAdminServer) testEmbeddedByValue()               {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_GetURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminGetURLReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetURL(ctx, req.(*AdminGetURLReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetUserURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminGetUserURLsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetUserURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetUserURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetUserURLs(ctx, req.(*AdminGetUserURLsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetURLsDisabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminSetURLsDisabledReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetURLsDisabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_SetURLsDisabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetURLsDisabled(ctx, req.(*AdminSetURLsDisabledReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DeleteURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminDeleteURLsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DeleteURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_DeleteURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DeleteURLs(ctx, req.(*AdminDeleteURLsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ReassignURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminReassignURLsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ReassignURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ReassignURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ReassignURLs(ctx, req.(*AdminReassignURLsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_QueryAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminQueryAuditReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).QueryAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_QueryAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).QueryAudit(ctx, req.(*AdminQueryAuditReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetURL",
+			Handler:    _Admin_GetURL_Handler,
+		},
+		{
+			MethodName: "GetUserURLs",
+			Handler:    _Admin_GetUserURLs_Handler,
+		},
+		{
+			MethodName: "SetURLsDisabled",
+			Handler:    _Admin_SetURLsDisabled_Handler,
+		},
+		{
+			MethodName: "DeleteURLs",
+			Handler:    _Admin_DeleteURLs_Handler,
+		},
+		{
+			MethodName: "ReassignURLs",
+			Handler:    _Admin_ReassignURLs_Handler,
+		},
+		{
+			MethodName: "QueryAudit",
+			Handler:    _Admin_QueryAudit_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/proto/shortener.proto",
@@ -0,0 +1,152 @@
+// Package ratelimit limits the rate of requests of clients with token buckets. Every client has a bucket
+// holding at most Burst tokens, refilled at Rate tokens per second, and every request takes a token.
+// Buckets are kept by a Store: Local keeps them in the process, a store shared by the instances of the
+// service, such as the Redis one, enforces a single quota across them.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sweepInterval is the number of requests after which Local drops the buckets which are full again.
+const sweepInterval = 1024
+
+// Limit is the rate of requests allowed to a client: Rate requests per second on average, with bursts
+// of at most Burst requests. A zero Rate disables the limit.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Enabled reports whether the limit applies.
+func (l Limit) Enabled() bool {
+	return l.Rate > 0
+}
+
+// Store keeps the token buckets of the clients.
+type Store interface {
+	// Allow takes a token from the bucket of key and reports whether there was one. When there was
+	// none, it returns the time after which a token is available.
+	Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+}
+
+// Limiter applies a Limit to the clients, whose buckets are kept by a Store. The limit can be
+// replaced while the Limiter is in use.
+type Limiter struct {
+	store Store
+	limit atomic.Pointer[Limit]
+}
+
+// New returns a Limiter applying limit with the buckets of store.
+func New(store Store, limit Limit) *Limiter {
+	l := &Limiter{store: store}
+	l.SetLimit(limit)
+	return l
+}
+
+// Limit returns the limit applied by the Limiter.
+func (l *Limiter) Limit() Limit {
+	return *l.limit.Load()
+}
+
+// SetLimit replaces the limit applied by the Limiter, the buckets of the clients are kept and hold
+// at most the new burst. A disabled limit allows every request.
+func (l *Limiter) SetLimit(limit Limit) {
+	l.limit.Store(&limit)
+}
+
+// Allow takes a token from the bucket of the client identified by key, as Store.Allow does, unless
+// the limit is disabled. When the store fails, the request is allowed and the error returned, so that
+// an unreachable shared store does not take the service down.
+func (l *Limiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	limit := l.Limit()
+	if !limit.Enabled() {
+		return true, 0, nil
+	}
+
+	allowed, wait, err := l.store.Allow(ctx, key, limit)
+	if err != nil {
+		return true, 0, err
+	}
+	return allowed, wait, nil
+}
+
+// bucket is a token bucket, holding tokens at the time updated.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Local is a Store keeping the token buckets in the process.
+type Local struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	buckets map[string]*bucket
+	calls   int
+}
+
+// NewLocal returns an empty Local store.
+func NewLocal() *Local {
+	return &Local{
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from the bucket of key.
+func (s *Local) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	s.calls++
+	if s.calls%sweepInterval == 0 {
+		s.sweep(now, limit)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	allowed, wait := take(b, limit, now)
+	return allowed, wait, nil
+}
+
+// sweep drops the buckets which are full again, they are recreated full when needed.
+func (s *Local) sweep(now time.Time, limit Limit) {
+	for key, b := range s.buckets {
+		if refill(b.tokens, limit, now.Sub(b.updated)) >= float64(limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// take refills b up to now and takes a token from it, returning the time until a token is
+// available when it is empty.
+func take(b *bucket, limit Limit, now time.Time) (bool, time.Duration) {
+	b.tokens = refill(b.tokens, limit, now.Sub(b.updated))
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration(math.Ceil((1 - b.tokens) / limit.Rate * float64(time.Second)))
+	return false, wait
+}
+
+// refill returns the tokens of a bucket holding tokens after elapsed.
+func refill(tokens float64, limit Limit, elapsed time.Duration) float64 {
+	if elapsed > 0 {
+		tokens += elapsed.Seconds() * limit.Rate
+	}
+	return math.Min(tokens, float64(limit.Burst))
+}
@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s := NewLocal()
+	s.now = func() time.Time { return now }
+
+	limit := Limit{Rate: 2, Burst: 2}
+	assert.True(t, limit.Enabled())
+	assert.False(t, Limit{}.Enabled())
+
+	for range 2 {
+		allowed, _, err := s.Allow(ctx, "10.0.0.1", limit)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, wait, err := s.Allow(ctx, "10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	allowed, _, err = s.Allow(ctx, "10.0.0.2", limit)
+	require.NoError(t, err)
+	assert.True(t, allowed, "buckets are kept by key")
+
+	now = now.Add(500 * time.Millisecond)
+	allowed, _, err = s.Allow(ctx, "10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	t.Run("full buckets are swept", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		for range sweepInterval {
+			_, _, err := s.Allow(ctx, "10.0.0.3", limit)
+			require.NoError(t, err)
+		}
+		assert.Len(t, s.buckets, 1)
+	})
+}
+
+func TestLimiterSetLimit(t *testing.T) {
+	ctx := context.Background()
+	l := New(NewLocal(), Limit{})
+
+	for range 3 {
+		allowed, _, err := l.Allow(ctx, "10.0.0.1")
+		require.NoError(t, err)
+		assert.True(t, allowed, "a disabled limit allows every request")
+	}
+
+	l.SetLimit(Limit{Rate: 1, Burst: 1})
+	assert.Equal(t, Limit{Rate: 1, Burst: 1}, l.Limit())
+
+	allowed, _, err := l.Allow(ctx, "10.0.0.1")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, wait, err := l.Allow(ctx, "10.0.0.1")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Positive(t, wait)
+}
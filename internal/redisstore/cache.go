@@ -0,0 +1,85 @@
+package redisstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// cacheNamespace is the namespace of the keys of the cache.
+const cacheNamespace = "cache"
+
+// scanCount is the number of keys requested by every SCAN of the cache keys.
+const scanCount = 1000
+
+// Cache is a cache.Store on a Redis server. Its size is bounded by the memory policy of the server, and
+// it is not a cache.Counter: counting its values would scan the keyspace of the server.
+type Cache struct {
+	c *Client
+}
+
+// Cache returns the cache store of the client.
+func (c *Client) Cache() *Cache {
+	return &Cache{c: c}
+}
+
+// Get returns the value stored for key.
+func (s *Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.c.rdb.Get(ctx, s.c.key(cacheNamespace, key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set stores value for key during ttl.
+func (s *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.c.rdb.Set(ctx, s.c.key(cacheNamespace, key), value, ttl).Err()
+}
+
+// Delete removes the values stored for keys.
+func (s *Cache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = s.c.key(cacheNamespace, key)
+	}
+	return s.c.rdb.Del(ctx, names...).Err()
+}
+
+// Purge removes every value of the cache.
+func (s *Cache) Purge(ctx context.Context) error {
+	return s.scan(ctx, func(keys []string) error {
+		return s.c.rdb.Del(ctx, keys...).Err()
+	})
+}
+
+// scan calls fn with the keys of the cache, a batch at a time.
+func (s *Cache) scan(ctx context.Context, fn func(keys []string) error) error {
+	var cursor uint64
+	match := s.c.key(cacheNamespace, "*")
+
+	for {
+		keys, next, err := s.c.rdb.Scan(ctx, cursor, match, scanCount).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
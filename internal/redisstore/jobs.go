@@ -0,0 +1,51 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/jobs"
+	"github.com/redis/go-redis/v9"
+)
+
+// jobsNamespace is the namespace of the keys of the deletion job statuses.
+const jobsNamespace = "job"
+
+// Jobs is a jobs.Store on a Redis server, keeping statuses as JSON.
+type Jobs struct {
+	c   *Client
+	ttl time.Duration
+}
+
+// Jobs returns the deletion job store of the client, keeping every status for ttl after its last update.
+func (c *Client) Jobs(ttl time.Duration) *Jobs {
+	return &Jobs{c: c, ttl: ttl}
+}
+
+// Put stores s.
+func (s *Jobs) Put(ctx context.Context, status jobs.Status) error {
+	value, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return s.c.rdb.Set(ctx, s.c.key(jobsNamespace, status.ID), value, s.ttl).Err()
+}
+
+// Get returns the status of the job with the given ID.
+func (s *Jobs) Get(ctx context.Context, id string) (jobs.Status, bool, error) {
+	value, err := s.c.rdb.Get(ctx, s.c.key(jobsNamespace, id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return jobs.Status{}, false, nil
+	}
+	if err != nil {
+		return jobs.Status{}, false, err
+	}
+
+	var status jobs.Status
+	if err := json.Unmarshal(value, &status); err != nil {
+		return jobs.Status{}, false, err
+	}
+	return status, true, nil
+}
@@ -0,0 +1,76 @@
+package redisstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+// rateLimitNamespace is the namespace of the keys of the token buckets.
+const rateLimitNamespace = "ratelimit"
+
+// takeToken refills the token bucket KEYS[1] up to the time ARGV[3], in milliseconds, at the rate
+// ARGV[1] per second up to ARGV[2] tokens, and takes a token from it. It returns 1 and 0 when
+// a token was taken, 0 and the milliseconds until a token is available otherwise. The bucket
+// expires once it is full again. Clocks of the instances may differ slightly, so the time of
+// the bucket never goes back.
+var takeToken = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(bucket[1])
+local updated = tonumber(bucket[2])
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+
+if now > updated then
+	tokens = tokens + (now - updated) / 1000 * rate
+else
+	now = updated
+end
+tokens = math.min(tokens, burst)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+
+return {allowed, wait}
+`)
+
+// Limiter is a ratelimit.Store on a Redis server, the buckets are shared by every instance.
+type Limiter struct {
+	c   *Client
+	now func() time.Time
+}
+
+// Limiter returns the rate limit store of the client.
+func (c *Client) Limiter() *Limiter {
+	return &Limiter{c: c, now: time.Now}
+}
+
+// Allow takes a token from the bucket of key.
+func (s *Limiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (bool, time.Duration, error) {
+	result, err := takeToken.Run(ctx, s.c.rdb,
+		[]string{s.c.key(rateLimitNamespace, key)},
+		limit.Rate, limit.Burst, s.now().UnixMilli(),
+	).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
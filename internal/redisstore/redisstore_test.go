@@ -0,0 +1,143 @@
+package redisstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mp1947/ya-url-shortener/internal/jobs"
+	"github.com/mp1947/ya-url-shortener/internal/ratelimit"
+	"github.com/mp1947/ya-url-shortener/internal/redisstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// open starts an in-process RESP server and returns a client of it along with the server.
+func open(t *testing.T) (*redisstore.Client, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+
+	c, err := redisstore.Open(context.Background(), "redis://"+server.Addr(), "test:")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	return c, server
+}
+
+func TestOpen(t *testing.T) {
+	_, err := redisstore.Open(context.Background(), "http://localhost", "test:")
+	assert.Error(t, err)
+
+	c, server := open(t)
+	require.NoError(t, c.Ping(context.Background()))
+
+	server.Close()
+	assert.Error(t, c.Ping(context.Background()))
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	c, server := open(t)
+	store := c.Cache()
+
+	require.NoError(t, store.Set(ctx, "aaa", []byte("a"), time.Minute))
+	require.NoError(t, store.Set(ctx, "bbb", []byte("b"), time.Second))
+	require.NoError(t, server.Set("other", "kept"))
+
+	value, ok, err := store.Get(ctx, "aaa")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), value)
+	assert.True(t, server.Exists("test:cache:aaa"))
+
+	assert.True(t, server.Exists("test:cache:bbb"))
+
+	server.FastForward(2 * time.Second)
+	_, ok, err = store.Get(ctx, "bbb")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.Delete(ctx, "aaa", "missing"))
+	_, ok, err = store.Get(ctx, "aaa")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.Set(ctx, "ccc", []byte("c"), time.Minute))
+	require.NoError(t, store.Purge(ctx))
+	assert.False(t, server.Exists("test:cache:ccc"))
+	assert.True(t, server.Exists("other"))
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	c, _ := open(t)
+
+	// instances share the buckets, so two limiters of the same server share the quota
+	first, second := c.Limiter(), c.Limiter()
+	limit := ratelimit.Limit{Rate: 1, Burst: 2}
+
+	allowed, _, err := first.Allow(ctx, "10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, _, err = second.Allow(ctx, "10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, wait, err := first.Allow(ctx, "10.0.0.1", limit)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Greater(t, wait, time.Duration(0))
+	assert.LessOrEqual(t, wait, time.Second)
+
+	allowed, _, err = second.Allow(ctx, "10.0.0.2", limit)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	t.Run("tokens are refilled", func(t *testing.T) {
+		limit := ratelimit.Limit{Rate: 100, Burst: 1}
+
+		allowed, _, err := first.Allow(ctx, "10.0.0.3", limit)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		time.Sleep(20 * time.Millisecond)
+
+		allowed, _, err = second.Allow(ctx, "10.0.0.3", limit)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+}
+
+func TestJobs(t *testing.T) {
+	ctx := context.Background()
+	c, server := open(t)
+	store := c.Jobs(time.Hour)
+
+	status := jobs.Status{
+		ID:        "job-1",
+		UserID:    "user-1",
+		State:     jobs.StateDone,
+		ShortURLs: 2,
+		Deleted:   2,
+		CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 1, 0, 0, 1, 0, time.UTC),
+	}
+	require.NoError(t, store.Put(ctx, status))
+
+	got, ok, err := store.Get(ctx, "job-1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, status, got)
+
+	_, ok, err = store.Get(ctx, "job-2")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	server.FastForward(2 * time.Hour)
+	_, ok, err = store.Get(ctx, "job-1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
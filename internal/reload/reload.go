@@ -0,0 +1,103 @@
+// Package reload holds the settings which can be changed while the service is running and applies
+// the configuration reloaded on SIGHUP to them. The other settings are only applied by a restart.
+package reload
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/apikey"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	"github.com/mp1947/ya-url-shortener/internal/ratelimit"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Keys are the keys of the settings applied by Settings.Apply without restarting the service.
+var Keys = []string{"ADMIN_API_KEYS", "LOG_LEVEL", "RATE_LIMIT_BURST", "RATE_LIMIT_RPS", "TRUSTED_PROXIES", "TRUSTED_SUBNET"}
+
+// Settings holds the components configured by the reloadable settings, which are shared by
+// the HTTP router and the gRPC interceptors so that both see the applied changes.
+type Settings struct {
+	TrustedSubnets *clientip.Networks
+	Resolver       *clientip.Resolver
+	AdminAPIKeys   *apikey.StaticStore
+	// LogLevel is the level of the application logger, it is not managed when nil.
+	LogLevel *zap.AtomicLevel
+	// RateLimiter limits the requests of the clients, it is not managed when nil.
+	RateLimiter *ratelimit.Limiter
+
+	mu      sync.Mutex
+	started *config.Config
+	current *config.Config
+}
+
+// Result describes the changes of a reloaded configuration.
+type Result struct {
+	// Applied are the keys of the reloadable settings changed by the reloaded configuration.
+	Applied []string
+	// RestartRequired are the keys of the settings which differ from the configuration the
+	// service was started with and are not applied until it is restarted.
+	RestartRequired []string
+}
+
+// NewSettings creates the Settings configured by cfg, the configuration the service is started with,
+// managing the given logger level and rate limiter.
+func NewSettings(cfg *config.Config, level *zap.AtomicLevel, limiter *ratelimit.Limiter) *Settings {
+	return &Settings{
+		TrustedSubnets: clientip.NewNetworks(cfg.TrustedSubnets),
+		Resolver:       clientip.NewResolver(cfg.TrustedProxies),
+		AdminAPIKeys:   apikey.NewStaticStore(cfg.AdminAPIKeys),
+		LogLevel:       level,
+		RateLimiter:    limiter,
+		started:        cfg,
+		current:        cfg,
+	}
+}
+
+// Apply applies the reloadable settings of next which differ from the last applied configuration.
+// next must be valid, as returned by config.Load, so its settings are applied all together:
+// every component is swapped atomically and requests in flight keep the previous settings.
+// A log level changed through the admin API is kept unless LOG_LEVEL itself changes.
+func (s *Settings) Apply(next *config.Config) Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result Result
+
+	for _, key := range config.Diff(s.current, next) {
+		switch key {
+		case "TRUSTED_SUBNET":
+			s.TrustedSubnets.Set(next.TrustedSubnets)
+		case "TRUSTED_PROXIES":
+			s.Resolver.SetProxies(next.TrustedProxies)
+		case "ADMIN_API_KEYS":
+			s.AdminAPIKeys.Replace(next.AdminAPIKeys)
+		case "LOG_LEVEL":
+			if s.LogLevel == nil {
+				continue
+			}
+			level, _ := zapcore.ParseLevel(next.Logging.Level)
+			s.LogLevel.SetLevel(level)
+		case "RATE_LIMIT_RPS", "RATE_LIMIT_BURST":
+			if s.RateLimiter == nil {
+				continue
+			}
+			s.RateLimiter.SetLimit(ratelimit.Limit{Rate: next.RateLimit.RPS, Burst: next.RateLimit.Burst})
+		default:
+			continue
+		}
+		result.Applied = append(result.Applied, key)
+	}
+
+	for _, key := range config.Diff(s.started, next) {
+		if !slices.Contains(Keys, key) {
+			result.RestartRequired = append(result.RestartRequired, key)
+		}
+	}
+
+	s.current = next
+
+	return result
+}
@@ -0,0 +1,106 @@
+package reload_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
+	"github.com/mp1947/ya-url-shortener/internal/ratelimit"
+	"github.com/mp1947/ya-url-shortener/internal/reload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func mustLoad(t *testing.T, environ ...string) *config.Config {
+	t.Helper()
+	cfg, err := config.Load(nil, append([]string{authtest.Environ}, environ...), nil)
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestApply(t *testing.T) {
+	started := mustLoad(t, "TRUSTED_SUBNET=192.168.1.0/24", "ADMIN_API_KEYS=old-key")
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	limiter := ratelimit.New(ratelimit.NewLocal(), ratelimit.Limit{})
+	settings := reload.NewSettings(started, &level, limiter)
+
+	t.Run("reloadable settings", func(t *testing.T) {
+		result := settings.Apply(mustLoad(t,
+			"TRUSTED_SUBNET=10.0.0.0/8",
+			"TRUSTED_PROXIES=172.16.0.0/12",
+			"ADMIN_API_KEYS=new-key",
+			"LOG_LEVEL=debug",
+		))
+
+		assert.Equal(t, []string{"ADMIN_API_KEYS", "LOG_LEVEL", "TRUSTED_PROXIES", "TRUSTED_SUBNET"}, result.Applied)
+		assert.Empty(t, result.RestartRequired)
+
+		assert.True(t, settings.TrustedSubnets.Contains(net.ParseIP("10.1.2.3")))
+		assert.False(t, settings.TrustedSubnets.Contains(net.ParseIP("192.168.1.10")))
+		assert.True(t, settings.Resolver.IsTrustedProxy(net.ParseIP("172.16.0.1")))
+		assert.Equal(t, zapcore.DebugLevel, level.Level())
+
+		_, err := settings.AdminAPIKeys.Lookup(context.Background(), "old-key")
+		assert.Error(t, err)
+		_, err = settings.AdminAPIKeys.Lookup(context.Background(), "new-key")
+		assert.NoError(t, err)
+	})
+
+	t.Run("unchanged log level keeps runtime changes", func(t *testing.T) {
+		level.SetLevel(zapcore.WarnLevel)
+
+		result := settings.Apply(mustLoad(t,
+			"TRUSTED_SUBNET=10.0.0.0/8",
+			"TRUSTED_PROXIES=172.16.0.0/12",
+			"ADMIN_API_KEYS=new-key",
+			"LOG_LEVEL=debug",
+		))
+
+		assert.Empty(t, result.Applied)
+		assert.Equal(t, zapcore.WarnLevel, level.Level())
+	})
+
+	t.Run("settings requiring a restart", func(t *testing.T) {
+		result := settings.Apply(mustLoad(t,
+			"TRUSTED_SUBNET=10.0.0.0/8",
+			"SERVER_ADDRESS=:9090",
+			"DATABASE_DSN=postgres://app@db/app",
+		))
+
+		assert.Equal(t, []string{"ADMIN_API_KEYS", "LOG_LEVEL", "TRUSTED_PROXIES"}, result.Applied)
+		assert.Contains(t, result.RestartRequired, "SERVER_ADDRESS")
+		assert.Contains(t, result.RestartRequired, "DATABASE_DSN")
+		assert.NotContains(t, result.RestartRequired, "TRUSTED_SUBNET")
+		assert.False(t, settings.Resolver.IsTrustedProxy(net.ParseIP("172.16.0.1")))
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		result := settings.Apply(mustLoad(t,
+			"TRUSTED_SUBNET=10.0.0.0/8",
+			"RATE_LIMIT_RPS=5",
+			"RATE_LIMIT_BURST=1",
+		))
+
+		assert.Equal(t, []string{"RATE_LIMIT_BURST", "RATE_LIMIT_RPS"}, result.Applied)
+		assert.Empty(t, result.RestartRequired)
+		assert.Equal(t, ratelimit.Limit{Rate: 5, Burst: 1}, limiter.Limit())
+
+		allowed, _, err := limiter.Allow(context.Background(), "10.0.0.1")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		allowed, _, err = limiter.Allow(context.Background(), "10.0.0.1")
+		require.NoError(t, err)
+		assert.False(t, allowed, "the reloaded limit is applied")
+
+		result = settings.Apply(mustLoad(t, "TRUSTED_SUBNET=10.0.0.0/8"))
+		assert.Equal(t, []string{"RATE_LIMIT_BURST", "RATE_LIMIT_RPS"}, result.Applied)
+
+		allowed, _, err = limiter.Allow(context.Background(), "10.0.0.1")
+		require.NoError(t, err)
+		assert.True(t, allowed, "rate limiting is disabled by the reload")
+	})
+}
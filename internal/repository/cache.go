@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/cache"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/repository/database"
+)
+
+// cachedRepository is a Repository decorator caching the results of Get, which serves redirects. Lookups
+// of unknown short URLs are cached as well, and the cached results of short URLs are invalidated by every
+// call of the wrapped Repository changing them. Lookups of short URLs invalidated within the
+// read-your-writes window which miss the cache are read from the primary database, as a replica lagging
+// behind the change would have its stale result cached for the whole TTL, the other lookups are served by
+// replicas as any read. Results are cached by a cache.Store: with a store local to the process, changes
+// made by other instances sharing a database are only seen once the cached results expire, a shared store
+// sees the invalidations of every instance. Errors of the store are reported by the lookups metric and
+// otherwise ignored, lookups then reach the wrapped Repository.
+type cachedRepository struct {
+	Repository
+
+	store       cache.Store
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	// generation is incremented by every invalidation, so that a lookup racing with a change of
+	// the short URL it looked up does not cache its stale result.
+	mu         sync.Mutex
+	generation uint64
+
+	// invalidated holds the time of the latest invalidation of the short URLs invalidated within window,
+	// purgedAt the time of the latest invalidation of every short URL. They are guarded by mu.
+	window      time.Duration
+	invalidated map[string]time.Time
+	purgedAt    time.Time
+	lastPrune   time.Time
+
+	hits         atomic.Int64
+	negativeHits atomic.Int64
+	misses       atomic.Int64
+}
+
+// lookup is the cached result of Get. Unknown short URLs are cached as lookups with an empty URL.
+type lookup struct {
+	URL model.URL `json:"url"`
+}
+
+// newLookup returns the lookup caching the result of Get, and whether the result can be cached.
+func newLookup(url model.URL, err error) (lookup, bool) {
+	switch {
+	case errors.Is(err, shrterr.ErrShortURLNotFound):
+		return lookup{}, true
+	case err != nil:
+		return lookup{}, false
+	}
+	return lookup{URL: url}, true
+}
+
+// notFound reports whether the lookup found no short URL.
+func (l lookup) notFound() bool {
+	return l.URL.OriginalURL == ""
+}
+
+// result returns the cached result of Get.
+func (l lookup) result() (model.URL, error) {
+	if l.notFound() {
+		return model.URL{}, shrterr.ErrShortURLNotFound
+	}
+	return l.URL, nil
+}
+
+// WithCache returns a Repository caching the short URL lookups of r in store for the time set by cfg, and
+// reporting the statistics of the cache through GetInternalStats. Short URLs changed within readYourWrites
+// are looked up from the primary database. Code relying on the concrete type of a repository must keep
+// a reference to r itself.
+func WithCache(r Repository, cfg config.Cache, store cache.Store, readYourWrites time.Duration) Repository {
+	return &cachedRepository{
+		Repository:  r,
+		store:       store,
+		ttl:         cfg.TTL,
+		negativeTTL: cfg.NegativeTTL,
+		window:      readYourWrites,
+		invalidated: make(map[string]time.Time),
+	}
+}
+
+// Get returns the cached result of the lookup of shortURL, or looks it up in the wrapped Repository,
+// reading from the primary database when shortURL was recently invalidated, and caches the result
+// unless it is an error.
+func (c *cachedRepository) Get(ctx context.Context, shortURL string) (model.URL, error) {
+	if l, ok := c.cached(ctx, shortURL); ok {
+		c.hits.Add(1)
+		if l.notFound() {
+			c.negativeHits.Add(1)
+			metrics.CacheLookupsTotal.WithLabelValues(metrics.CacheNegativeHit).Inc()
+		} else {
+			metrics.CacheLookupsTotal.WithLabelValues(metrics.CacheHit).Inc()
+		}
+		return l.result()
+	}
+
+	c.misses.Add(1)
+	metrics.CacheLookupsTotal.WithLabelValues(metrics.CacheMiss).Inc()
+
+	c.mu.Lock()
+	generation := c.generation
+	recent := c.recentlyInvalidated(time.Now(), shortURL)
+	c.mu.Unlock()
+
+	readCtx := ctx
+	if recent {
+		readCtx = database.WithPrimaryReads(ctx)
+	}
+
+	url, err := c.Repository.Get(readCtx, shortURL)
+
+	if l, ok := newLookup(url, err); ok {
+		ttl := c.ttl
+		if l.notFound() {
+			ttl = c.negativeTTL
+		}
+		if ttl > 0 {
+			c.storeLookup(ctx, shortURL, l, ttl, generation)
+		}
+	}
+
+	return url, err
+}
+
+// cached returns the cached lookup of shortURL.
+func (c *cachedRepository) cached(ctx context.Context, shortURL string) (lookup, bool) {
+	value, ok, err := c.store.Get(ctx, shortURL)
+	if err != nil {
+		metrics.CacheLookupsTotal.WithLabelValues(metrics.CacheError).Inc()
+		return lookup{}, false
+	}
+	if !ok {
+		return lookup{}, false
+	}
+
+	var l lookup
+	if err := json.Unmarshal(value, &l); err != nil {
+		metrics.CacheLookupsTotal.WithLabelValues(metrics.CacheError).Inc()
+		return lookup{}, false
+	}
+	return l, true
+}
+
+// storeLookup caches l for shortURL unless the cache was invalidated since generation.
+func (c *cachedRepository) storeLookup(ctx context.Context, shortURL string, l lookup, ttl time.Duration, generation uint64) {
+	value, err := json.Marshal(l)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+	if err := c.store.Set(ctx, shortURL, value, ttl); err != nil {
+		metrics.CacheLookupsTotal.WithLabelValues(metrics.CacheError).Inc()
+	}
+}
+
+// invalidate removes the cached lookups of shortURLs, or every cached lookup when none is given.
+func (c *cachedRepository) invalidate(ctx context.Context, shortURLs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.recordInvalidation(time.Now(), shortURLs...)
+
+	var err error
+	if len(shortURLs) == 0 {
+		err = c.store.Purge(ctx)
+	} else {
+		err = c.store.Delete(ctx, shortURLs...)
+	}
+	if err != nil {
+		metrics.CacheLookupsTotal.WithLabelValues(metrics.CacheError).Inc()
+	}
+}
+
+// recordInvalidation records the invalidation of shortURLs, or of every short URL when none is given,
+// at now, forgetting the invalidations older than the window. It must be called with mu held.
+func (c *cachedRepository) recordInvalidation(now time.Time, shortURLs ...string) {
+	if now.Sub(c.lastPrune) > c.window {
+		for shortURL, t := range c.invalidated {
+			if now.Sub(t) > c.window {
+				delete(c.invalidated, shortURL)
+			}
+		}
+		c.lastPrune = now
+	}
+
+	if len(shortURLs) == 0 {
+		c.purgedAt = now
+		return
+	}
+	for _, shortURL := range shortURLs {
+		c.invalidated[shortURL] = now
+	}
+}
+
+// recentlyInvalidated reports whether shortURL was invalidated within the window before now. It must be
+// called with mu held.
+func (c *cachedRepository) recentlyInvalidated(now time.Time, shortURL string) bool {
+	if now.Sub(c.purgedAt) <= c.window {
+		return true
+	}
+	t, ok := c.invalidated[shortURL]
+	return ok && now.Sub(t) <= c.window
+}
+
+func (c *cachedRepository) Save(ctx context.Context, shortURLID string, originalURL string, userID string) error {
+	defer c.invalidate(ctx, shortURLID)
+	return c.Repository.Save(ctx, shortURLID, originalURL, userID)
+}
+
+func (c *cachedRepository) SaveBatch(ctx context.Context, urls []model.URLWithCorrelation, userID string) (bool, error) {
+	shortURLs := make([]string, len(urls))
+	for i, u := range urls {
+		shortURLs[i] = u.ShortURLID
+	}
+	defer c.invalidate(ctx, shortURLs...)
+	return c.Repository.SaveBatch(ctx, urls, userID)
+}
+
+func (c *cachedRepository) DeleteBatch(ctx context.Context, shortURLs model.BatchDeleteShortURLs) (int64, error) {
+	defer c.invalidate(ctx, shortURLs.ShortURLs...)
+	return c.Repository.DeleteBatch(ctx, shortURLs)
+}
+
+// ReassignURLs changes the owner of short URLs which are not known in advance, so every
+// cached lookup is invalidated.
+func (c *cachedRepository) ReassignURLs(ctx context.Context, fromUserID string, toUserID string) (int64, error) {
+	defer c.invalidate(ctx)
+	return c.Repository.ReassignURLs(ctx, fromUserID, toUserID)
+}
+
+func (c *cachedRepository) SetDisabled(ctx context.Context, shortURLs []string, disabled bool) (int64, error) {
+	defer c.invalidate(ctx, shortURLs...)
+	return c.Repository.SetDisabled(ctx, shortURLs, disabled)
+}
+
+func (c *cachedRepository) ForceDeleteBatch(ctx context.Context, shortURLs []string) (int64, error) {
+	defer c.invalidate(ctx, shortURLs...)
+	return c.Repository.ForceDeleteBatch(ctx, shortURLs)
+}
+
+func (c *cachedRepository) TransferURLs(ctx context.Context, shortURLs []string, toUserID string) (int64, error) {
+	defer c.invalidate(ctx, shortURLs...)
+	return c.Repository.TransferURLs(ctx, shortURLs, toUserID)
+}
+
+// GetInternalStats returns the statistics of the wrapped Repository along with those of the cache.
+func (c *cachedRepository) GetInternalStats(ctx context.Context) (*dto.InternalStatsResp, error) {
+	stats, err := c.Repository.GetInternalStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, misses := c.hits.Load(), c.misses.Load()
+	cacheStats := &dto.CacheStats{
+		Hits:         hits,
+		NegativeHits: c.negativeHits.Load(),
+		Misses:       misses,
+	}
+	if counter, ok := c.store.(cache.Counter); ok {
+		if entries, err := counter.Len(ctx); err == nil {
+			cacheStats.Entries = &entries
+		}
+	}
+	if hits+misses > 0 {
+		cacheStats.HitRatio = float64(hits) / float64(hits+misses)
+	}
+
+	withCache := *stats
+	withCache.Cache = cacheStats
+	return &withCache, nil
+}
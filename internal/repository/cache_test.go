@@ -0,0 +1,226 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/cache"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/mocks"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/redisstore"
+	"github.com/mp1947/ya-url-shortener/internal/repository"
+	"github.com/mp1947/ya-url-shortener/internal/repository/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var cacheConfig = config.Cache{
+	Size:        10,
+	Policy:      "lru",
+	TTL:         time.Minute,
+	NegativeTTL: time.Minute,
+}
+
+// readYourWrites is the read-your-writes window of the cached repositories.
+const readYourWrites = time.Minute
+
+// newCachedRepository returns r caching its lookups in the process.
+func newCachedRepository(t *testing.T, r repository.Repository) repository.Repository {
+	t.Helper()
+
+	store, err := cache.NewLocal(cacheConfig.Size, cacheConfig.Policy)
+	require.NoError(t, err)
+
+	return repository.WithCache(r, cacheConfig, store, readYourWrites)
+}
+
+func TestWithCache(t *testing.T) {
+	ctx := context.Background()
+	url := model.URL{ShortURLID: "aaa", OriginalURL: "https://a.example.com", UserID: "user-1"}
+
+	t.Run("lookups are cached and invalidated", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := mocks.NewMockRepository(ctrl)
+
+		repo := newCachedRepository(t, m)
+
+		m.EXPECT().Get(gomock.Any(), "aaa").Return(url, nil).Times(2)
+		m.EXPECT().DeleteBatch(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+		for range 3 {
+			got, err := repo.Get(ctx, "aaa")
+			require.NoError(t, err)
+			assert.Equal(t, url, got)
+		}
+
+		_, err := repo.DeleteBatch(ctx, model.BatchDeleteShortURLs{ShortURLs: []string{"aaa"}})
+		require.NoError(t, err)
+
+		_, err = repo.Get(ctx, "aaa")
+		require.NoError(t, err)
+	})
+
+	t.Run("replicas share lookups and invalidations through redis", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m1 := mocks.NewMockRepository(ctrl)
+		m2 := mocks.NewMockRepository(ctrl)
+
+		mr := miniredis.RunT(t)
+		client, err := redisstore.Open(ctx, "redis://"+mr.Addr(), "test:")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+
+		replica1 := repository.WithCache(m1, cacheConfig, client.Cache(), readYourWrites)
+		replica2 := repository.WithCache(m2, cacheConfig, client.Cache(), readYourWrites)
+
+		m1.EXPECT().Get(gomock.Any(), "aaa").Return(url, nil).Times(2)
+		m2.EXPECT().DeleteBatch(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+		_, err = replica1.Get(ctx, "aaa")
+		require.NoError(t, err)
+
+		got, err := replica2.Get(ctx, "aaa")
+		require.NoError(t, err)
+		assert.Equal(t, url, got)
+
+		_, err = replica2.DeleteBatch(ctx, model.BatchDeleteShortURLs{ShortURLs: []string{"aaa"}})
+		require.NoError(t, err)
+
+		_, err = replica1.Get(ctx, "aaa")
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown short urls are cached until saved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := mocks.NewMockRepository(ctrl)
+
+		repo := newCachedRepository(t, m)
+
+		gomock.InOrder(
+			m.EXPECT().Get(gomock.Any(), "aaa").Return(model.URL{}, shrterr.ErrShortURLNotFound),
+			m.EXPECT().Save(gomock.Any(), "aaa", url.OriginalURL, url.UserID).Return(nil),
+			m.EXPECT().Get(gomock.Any(), "aaa").Return(url, nil),
+		)
+
+		for range 2 {
+			_, err := repo.Get(ctx, "aaa")
+			assert.ErrorIs(t, err, shrterr.ErrShortURLNotFound)
+		}
+
+		require.NoError(t, repo.Save(ctx, "aaa", url.OriginalURL, url.UserID))
+
+		got, err := repo.Get(ctx, "aaa")
+		require.NoError(t, err)
+		assert.Equal(t, url, got)
+	})
+
+	t.Run("misses of recently changed short urls are read from the primary", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := mocks.NewMockRepository(ctrl)
+
+		repo := newCachedRepository(t, m)
+
+		primary := gomock.Cond(func(ctx context.Context) bool { return database.PrimaryReadsRequested(ctx) })
+		replica := gomock.Cond(func(ctx context.Context) bool { return !database.PrimaryReadsRequested(ctx) })
+
+		gomock.InOrder(
+			m.EXPECT().Get(replica, "aaa").Return(model.URL{}, shrterr.ErrShortURLNotFound),
+			m.EXPECT().Save(gomock.Any(), "aaa", url.OriginalURL, url.UserID).Return(nil),
+			m.EXPECT().Get(primary, "aaa").Return(url, nil),
+			m.EXPECT().Get(replica, "bbb").Return(model.URL{}, shrterr.ErrShortURLNotFound),
+			m.EXPECT().ReassignURLs(gomock.Any(), "user-1", "user-2").Return(int64(1), nil),
+			m.EXPECT().Get(primary, "ccc").Return(model.URL{}, shrterr.ErrShortURLNotFound),
+		)
+
+		_, err := repo.Get(ctx, "aaa")
+		assert.ErrorIs(t, err, shrterr.ErrShortURLNotFound)
+
+		require.NoError(t, repo.Save(ctx, "aaa", url.OriginalURL, url.UserID))
+
+		_, err = repo.Get(ctx, "aaa")
+		require.NoError(t, err)
+
+		_, err = repo.Get(ctx, "bbb")
+		assert.ErrorIs(t, err, shrterr.ErrShortURLNotFound)
+
+		_, err = repo.ReassignURLs(ctx, "user-1", "user-2")
+		require.NoError(t, err)
+
+		_, err = repo.Get(ctx, "ccc")
+		assert.ErrorIs(t, err, shrterr.ErrShortURLNotFound)
+	})
+
+	t.Run("misses are read from replicas after the read-your-writes window", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := mocks.NewMockRepository(ctrl)
+
+		store, err := cache.NewLocal(cacheConfig.Size, cacheConfig.Policy)
+		require.NoError(t, err)
+		repo := repository.WithCache(m, cacheConfig, store, time.Millisecond)
+
+		replica := gomock.Cond(func(ctx context.Context) bool { return !database.PrimaryReadsRequested(ctx) })
+		m.EXPECT().Save(gomock.Any(), "aaa", url.OriginalURL, url.UserID).Return(nil)
+		m.EXPECT().Get(replica, "aaa").Return(url, nil)
+
+		require.NoError(t, repo.Save(ctx, "aaa", url.OriginalURL, url.UserID))
+		time.Sleep(5 * time.Millisecond)
+
+		_, err = repo.Get(ctx, "aaa")
+		require.NoError(t, err)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := mocks.NewMockRepository(ctrl)
+
+		repo := newCachedRepository(t, m)
+
+		m.EXPECT().Get(gomock.Any(), "aaa").Return(model.URL{}, context.DeadlineExceeded).Times(2)
+
+		for range 2 {
+			_, err := repo.Get(ctx, "aaa")
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("hit ratio is reported", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := mocks.NewMockRepository(ctrl)
+
+		repo := newCachedRepository(t, m)
+
+		m.EXPECT().Get(gomock.Any(), "aaa").Return(url, nil)
+		m.EXPECT().Get(gomock.Any(), "bbb").Return(model.URL{}, shrterr.ErrShortURLNotFound)
+		m.EXPECT().GetInternalStats(gomock.Any()).Return(&dto.InternalStatsResp{URLs: 1, Users: 1}, nil)
+
+		for _, shortURL := range []string{"aaa", "aaa", "aaa", "bbb", "bbb"} {
+			_, err := repo.Get(ctx, shortURL)
+			if shortURL == "bbb" {
+				require.ErrorIs(t, err, shrterr.ErrShortURLNotFound)
+			} else {
+				require.NoError(t, err)
+			}
+		}
+
+		stats, err := repo.GetInternalStats(ctx)
+		require.NoError(t, err)
+		entries := 2
+		assert.Equal(t, &dto.InternalStatsResp{
+			URLs:  1,
+			Users: 1,
+			Cache: &dto.CacheStats{
+				Hits:         3,
+				NegativeHits: 1,
+				Misses:       2,
+				Entries:      &entries,
+				HitRatio:     0.6,
+			},
+		}, stats)
+	})
+}
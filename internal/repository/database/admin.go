@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SetDisabled sets the disabled flag of the given short URLs regardless of their owner, deleted ones being skipped.
+// It returns the number of updated URLs or an error if the update fails.
+func (d *Database) SetDisabled(
+	ctx context.Context,
+	shortURLs []string,
+	disabled bool,
+) (int64, error) {
+	args := pgx.NamedArgs{
+		"shortURLs": shortURLs,
+		"disabled":  disabled,
+	}
+
+	ct, err := d.conn.Exec(ctx, setDisabledQuery, args)
+	if err != nil {
+		return 0, err
+	}
+
+	d.recordWrite(ctx)
+	return ct.RowsAffected(), nil
+}
+
+// ForceDeleteBatch marks the given short URLs as deleted regardless of their owner, already deleted ones being skipped.
+// It returns the number of deleted URLs or an error if the update fails.
+func (d *Database) ForceDeleteBatch(
+	ctx context.Context,
+	shortURLs []string,
+) (int64, error) {
+	args := pgx.NamedArgs{
+		"shortURLs": shortURLs,
+	}
+
+	ct, err := d.conn.Exec(ctx, forceDeleteQuery, args)
+	if err != nil {
+		return 0, err
+	}
+
+	d.recordWrite(ctx)
+	return ct.RowsAffected(), nil
+}
+
+// TransferURLs assigns the given short URLs to toUserID regardless of their current owner, deleted ones being skipped.
+// It returns the number of transferred URLs or an error if the update fails.
+func (d *Database) TransferURLs(
+	ctx context.Context,
+	shortURLs []string,
+	toUserID string,
+) (int64, error) {
+	args := pgx.NamedArgs{
+		"shortURLs": shortURLs,
+		"toUserID":  toUserID,
+	}
+
+	ct, err := d.conn.Exec(ctx, transferURLsQuery, args)
+	if err != nil {
+		return 0, err
+	}
+
+	d.recordWrite(ctx, toUserID)
+	return ct.RowsAffected(), nil
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mp1947/ya-url-shortener/internal/audit"
+)
+
+// AuditStore is an audit.Store backed by the audit_log table.
+type AuditStore struct {
+	conn *pgxpool.Pool
+}
+
+// AuditStore returns an audit.Store sharing the connection pool of the Database.
+func (d *Database) AuditStore() *AuditStore {
+	return &AuditStore{conn: d.conn}
+}
+
+// Record inserts the entry into the audit_log table.
+func (s *AuditStore) Record(ctx context.Context, e audit.Entry) error {
+	shortURLs := e.ShortURLs
+	if shortURLs == nil {
+		shortURLs = []string{}
+	}
+
+	args := pgx.NamedArgs{
+		"time":         e.Time,
+		"actorID":      e.ActorID,
+		"authMethod":   e.AuthMethod,
+		"clientIP":     e.ClientIP,
+		"operation":    e.Operation,
+		"shortURLs":    shortURLs,
+		"targetUserID": e.TargetUser,
+		"result":       e.Result,
+		"error":        e.Error,
+	}
+
+	_, err := s.conn.Exec(ctx, insertAuditEntryQuery, args)
+	return err
+}
+
+// Query returns the newest entries of the audit_log table matching the filter.
+func (s *AuditStore) Query(ctx context.Context, f audit.Filter) ([]audit.Entry, error) {
+	args := pgx.NamedArgs{
+		"userID":   f.UserID,
+		"shortURL": f.ShortURL,
+		"from":     nullableTime(f.From),
+		"to":       nullableTime(f.To),
+		"limit":    f.EffectiveLimit(),
+	}
+
+	rows, err := s.conn.Query(ctx, queryAuditEntriesQuery, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []audit.Entry{}
+
+	for rows.Next() {
+		var e audit.Entry
+		if err := rows.Scan(
+			&e.Time,
+			&e.ActorID,
+			&e.AuthMethod,
+			&e.ClientIP,
+			&e.Operation,
+			&e.ShortURLs,
+			&e.TargetUser,
+			&e.Result,
+			&e.Error,
+		); err != nil {
+			return nil, err
+		}
+		e.Time = e.Time.UTC()
+		result = append(result, e)
+	}
+
+	return result, rows.Err()
+}
+
+// nullableTime converts a zero time to nil so that it is passed to the database as NULL.
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+)
+
+// LookupURLs returns the stored URLs, deleted ones included, whose short URL is one of shortURLs
+// or whose original URL is one of originalURLs.
+func (d *Database) LookupURLs(ctx context.Context, shortURLs, originalURLs []string) ([]model.URL, error) {
+	args := pgx.NamedArgs{
+		"shortURLs":    shortURLs,
+		"originalURLs": originalURLs,
+	}
+
+	rows, err := d.conn.Query(ctx, lookupURLsQuery, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.URL
+
+	for rows.Next() {
+		var u model.URL
+		if err := rows.Scan(&u.ShortURLID, &u.OriginalURL, &u.UserID, &u.IsDeleted, &u.IsDisabled); err != nil {
+			return nil, err
+		}
+		result = append(result, u)
+	}
+
+	return result, rows.Err()
+}
+
+// InsertURLs stores urls along with their owner, deletion and disabled state with a single statement,
+// so either all of them or none are inserted. URLs whose short or original URL is already stored, or
+// used by a previous URL of urls, are skipped, which makes inserting the same URLs again a no-op.
+// Returns the number of inserted URLs.
+func (d *Database) InsertURLs(ctx context.Context, urls []model.URL) (int64, error) {
+	n := len(urls)
+	shortURLs, originalURLs, userIDs := make([]string, n), make([]string, n), make([]string, n)
+	deleted, disabled := make([]bool, n), make([]bool, n)
+
+	for i, u := range urls {
+		shortURLs[i] = u.ShortURLID
+		originalURLs[i] = u.OriginalURL
+		userIDs[i] = u.UserID
+		deleted[i] = u.IsDeleted
+		disabled[i] = u.IsDisabled
+	}
+
+	tag, err := d.conn.Exec(ctx, insertURLsQuery, pgx.NamedArgs{
+		"shortURLs":    shortURLs,
+		"originalURLs": originalURLs,
+		"userIDs":      userIDs,
+		"deleted":      deleted,
+		"disabled":     disabled,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -3,17 +3,28 @@ package database
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"go.uber.org/zap"
 )
 
 // Database represents a storage layer backed by a PostgreSQL connection pool.
-// It holds the database connection pool, configuration settings, and the type of storage used.
+// It holds the connection pool of the primary database, those of its optional read replicas, configuration
+// settings, the logger it was initialized with and the type of storage used.
+// Writes go to the primary, redirect lookups, user listings and statistics are read from the available
+// replicas in turn, see read.
 type Database struct {
 	conn        *pgxpool.Pool
 	cfg         config.Config
+	l           *zap.Logger
 	StorageType string
+
+	replicas    []*replica
+	nextReplica atomic.Uint64
+	writes      *writeTracker
 }
 
 // GetType returns the storage type used by the Database as a string.
@@ -21,14 +32,33 @@ func (d *Database) GetType() string {
 	return d.StorageType
 }
 
-// Close closes the database connection held by the Database instance.
+// log returns the request-scoped logger of ctx, or the logger of the Database when ctx has none.
+func (d *Database) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, d.l)
+}
+
+// Close closes the database connections held by the Database instance, those of the replicas included.
 // It should be called when the Database is no longer needed to release resources.
 func (d *Database) Close() {
 	d.conn.Close()
+	d.closeReplicas()
 }
 
-// Ping checks the connection to the database by sending a ping request.
+// closeReplicas closes the connections to the replicas and forgets them.
+func (d *Database) closeReplicas() {
+	for _, r := range d.replicas {
+		r.conn.Close()
+	}
+	d.replicas = nil
+}
+
+// Ping checks the connection to the primary database by sending a ping request.
 // It returns an error if the database is unreachable or the ping fails.
 func (d *Database) Ping(ctx context.Context) error {
 	return d.conn.Ping(ctx)
 }
+
+// Stat returns the statistics of the connection pool of the Database.
+func (d *Database) Stat() *pgxpool.Stat {
+	return d.conn.Stat()
+}
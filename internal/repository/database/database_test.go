@@ -0,0 +1,89 @@
+package database_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/repository"
+	"github.com/mp1947/ya-url-shortener/internal/repository/database"
+	"github.com/mp1947/ya-url-shortener/internal/repository/repositorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// dsnEnv is the environment variable holding the DSN of the PostgreSQL database the tests run
+// against. They are skipped when it is not set, every table of the database is emptied.
+const dsnEnv = "TEST_DATABASE_DSN"
+
+func TestConformance(t *testing.T) {
+	dsn := testDSN(t)
+
+	repositorytest.Run(t, func(t *testing.T) repository.Repository {
+		return openDatabase(t, dsn)
+	})
+}
+
+func TestInsertURLs(t *testing.T) {
+	ctx := context.Background()
+	db := openDatabase(t, testDSN(t))
+
+	inserted, err := db.InsertURLs(ctx, []model.URL{
+		{ShortURLID: "aaa", OriginalURL: "https://a.example.com"},
+		{ShortURLID: "aaa", OriginalURL: "https://b.example.com"},
+		{ShortURLID: "ccc", OriginalURL: "https://a.example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), inserted)
+
+	inserted, err = db.InsertURLs(ctx, []model.URL{
+		{ShortURLID: "aaa", OriginalURL: "https://c.example.com"},
+		{ShortURLID: "ddd", OriginalURL: "https://d.example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), inserted)
+
+	links, err := db.LookupURLs(ctx, []string{"aaa", "ccc", "ddd"}, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []model.URL{
+		{ShortURLID: "aaa", OriginalURL: "https://a.example.com"},
+		{ShortURLID: "ddd", OriginalURL: "https://d.example.com"},
+	}, links)
+}
+
+// testDSN returns the DSN of the test database, skipping the test when it is not set.
+func testDSN(t *testing.T) string {
+	t.Helper()
+
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", dsnEnv)
+	}
+	return dsn
+}
+
+// openDatabase returns the database at dsn, migrated and emptied.
+func openDatabase(t *testing.T, dsn string) *database.Database {
+	t.Helper()
+	ctx := context.Background()
+
+	autoMigrate := true
+	db := &database.Database{}
+	require.NoError(t, db.Init(ctx, config.Config{DatabaseDSN: &dsn, AutoMigrate: &autoMigrate}, zap.NewNop()))
+	t.Cleanup(db.Close)
+
+	conn, err := pgx.Connect(ctx, dsn)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	_, err = conn.Exec(ctx, "TRUNCATE urls, audit_log RESTART IDENTITY")
+	require.NoError(t, err)
+
+	return db
+}
@@ -4,20 +4,21 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/mp1947/ya-url-shortener/internal/model"
+	"go.uber.org/zap"
 )
 
-// DeleteBatch deletes a batch of short URLs associated with a specific user from the database.
+// DeleteBatch marks a batch of short URLs associated with a specific user as deleted in the database.
 // It starts a transaction and iterates over the provided short URLs, executing a delete query for each.
+// URLs of other users, unknown and already deleted ones are left untouched.
 // If any deletion fails, the transaction is rolled back and the error is returned.
-// On success, the transaction is committed and the number of rows affected by the last delete operation is returned.
+// On success, the transaction is committed and the number of deleted URLs is returned.
 // Parameters:
 //   - ctx: context for controlling cancellation and deadlines.
 //   - shortURLs: a BatchDeleteShortURLs struct containing the user ID and a slice of short URLs to delete.
 //
 // Returns:
-//   - int64: the number of rows affected by the last delete operation.
+//   - int64: the number of deleted URLs.
 //   - error: an error if the operation fails, otherwise nil.
 func (d *Database) DeleteBatch(
 	ctx context.Context,
@@ -29,20 +30,22 @@ func (d *Database) DeleteBatch(
 		return 0, err
 	}
 
-	var ct pgconn.CommandTag
+	var deleted int64
 
 	for _, v := range shortURLs.ShortURLs {
 		args := pgx.NamedArgs{
 			"shortURL": v,
 			"userID":   shortURLs.UserID,
 		}
-		ct, err = tx.Exec(ctx, deleteURLQuery, args)
+		ct, err := tx.Exec(ctx, deleteURLQuery, args)
 		if err != nil {
 			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				d.log(ctx).Warn("error rolling back batch deletion", zap.NamedError("cause", err), zap.Error(rbErr))
 				return 0, rbErr
 			}
 			return 0, err
 		}
+		deleted += ct.RowsAffected()
 	}
 
 	err = tx.Commit(ctx)
@@ -51,5 +54,8 @@ func (d *Database) DeleteBatch(
 		return 0, err
 	}
 
-	return ct.RowsAffected(), nil
+	d.recordWrite(ctx, shortURLs.UserID)
+	d.log(ctx).Debug("batch deletion committed", zap.Int("short_urls", len(shortURLs.ShortURLs)))
+
+	return deleted, nil
 }
@@ -2,42 +2,72 @@ package database
 
 import (
 	"context"
+	"errors"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
 	"github.com/mp1947/ya-url-shortener/internal/model"
 )
 
-// Get retrieves the original URL and deletion status associated with the given short URL identifier from the database.
-// It returns a model.URL containing the short URL ID, the original URL, and a deletion flag.
-// If the short URL is not found or a database error occurs, an error is returned.
+// Get retrieves the original URL, owner, deletion and disabled status associated with the given short URL identifier from the database.
+// It returns a model.URL containing the short URL ID, the original URL, the owner user ID, and the deletion and disabled flags.
+// If the short URL is not found, shrterr.ErrShortURLNotFound is returned, and if a database error occurs, that error.
+// The lookup is served by a replica unless the actor of ctx wrote recently or ctx requests primary reads.
 func (d *Database) Get(ctx context.Context, shortURL string) (model.URL, error) {
 	args := pgx.NamedArgs{
 		"shortURL": shortURL,
 	}
-	row := d.conn.QueryRow(ctx, getOriginalURLByShortIDQuery, args)
-	var originalURLFromDB string
-	var isDeleted bool
-	err := row.Scan(&originalURLFromDB, &isDeleted)
+	var actorID string
+	if actor, ok := auth.ActorFromContext(ctx); ok {
+		actorID = actor.ID
+	}
+	var originalURLFromDB, userID string
+	var isDeleted, isDisabled bool
+	err := d.read(ctx, actorID, func(q querier) error {
+		return q.QueryRow(ctx, getOriginalURLByShortIDQuery, args).Scan(&originalURLFromDB, &isDeleted, &isDisabled, &userID)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.URL{}, shrterr.ErrShortURLNotFound
+	}
 	if err != nil {
 		return model.URL{}, err
 	}
 	return model.URL{
 		ShortURLID:  shortURL,
 		OriginalURL: originalURLFromDB,
+		UserID:      userID,
 		IsDeleted:   isDeleted,
+		IsDisabled:  isDisabled,
 	}, nil
 }
 
-// GetURLsByUserID retrieves all URLs associated with the specified user ID from the database.
-// It returns a slice of model.UserURL containing the original and shortened URLs for the user.
+// GetURLsByUserID retrieves all URLs associated with the specified user ID from the database, deleted ones included,
+// in the order they were saved. It returns a slice of model.UserURL containing the original and shortened URLs for the user.
 // If an error occurs during the query or scanning process, it returns the error.
+// The listing is served by a replica unless the user wrote recently.
 func (d *Database) GetURLsByUserID(ctx context.Context, userID string) ([]model.UserURL, error) {
+	var userURLs []model.UserURL
 
+	err := d.read(ctx, userID, func(q querier) error {
+		var err error
+		userURLs, err = d.getURLsByUserID(ctx, q, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return userURLs, nil
+}
+
+// getURLsByUserID lists the URLs of userID with q.
+func (d *Database) getURLsByUserID(ctx context.Context, q querier, userID string) ([]model.UserURL, error) {
 	args := pgx.NamedArgs{
 		"userID": userID,
 	}
 
-	rows, err := d.conn.Query(ctx, getURLsByUserID, args)
+	rows, err := q.Query(ctx, getURLsByUserID, args)
 	if err != nil {
 		return nil, err
 	}
@@ -47,8 +77,9 @@ func (d *Database) GetURLsByUserID(ctx context.Context, userID string) ([]model.
 
 	for rows.Next() {
 		var originalURL, shortURL string
+		var isDeleted, isDisabled bool
 
-		err := rows.Scan(&originalURL, &shortURL)
+		err := rows.Scan(&originalURL, &shortURL, &isDeleted, &isDisabled)
 
 		if err != nil {
 			return nil, err
@@ -56,6 +87,8 @@ func (d *Database) GetURLsByUserID(ctx context.Context, userID string) ([]model.
 		UserURL = append(UserURL, model.UserURL{
 			ShortURLID:  shortURL,
 			OriginalURL: originalURL,
+			IsDeleted:   isDeleted,
+			IsDisabled:  isDisabled,
 		})
 	}
 
@@ -65,3 +98,53 @@ func (d *Database) GetURLsByUserID(ctx context.Context, userID string) ([]model.
 
 	return UserURL, nil
 }
+
+// ListUserURLs calls fn for every URL of userID, deleted and disabled ones included, in the order they were
+// saved, as the rows are read. It stops and returns the error of fn or of the query. The URLs are read
+// from the primary, since a listing failing halfway on a replica could not be read again from the primary
+// without passing the same URLs to fn twice.
+func (d *Database) ListUserURLs(ctx context.Context, userID string, fn func(model.UserURL) error) error {
+	rows, err := d.conn.Query(ctx, getURLsByUserID, pgx.NamedArgs{"userID": userID})
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u model.UserURL
+
+		if err := rows.Scan(&u.OriginalURL, &u.ShortURLID, &u.IsDeleted, &u.IsDisabled); err != nil {
+			return err
+		}
+
+		if err := fn(u); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// ListURLs calls fn for every URL stored in the database, deleted and disabled ones included,
+// in the order they were saved. It stops and returns the error of fn or of the query.
+func (d *Database) ListURLs(ctx context.Context, fn func(model.URL) error) error {
+	rows, err := d.conn.Query(ctx, listURLsQuery)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u model.URL
+
+		if err := rows.Scan(&u.ShortURLID, &u.OriginalURL, &u.UserID, &u.IsDeleted, &u.IsDisabled); err != nil {
+			return err
+		}
+
+		if err := fn(u); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,27 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/pressly/goose/v3"
+)
+
+// CheckMigrations reports whether every embedded migration has been applied to the database.
+// It returns an error wrapping shrterr.ErrMigrationsPending if the schema version is older
+// than the latest migration, or the error encountered while reading the versions.
+func (d *Database) CheckMigrations(ctx context.Context) error {
+	return d.withMigrations(func(p *goose.Provider) error {
+		current, latest, err := p.GetVersions(ctx)
+		if err != nil {
+			return err
+		}
+
+		if current < latest {
+			return fmt.Errorf("%w: version %d, latest %d", shrterr.ErrMigrationsPending, current, latest)
+		}
+
+		return nil
+	})
+}
@@ -2,19 +2,20 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/jackc/pgx/v5/stdlib"
-	embed "github.com/mp1947/ya-url-shortener"
 	"github.com/mp1947/ya-url-shortener/config"
-	"github.com/pressly/goose/v3"
+	"github.com/mp1947/ya-url-shortener/internal/tracing"
 	"go.uber.org/zap"
 )
 
 // Init initializes the Database connection using the provided configuration and logger.
-// It parses the database DSN, establishes a new connection pool, pings the database to ensure connectivity,
-// and applies any pending migrations using Goose. If a previous connection exists, it is closed before
-// establishing a new one. The function sets the storage type to "database" upon successful initialization.
+// It parses the database DSN, establishes a new connection pool tracing every query, pings the database to ensure connectivity,
+// and, only when DATABASE_AUTO_MIGRATE enables it, applies any pending migrations as MigrateUp does:
+// migrations are otherwise applied on demand with shortenerctl migrate. A connection pool is then created for every replica of DATABASE_REPLICA_DSNS, replicas
+// which do not answer are only read from once they do. If previous connections exist, they are closed before
+// establishing new ones. The function sets the storage type to "database" upon successful initialization.
 // Returns an error if any step fails.
 func (d *Database) Init(
 	ctx context.Context,
@@ -23,15 +24,19 @@ func (d *Database) Init(
 ) error {
 	var err error
 	d.cfg = cfg
+	d.l = l
 	pgConfig, err := pgxpool.ParseConfig(*d.cfg.DatabaseDSN)
 
 	if err != nil {
 		return err
 	}
 
+	pgConfig.ConnConfig.Tracer = tracing.NewQueryTracer()
+
 	if d.conn != nil {
 		d.conn.Close()
 	}
+	d.closeReplicas()
 
 	d.conn, err = pgxpool.NewWithConfig(ctx, pgConfig)
 	if err != nil {
@@ -42,23 +47,28 @@ func (d *Database) Init(
 		return err
 	}
 
-	goose.SetBaseFS(embed.EmbedMigrations)
-	goose.SetLogger(goose.NopLogger())
+	if d.cfg.AutoMigrate != nil && *d.cfg.AutoMigrate {
+		applied, err := d.MigrateUp(ctx)
+		if err != nil {
+			return err
+		}
+		l.Info("database migrations have been applied", zap.Int64s("versions", applied))
+	}
 
-	if err := goose.SetDialect("postgres"); err != nil {
-		return err
+	var window time.Duration
+	if d.cfg.ReadYourWrites != nil {
+		window = *d.cfg.ReadYourWrites
 	}
+	d.writes = newWriteTracker(window)
 
-	db := stdlib.OpenDBFromPool(d.conn)
-	defer func() {
-		err := db.Close()
+	for _, dsn := range d.cfg.ReplicaDSNs {
+		r, err := openReplica(ctx, dsn, l)
 		if err != nil {
-			l.Error("error closing database connection", zap.Error(err))
+			d.closeReplicas()
+			return err
 		}
-	}()
-
-	if err := goose.Up(db, "migrations"); err != nil {
-		return err
+		d.replicas = append(d.replicas, r)
+		l.Info("reading from database replica", zap.String("replica", r.name))
 	}
 
 	d.StorageType = "database"
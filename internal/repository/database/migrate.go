@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	embed "github.com/mp1947/ya-url-shortener"
+	"github.com/pressly/goose/v3"
+	"go.uber.org/zap"
+)
+
+// migrationsDir is the directory of the embedded migrations.
+const migrationsDir = "migrations"
+
+// MigrationStatus describes an embedded migration and whether it is applied to the database.
+type MigrationStatus struct {
+	Version int64
+	Source  string
+	Applied bool
+}
+
+// MigrateUp applies every pending migration and returns the versions it applied.
+func (d *Database) MigrateUp(ctx context.Context) ([]int64, error) {
+	var applied []int64
+
+	err := d.withMigrations(func(p *goose.Provider) error {
+		results, err := p.Up(ctx)
+		for _, r := range results {
+			if r.Error == nil {
+				applied = append(applied, r.Source.Version)
+			}
+		}
+		return err
+	})
+
+	return applied, err
+}
+
+// MigrateDown rolls back the latest applied migration and returns its version.
+// It returns goose.ErrNoNextVersion when no migration is applied.
+func (d *Database) MigrateDown(ctx context.Context) (int64, error) {
+	var version int64
+
+	err := d.withMigrations(func(p *goose.Provider) error {
+		result, err := p.Down(ctx)
+		if result != nil {
+			version = result.Source.Version
+		}
+		return err
+	})
+
+	return version, err
+}
+
+// MigrationStatus returns the status of every embedded migration, ordered by version.
+func (d *Database) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+
+	err := d.withMigrations(func(p *goose.Provider) error {
+		results, err := p.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, r := range results {
+			statuses = append(statuses, MigrationStatus{
+				Version: r.Source.Version,
+				Source:  r.Source.Path,
+				Applied: r.State == goose.StateApplied,
+			})
+		}
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withMigrations calls fn with a goose provider of the embedded migrations running on the
+// connection pool of the Database.
+func (d *Database) withMigrations(fn func(p *goose.Provider) error) error {
+	migrations, err := fs.Sub(embed.EmbedMigrations, migrationsDir)
+	if err != nil {
+		return err
+	}
+
+	db := stdlib.OpenDBFromPool(d.conn)
+	defer func() {
+		if err := db.Close(); err != nil {
+			d.l.Error("error closing database connection", zap.Error(err))
+		}
+	}()
+
+	p, err := goose.NewProvider(goose.DialectPostgres, db, migrations)
+	if err != nil {
+		return err
+	}
+
+	return fn(p)
+}
@@ -5,8 +5,44 @@ const (
 	INSERT INTO urls (short_url, original_url, user_uuid) 
 	VALUES (@shortURL, @originalURL, @userID)
 	`
-	getOriginalURLByShortIDQuery = `SELECT original_url, is_deleted FROM urls where short_url = @shortURL`
-	getURLsByUserID              = `SELECT original_url, short_url FROM urls where user_uuid = @userID`
-	deleteURLQuery               = `UPDATE urls SET is_deleted = true WHERE short_url = @shortURL AND user_uuid = @userID`
-	getInternalStatsQuery        = `SELECT count(*), count(distinct user_uuid) from urls`
+	getOriginalURLByShortIDQuery = `
+	SELECT original_url, is_deleted, is_disabled, COALESCE(user_uuid::text, '')
+	FROM urls where short_url = @shortURL
+	`
+	getURLsByUserID       = `SELECT original_url, short_url, is_deleted, is_disabled FROM urls where user_uuid = @userID ORDER BY uuid`
+	deleteURLQuery        = `UPDATE urls SET is_deleted = true WHERE short_url = @shortURL AND user_uuid = @userID AND NOT is_deleted`
+	listURLsQuery         = `SELECT short_url, original_url, COALESCE(user_uuid::text, ''), is_deleted, is_disabled FROM urls ORDER BY uuid`
+	getInternalStatsQuery = `SELECT count(*), count(distinct user_uuid) from urls`
+	reassignURLsQuery     = `UPDATE urls SET user_uuid = @toUserID WHERE user_uuid = @fromUserID`
+	setDisabledQuery      = `UPDATE urls SET is_disabled = @disabled WHERE short_url = ANY(@shortURLs) AND NOT is_deleted`
+	forceDeleteQuery      = `UPDATE urls SET is_deleted = true WHERE short_url = ANY(@shortURLs) AND NOT is_deleted`
+	transferURLsQuery     = `UPDATE urls SET user_uuid = @toUserID WHERE short_url = ANY(@shortURLs) AND NOT is_deleted`
+	insertAuditEntryQuery = `
+	INSERT INTO audit_log (
+		created_at, actor_id, auth_method, client_ip, operation, short_urls, target_user_id, result, error
+	)
+	VALUES (@time, @actorID, @authMethod, @clientIP, @operation, @shortURLs, @targetUserID, @result, @error)
+	`
+	queryAuditEntriesQuery = `
+	SELECT created_at, actor_id, auth_method, client_ip, operation, short_urls, target_user_id, result, error
+	FROM audit_log
+	WHERE (@userID = '' OR actor_id = @userID OR target_user_id = @userID)
+	AND (@shortURL = '' OR @shortURL = ANY(short_urls))
+	AND (@from::timestamptz IS NULL OR created_at >= @from)
+	AND (@to::timestamptz IS NULL OR created_at < @to)
+	ORDER BY created_at DESC, id DESC
+	LIMIT @limit
+	`
+	lookupURLsQuery = `
+	SELECT short_url, original_url, COALESCE(user_uuid::text, ''), is_deleted, is_disabled FROM urls
+	WHERE short_url = ANY(@shortURLs) OR original_url = ANY(@originalURLs)
+	`
+	insertURLsQuery = `
+	INSERT INTO urls (short_url, original_url, user_uuid, is_deleted, is_disabled)
+	SELECT s.short_url, s.original_url, NULLIF(s.user_id, '')::uuid, s.is_deleted, s.is_disabled
+	FROM unnest(@shortURLs::text[], @originalURLs::text[], @userIDs::text[], @deleted::bool[], @disabled::bool[])
+		AS s(short_url, original_url, user_id, is_deleted, is_disabled)
+	WHERE NOT EXISTS (SELECT 1 FROM urls WHERE urls.short_url = s.short_url)
+	ON CONFLICT DO NOTHING
+	`
 )
@@ -0,0 +1,27 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ReassignURLs transfers ownership of every URL owned by fromUserID to toUserID.
+// It returns the number of URLs that were reassigned or an error if the update fails.
+func (d *Database) ReassignURLs(
+	ctx context.Context,
+	fromUserID, toUserID string,
+) (int64, error) {
+	args := pgx.NamedArgs{
+		"fromUserID": fromUserID,
+		"toUserID":   toUserID,
+	}
+
+	ct, err := d.conn.Exec(ctx, reassignURLsQuery, args)
+	if err != nil {
+		return 0, err
+	}
+
+	d.recordWrite(ctx, fromUserID, toUserID)
+	return ct.RowsAffected(), nil
+}
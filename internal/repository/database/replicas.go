@@ -0,0 +1,225 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
+	"github.com/mp1947/ya-url-shortener/internal/tracing"
+	"go.uber.org/zap"
+)
+
+// replicaRetryInterval is the time during which a replica is not read from after it failed,
+// unless its readiness check finds it healthy again in the meantime.
+const replicaRetryInterval = 10 * time.Second
+
+// querier is the part of a connection pool used by reads.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// primaryReadsCtxKey marks the contexts whose reads are served by the primary.
+type primaryReadsCtxKey struct{}
+
+// WithPrimaryReads returns a copy of ctx whose reads are served by the primary. It is meant for results
+// which outlive the request, as cached lookups do, and would keep a change lagging on a replica stale.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsCtxKey{}, true)
+}
+
+// PrimaryReadsRequested reports whether the reads of ctx are served by the primary, see WithPrimaryReads.
+func PrimaryReadsRequested(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryReadsCtxKey{}).(bool)
+	return primary
+}
+
+// replica is a read-only standby of the primary database, with its own connection pool.
+type replica struct {
+	name string
+	conn *pgxpool.Pool
+
+	// downUntil is the time, in nanoseconds since the epoch, before which the replica is not read from.
+	downUntil atomic.Int64
+}
+
+// available reports whether the replica can be read from at now.
+func (r *replica) available(now time.Time) bool {
+	return now.UnixNano() >= r.downUntil.Load()
+}
+
+// markDown keeps the replica out of the reads for replicaRetryInterval after now.
+func (r *replica) markDown(now time.Time) {
+	r.downUntil.Store(now.Add(replicaRetryInterval).UnixNano())
+}
+
+// markUp makes the replica available to reads.
+func (r *replica) markUp() {
+	r.downUntil.Store(0)
+}
+
+// openReplica creates the connection pool of the replica at dsn and pings it. A replica which does
+// not answer is kept and marked down, so that it does not prevent the service from starting.
+func openReplica(ctx context.Context, dsn string, l *zap.Logger) (*replica, error) {
+	pgConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	pgConfig.ConnConfig.Tracer = tracing.NewQueryTracer()
+
+	conn, err := pgxpool.NewWithConfig(ctx, pgConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &replica{
+		name: net.JoinHostPort(pgConfig.ConnConfig.Host, strconv.Itoa(int(pgConfig.ConnConfig.Port))),
+		conn: conn,
+	}
+
+	if err := conn.Ping(ctx); err != nil {
+		l.Warn("database replica is not reachable", zap.String("replica", r.name), zap.Error(err))
+		r.markDown(time.Now())
+	}
+
+	return r, nil
+}
+
+// writeTracker records the users who recently wrote to the primary, whose reads are not served by
+// replicas for window after their last write so that they read their own writes.
+type writeTracker struct {
+	window time.Duration
+
+	mu        sync.Mutex
+	lastWrite map[string]time.Time
+	lastPrune time.Time
+}
+
+// newWriteTracker returns a writeTracker for the given read-your-writes window.
+func newWriteTracker(window time.Duration) *writeTracker {
+	return &writeTracker{window: window, lastWrite: make(map[string]time.Time)}
+}
+
+// record records a write of every non-empty user ID at now, forgetting the writes older than the window.
+func (w *writeTracker) record(now time.Time, userIDs ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if now.Sub(w.lastPrune) > w.window {
+		for userID, t := range w.lastWrite {
+			if now.Sub(t) > w.window {
+				delete(w.lastWrite, userID)
+			}
+		}
+		w.lastPrune = now
+	}
+
+	for _, userID := range userIDs {
+		if userID != "" {
+			w.lastWrite[userID] = now
+		}
+	}
+}
+
+// recent reports whether userID wrote within the window before now.
+func (w *writeTracker) recent(now time.Time, userID string) bool {
+	if userID == "" {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	t, ok := w.lastWrite[userID]
+	return ok && now.Sub(t) <= w.window
+}
+
+// ReplicaCount returns the number of replicas the Database reads from.
+func (d *Database) ReplicaCount() int {
+	return len(d.replicas)
+}
+
+// PingReplica pings the i-th replica, making it available to reads again when it answers and keeping it
+// out of the reads for a while otherwise. It is meant to be used as a readiness check of the replica.
+func (d *Database) PingReplica(ctx context.Context, i int) error {
+	r := d.replicas[i]
+	if err := r.conn.Ping(ctx); err != nil {
+		r.markDown(time.Now())
+		return err
+	}
+	r.markUp()
+	return nil
+}
+
+// recordWrite records a write made on behalf of the given users and of the actor of ctx, whose reads are
+// then served by the primary for the read-your-writes window. Nothing is recorded without replicas.
+func (d *Database) recordWrite(ctx context.Context, userIDs ...string) {
+	if len(d.replicas) == 0 {
+		return
+	}
+	if actor, ok := auth.ActorFromContext(ctx); ok {
+		userIDs = append(userIDs, actor.ID)
+	}
+	d.writes.record(time.Now(), userIDs...)
+}
+
+// replicaFor returns the replica serving a read of userID, in turn among the available ones, or nil when
+// the read must be served by the primary: there is no available replica or userID wrote recently.
+func (d *Database) replicaFor(userID string) *replica {
+	if len(d.replicas) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if d.writes.recent(now, userID) {
+		return nil
+	}
+
+	start := d.nextReplica.Add(1)
+	for i := range uint64(len(d.replicas)) {
+		r := d.replicas[(start+i)%uint64(len(d.replicas))]
+		if r.available(now) {
+			return r
+		}
+	}
+	return nil
+}
+
+// read runs query on a replica chosen by replicaFor for userID, or on the primary when there is none or ctx
+// requests primary reads. A query failing on the replica is run again on the primary, the replica being
+// kept out of the reads for a while unless the query only found no rows, which may be caused by the
+// replication lag.
+func (d *Database) read(ctx context.Context, userID string, query func(q querier) error) error {
+	var r *replica
+	if !PrimaryReadsRequested(ctx) {
+		r = d.replicaFor(userID)
+	}
+	if r == nil {
+		metrics.DatabaseReadsTotal.WithLabelValues(metrics.ReadPrimary).Inc()
+		return query(d.conn)
+	}
+
+	err := query(r.conn)
+	if err == nil || ctx.Err() != nil {
+		metrics.DatabaseReadsTotal.WithLabelValues(metrics.ReadReplica).Inc()
+		return err
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		d.log(ctx).Warn("error reading from database replica, falling back to the primary",
+			zap.String("replica", r.name), zap.Error(err))
+		r.markDown(time.Now())
+	}
+
+	metrics.DatabaseReadsTotal.WithLabelValues(metrics.ReadFallback).Inc()
+	return query(d.conn)
+}
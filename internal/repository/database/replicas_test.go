@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newTestPool returns a pool to dsn, which connects lazily and is only compared by identity.
+func newTestPool(t *testing.T, dsn string) *pgxpool.Pool {
+	t.Helper()
+	pool, err := pgxpool.New(context.Background(), dsn)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func newTestDatabase(t *testing.T) *Database {
+	d := &Database{
+		conn:   newTestPool(t, "postgres://primary:5432/app"),
+		l:      zap.NewNop(),
+		writes: newWriteTracker(time.Minute),
+	}
+	for _, name := range []string{"replica1", "replica2"} {
+		d.replicas = append(d.replicas, &replica{name: name, conn: newTestPool(t, "postgres://"+name+":5432/app")})
+	}
+	return d
+}
+
+// readFrom returns the pool serving a read of userID, failing on the pools in failing.
+func readFrom(d *Database, userID string, failing map[querier]error) (querier, error) {
+	var served querier
+	err := d.read(context.Background(), userID, func(q querier) error {
+		served = q
+		return failing[q]
+	})
+	return served, err
+}
+
+func TestRead(t *testing.T) {
+	t.Run("replicas in turn", func(t *testing.T) {
+		d := newTestDatabase(t)
+
+		first, err := readFrom(d, "user-1", nil)
+		require.NoError(t, err)
+		second, err := readFrom(d, "user-1", nil)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []querier{d.replicas[0].conn, d.replicas[1].conn}, []querier{first, second})
+	})
+
+	t.Run("primary without replicas", func(t *testing.T) {
+		d := newTestDatabase(t)
+		d.replicas = nil
+
+		served, err := readFrom(d, "user-1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, querier(d.conn), served)
+	})
+
+	t.Run("read your writes", func(t *testing.T) {
+		d := newTestDatabase(t)
+		d.recordWrite(context.Background(), "user-1")
+		d.recordWrite(auth.WithActor(context.Background(), auth.Actor{ID: "admin"}))
+
+		served, err := readFrom(d, "user-1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, querier(d.conn), served)
+
+		served, err = readFrom(d, "admin", nil)
+		require.NoError(t, err)
+		assert.Equal(t, querier(d.conn), served)
+
+		served, err = readFrom(d, "user-2", nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, querier(d.conn), served)
+	})
+
+	t.Run("primary reads requested by the context", func(t *testing.T) {
+		d := newTestDatabase(t)
+
+		var served querier
+		require.NoError(t, d.read(WithPrimaryReads(context.Background()), "user-1", func(q querier) error {
+			served = q
+			return nil
+		}))
+		assert.Equal(t, querier(d.conn), served)
+	})
+
+	t.Run("writes are forgotten after the window", func(t *testing.T) {
+		w := newWriteTracker(time.Second)
+		now := time.Now()
+		w.record(now, "user-1")
+
+		assert.True(t, w.recent(now.Add(time.Second), "user-1"))
+		assert.False(t, w.recent(now.Add(2*time.Second), "user-1"))
+
+		w.record(now.Add(3*time.Second), "user-2")
+		assert.NotContains(t, w.lastWrite, "user-1")
+	})
+
+	t.Run("failed replica falls back to the primary and is skipped", func(t *testing.T) {
+		d := newTestDatabase(t)
+		failing := map[querier]error{
+			d.replicas[0].conn: errors.New("connection refused"),
+			d.replicas[1].conn: errors.New("connection refused"),
+		}
+
+		served, err := readFrom(d, "", failing)
+		require.NoError(t, err)
+		assert.Equal(t, querier(d.conn), served)
+
+		served, err = readFrom(d, "", failing)
+		require.NoError(t, err)
+		assert.Equal(t, querier(d.conn), served)
+
+		assert.False(t, d.replicas[0].available(time.Now()))
+		assert.False(t, d.replicas[1].available(time.Now()))
+		assert.Nil(t, d.replicaFor(""))
+	})
+
+	t.Run("no rows on a replica falls back without skipping it", func(t *testing.T) {
+		d := newTestDatabase(t)
+		failing := map[querier]error{
+			d.replicas[0].conn: pgx.ErrNoRows,
+			d.replicas[1].conn: pgx.ErrNoRows,
+		}
+
+		served, err := readFrom(d, "", failing)
+		require.NoError(t, err)
+		assert.Equal(t, querier(d.conn), served)
+		assert.True(t, d.replicas[0].available(time.Now()))
+		assert.True(t, d.replicas[1].available(time.Now()))
+	})
+}
@@ -9,10 +9,11 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
 	"github.com/mp1947/ya-url-shortener/internal/model"
+	"go.uber.org/zap"
 )
 
 // Save inserts a new short URL mapping into the database, associating the given shortURLID with the originalURL and userID.
-// If the originalURL already exists for a user, it returns shrterr.ErrOriginalURLAlreadyExists.
+// If the shortURLID or the originalURL is already stored, it returns shrterr.ErrOriginalURLAlreadyExists.
 // Returns an error if the operation fails for other reasons.
 func (d *Database) Save(
 	ctx context.Context,
@@ -30,19 +31,22 @@ func (d *Database) Save(
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
 		if pgErr.Code == pgerrcode.UniqueViolation {
+			d.log(ctx).Debug("short url already exists", zap.String("short_url_id", shortURLID))
 			return shrterr.ErrOriginalURLAlreadyExists
 		}
 	} else if err != nil {
 		return err
 	}
 
+	d.recordWrite(ctx, userID)
 	return nil
 }
 
 // SaveBatch saves a batch of shortened URLs to the database within a single transaction.
 // It takes a context, a slice of model.URLWithCorrelation containing the URLs to save,
 // and the userID associated with the URLs. If any insert fails, the transaction is rolled back.
-// Returns true if all URLs are saved successfully, otherwise returns false and an error.
+// Returns true if all URLs are saved successfully, otherwise returns false and an error, which is
+// shrterr.ErrOriginalURLAlreadyExists if a short or original URL of the batch is already stored or appears twice in it.
 func (d *Database) SaveBatch(
 	ctx context.Context,
 	urls []model.URLWithCorrelation,
@@ -62,8 +66,13 @@ func (d *Database) SaveBatch(
 		_, ExecErr := tx.Exec(ctx, insertShortURLQuery, args)
 		if ExecErr != nil {
 			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				d.log(ctx).Warn("error rolling back batch save", zap.NamedError("cause", ExecErr), zap.Error(rbErr))
 				return false, rbErr
 			}
+			var pgErr *pgconn.PgError
+			if errors.As(ExecErr, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return false, shrterr.ErrOriginalURLAlreadyExists
+			}
 			return false, ExecErr
 		}
 	}
@@ -74,5 +83,7 @@ func (d *Database) SaveBatch(
 		return false, err
 	}
 
+	d.recordWrite(ctx, userID)
+
 	return true, nil
 }
@@ -9,13 +9,14 @@ import (
 // GetInternalStats retrieves internal statistics from the database, including the total number of URLs and users.
 // It executes a query to fetch these statistics and returns them as a dto.InternalStatsResp object.
 // If an error occurs during the query or scanning process, it returns the error.
+// The statistics are read from a replica when one is available.
 func (d *Database) GetInternalStats(ctx context.Context) (*dto.InternalStatsResp, error) {
 
-	data := d.conn.QueryRow(ctx, getInternalStatsQuery)
-
 	result := dto.InternalStatsResp{}
 
-	err := data.Scan(&result.URLs, &result.Users)
+	err := d.read(ctx, "", func(q querier) error {
+		return q.QueryRow(ctx, getInternalStatsQuery).Scan(&result.URLs, &result.Users)
+	})
 
 	if err != nil {
 		return nil, err
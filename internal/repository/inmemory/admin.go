@@ -0,0 +1,78 @@
+package inmemory
+
+import (
+	"context"
+
+	"github.com/mp1947/ya-url-shortener/internal/eventlog"
+)
+
+// SetDisabled sets the disabled flag of the given short URLs regardless of their owner.
+// Every change is appended to the event log. Unknown and deleted short URLs are skipped.
+// Returns the number of updated URLs and an error if writing an event fails.
+func (s *Memory) SetDisabled(
+	ctx context.Context,
+	shortURLs []string,
+	disabled bool,
+) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.updateEvents(shortURLs, func(event *eventlog.Event) {
+		event.IsDisabled = disabled
+	})
+}
+
+// ForceDeleteBatch marks the given short URLs as deleted regardless of their owner.
+// A deletion event is appended to the event log for every deleted URL. Unknown and already deleted
+// short URLs are skipped. Returns the number of deleted URLs and an error if writing an event fails.
+func (s *Memory) ForceDeleteBatch(
+	ctx context.Context,
+	shortURLs []string,
+) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.markDeleted(shortURLs, func(string) bool { return true })
+}
+
+// TransferURLs assigns the given short URLs to toUserID regardless of their current owner.
+// Every change is appended to the event log. Unknown and deleted short URLs are skipped.
+// Returns the number of transferred URLs and an error if writing an event fails.
+func (s *Memory) TransferURLs(
+	ctx context.Context,
+	shortURLs []string,
+	toUserID string,
+) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.updateEvents(shortURLs, func(event *eventlog.Event) {
+		event.UserID = toUserID
+	})
+}
+
+// updateEvents applies update to the stored event of every known short URL which is not deleted and
+// appends the updated event to the event log. The caller must hold the lock.
+func (s *Memory) updateEvents(
+	shortURLs []string,
+	update func(event *eventlog.Event),
+) (int64, error) {
+	var counter int64
+
+	for _, v := range shortURLs {
+		event, ok := s.shortURLToEvent[v]
+		if !ok || event.IsDeleted {
+			continue
+		}
+
+		update(&event)
+		s.shortURLToEvent[v] = event
+
+		if err := s.appendEvent(event); err != nil {
+			return counter, err
+		}
+		counter++
+	}
+
+	return counter, nil
+}
@@ -0,0 +1,68 @@
+package inmemory
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/mp1947/ya-url-shortener/internal/eventlog"
+)
+
+// Compact rewrites the event log with a single event per stored short URL, holding its latest state,
+// so that restoring from the compacted log yields the current in-memory state, deleted short URLs included.
+// The log is written to a temporary file in the same directory which
+// then replaces it, so the previous log is kept if compaction fails. The event log must not be written
+// by another process while it is compacted. Returns the number of events of the compacted log.
+func (s *Memory) Compact(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := *s.cfg.FileStoragePath
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".compact-*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	encoder := json.NewEncoder(tmp)
+	events := s.sortedEvents(func(event eventlog.Event) bool { return event.ShortURL != "" })
+
+	for i := range events {
+		events[i].UUID = strconv.Itoa(i + 1)
+		if err := encoder.Encode(&events[i]); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		s.shortURLToEvent[event.ShortURL] = event
+	}
+
+	// The replaced log is still open for appending, new events go to the compacted one.
+	_ = s.EP.File.Close()
+
+	s.EP, err = eventlog.NewEventProcessor(s.cfg)
+	if err != nil {
+		return 0, err
+	}
+	s.EP.CurrentUUID = len(events)
+
+	return len(events), nil
+}
@@ -3,24 +3,46 @@ package inmemory
 import (
 	"context"
 
-	"github.com/mp1947/ya-url-shortener/internal/eventlog"
 	"github.com/mp1947/ya-url-shortener/internal/model"
+	"go.uber.org/zap"
 )
 
-// DeleteBatch removes a batch of short URLs for a user from memory.
-// Returns the number of processed URLs and an error if any.
+// DeleteBatch marks the short URLs of a batch owned by its user as deleted, the URLs of other users,
+// unknown and already deleted ones being skipped. A deletion event is appended to the event log for
+// every deleted URL. Returns the number of deleted URLs and an error if writing an event fails.
 func (s *Memory) DeleteBatch(
 	ctx context.Context,
 	shortURLs model.BatchDeleteShortURLs,
 ) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter, err := s.markDeleted(shortURLs.ShortURLs, func(userID string) bool {
+		return userID == shortURLs.UserID
+	})
+	s.log(ctx).Debug("batch deletion applied", zap.Int64("short_urls", counter))
+	return counter, err
+}
+
+// markDeleted marks the stored, not yet deleted, short URLs whose owner matches owned as deleted
+// and appends their deletion events to the event log. The caller must hold the lock.
+func (s *Memory) markDeleted(shortURLs []string, owned func(userID string) bool) (int64, error) {
 	var counter int64
-	for _, v := range shortURLs.ShortURLs {
-		event := s.shortURLToEvent[v]
-		if event.UserID == shortURLs.UserID {
-			s.data[v] = ""
-			s.shortURLToEvent[v] = eventlog.Event{}
+
+	for _, v := range shortURLs {
+		event, ok := s.shortURLToEvent[v]
+		if !ok || event.IsDeleted || !owned(event.UserID) {
+			continue
+		}
+
+		event.IsDeleted = true
+		s.shortURLToEvent[v] = event
+
+		if err := s.appendEvent(event); err != nil {
+			return counter, err
 		}
 		counter++
 	}
+
 	return counter, nil
 }
@@ -1,23 +1,35 @@
 package inmemory
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
-	"os"
+	"sort"
+	"strconv"
 
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
 	"github.com/mp1947/ya-url-shortener/internal/eventlog"
 	"github.com/mp1947/ya-url-shortener/internal/model"
 )
 
 // Get retrieves the original URL and related information associated with the given shortURL from the in-memory storage.
-// It returns a model.URL containing the original URL, the short URL ID, and a deletion status.
-// If the shortURL does not exist, the OriginalURL field will be empty.
+// It returns a model.URL containing the original URL, the short URL ID, the owner user ID, a deletion status
+// and a disabled status.
+// If the shortURL does not exist, shrterr.ErrShortURLNotFound is returned.
 func (s *Memory) Get(ctx context.Context, shortURL string) (model.URL, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	originalURL, ok := s.data[shortURL]
+	if !ok {
+		return model.URL{}, shrterr.ErrShortURLNotFound
+	}
+
+	event := s.shortURLToEvent[shortURL]
 	return model.URL{
-		OriginalURL: s.data[shortURL],
+		OriginalURL: originalURL,
 		ShortURLID:  shortURL,
-		IsDeleted:   false,
+		UserID:      event.UserID,
+		IsDeleted:   event.IsDeleted,
+		IsDisabled:  event.IsDisabled,
 	}, nil
 }
 
@@ -26,38 +38,82 @@ func (s *Memory) GetType() string {
 	return s.StorageType
 }
 
-// GetURLsByUserID retrieves all URLs associated with the specified user ID from the file storage.
-// It reads each line from the storage file, unmarshals it into an event structure, and collects
-// the URLs that belong to the given user. Returns a slice of UserURL and an error if any occurs
-// during file operations or JSON unmarshalling.
+// GetURLsByUserID retrieves all URLs associated with the specified user ID from the in-memory storage.
+// It collects the URLs whose latest event belongs to the given user, deleted ones included, ordered by the time
+// they were first saved. Returns a slice of UserURL and a nil error.
 func (s *Memory) GetURLsByUserID(ctx context.Context, userID string) ([]model.UserURL, error) {
-	file, err := os.OpenFile(*s.cfg.FileStoragePath, os.O_RDONLY|os.O_CREATE, 0666)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	if err != nil {
-		return nil, err
+	var result []model.UserURL
+
+	for _, event := range s.sortedEvents(func(event eventlog.Event) bool { return event.UserID == userID }) {
+		result = append(result, model.UserURL{
+			ShortURLID:  event.ShortURL,
+			OriginalURL: event.OriginalURL,
+			IsDeleted:   event.IsDeleted,
+			IsDisabled:  event.IsDisabled,
+		})
 	}
-	defer func() {
-		_ = file.Close()
-	}()
 
-	scanner := bufio.NewScanner(file)
+	return result, nil
+}
 
-	var result []model.UserURL
+// ListUserURLs calls fn for every URL of userID, deleted and disabled ones included, in the order they were
+// first saved. It stops and returns the error of fn. The URLs are collected before fn is first called, so
+// that a slow fn does not keep the storage locked.
+func (s *Memory) ListUserURLs(ctx context.Context, userID string, fn func(model.UserURL) error) error {
+	urls, err := s.GetURLsByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
 
-	for scanner.Scan() {
-		var event eventlog.Event
-		line := scanner.Text()
+	for _, u := range urls {
+		if err := fn(u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		if err := json.Unmarshal([]byte(line), &event); err != nil {
-			return nil, err
+// ListURLs calls fn for every URL stored in memory, deleted and disabled ones included, in the order
+// they were first saved. It stops and returns the error of fn. The storage is locked for reading until
+// it returns, so fn must not write to it.
+func (s *Memory) ListURLs(ctx context.Context, fn func(model.URL) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, event := range s.sortedEvents(func(event eventlog.Event) bool { return event.ShortURL != "" }) {
+		err := fn(model.URL{
+			ShortURLID:  event.ShortURL,
+			OriginalURL: event.OriginalURL,
+			UserID:      event.UserID,
+			IsDeleted:   event.IsDeleted,
+			IsDisabled:  event.IsDisabled,
+		})
+		if err != nil {
+			return err
 		}
-		if event.UserID == userID {
-			result = append(result, model.UserURL{
-				ShortURLID:  event.ShortURL,
-				OriginalURL: event.OriginalURL,
-			})
+	}
+	return nil
+}
+
+// sortedEvents returns the latest events of the short URLs matching keep, ordered by the time
+// they were first saved. The caller must hold the lock.
+func (s *Memory) sortedEvents(keep func(event eventlog.Event) bool) []eventlog.Event {
+	events := make([]eventlog.Event, 0)
+
+	for _, event := range s.shortURLToEvent {
+		if keep(event) {
+			events = append(events, event)
 		}
 	}
 
-	return result, nil
+	sort.Slice(events, func(i, j int) bool {
+		left, _ := strconv.Atoi(events[i].UUID)
+		right, _ := strconv.Atoi(events[j].UUID)
+		return left < right
+	})
+
+	return events
 }
@@ -20,8 +20,10 @@ func (s *Memory) Init(
 	var err error
 
 	s.cfg = cfg
+	s.l = l
 	s.isInRestoreMode = false
 	s.data = make(map[string]string)
+	s.originalToShort = make(map[string]string)
 	s.shortURLToEvent = make(map[string]eventlog.Event)
 	s.StorageType = "inmemory"
 	s.EP, err = eventlog.NewEventProcessor(s.cfg)
@@ -2,21 +2,45 @@
 package inmemory
 
 import (
-	"github.com/mp1947/ya-url-shortener/config"
+	"context"
+	"strconv"
+	"sync"
 
+	"github.com/mp1947/ya-url-shortener/config"
 	"github.com/mp1947/ya-url-shortener/internal/eventlog"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"go.uber.org/zap"
 )
 
 // Memory represents an in-memory storage for URL shortening service data.
 // It maintains mappings between original URLs and their shortened versions,
 // as well as event logs associated with shortened URLs. The struct also
-// holds configuration settings, an event processor for handling events,
+// holds configuration settings, the logger it was initialized with, an event processor for handling events,
 // a flag indicating if the storage is in restore mode, and the type of storage used.
+// Deleted short URLs are kept with their deletion flag set, as the database does.
+// The methods of Memory are safe for concurrent use.
 type Memory struct {
+	mu              sync.RWMutex
 	EP              *eventlog.EventProcessor
 	data            map[string]string
+	originalToShort map[string]string
 	shortURLToEvent map[string]eventlog.Event
 	cfg             config.Config
+	l               *zap.Logger
 	StorageType     string
 	isInRestoreMode bool
 }
+
+// appendEvent writes a later event of an already stored short URL to the event log, under a new UUID.
+// The stored event keeps the UUID of the first event of the short URL, which orders the listings.
+// The caller must hold the lock.
+func (s *Memory) appendEvent(event eventlog.Event) error {
+	s.EP.IncrementUUID()
+	event.UUID = strconv.Itoa(s.EP.CurrentUUID)
+	return s.EP.WriteEvent(&event)
+}
+
+// log returns the request-scoped logger of ctx, or the logger of the Memory when ctx has none.
+func (s *Memory) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.l)
+}
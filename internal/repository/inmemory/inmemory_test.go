@@ -0,0 +1,157 @@
+package inmemory_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/eventlog"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/repository"
+	"github.com/mp1947/ya-url-shortener/internal/repository/inmemory"
+	"github.com/mp1947/ya-url-shortener/internal/repository/repositorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newMemory returns an in-memory storage whose event log is at path, restored from it.
+func newMemory(t *testing.T, path string) *inmemory.Memory {
+	t.Helper()
+
+	m := &inmemory.Memory{}
+	require.NoError(t, m.Init(context.Background(), config.Config{FileStoragePath: &path}, zap.NewNop()))
+	t.Cleanup(func() { _ = m.EP.File.Close() })
+
+	_, err := m.RestoreFromFile(zap.NewNop())
+	require.NoError(t, err)
+	return m
+}
+
+// eventUUIDs returns the UUIDs of the events of the event log at path, in order.
+func eventUUIDs(t *testing.T, path string) []string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var uuids []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event eventlog.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		uuids = append(uuids, event.UUID)
+	}
+	require.NoError(t, scanner.Err())
+	return uuids
+}
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.Repository {
+		return newMemory(t, filepath.Join(t.TempDir(), "events.out"))
+	})
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.out")
+	userID := uuid.NewString()
+
+	m := newMemory(t, path)
+	require.NoError(t, m.Save(ctx, "aaa", "https://a.example.com", userID))
+	require.NoError(t, m.Save(ctx, "bbb", "https://b.example.com", userID))
+	require.NoError(t, m.Save(ctx, "ccc", "https://c.example.com", userID))
+	_, err := m.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: userID, ShortURLs: []string{"aaa"}})
+	require.NoError(t, err)
+	_, err = m.SetDisabled(ctx, []string{"bbb"}, true)
+	require.NoError(t, err)
+
+	var before []model.URL
+	require.NoError(t, m.ListURLs(ctx, func(u model.URL) error {
+		before = append(before, u)
+		return nil
+	}))
+
+	restored := newMemory(t, path)
+	var after []model.URL
+	require.NoError(t, restored.ListURLs(ctx, func(u model.URL) error {
+		after = append(after, u)
+		return nil
+	}))
+
+	assert.Equal(t, before, after, "deletions are persisted")
+	assert.True(t, after[0].IsDeleted)
+}
+
+func TestReassignURLs(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.out")
+	from, to := uuid.NewString(), uuid.NewString()
+
+	m := newMemory(t, path)
+	require.NoError(t, m.Save(ctx, "aaa", "https://a.example.com", from))
+	require.NoError(t, m.Save(ctx, "bbb", "https://b.example.com", from))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		reassigned, err := m.ReassignURLs(ctx, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), reassigned)
+	}()
+	go func() {
+		defer wg.Done()
+		_, err := m.GetURLsByUserID(ctx, to)
+		assert.NoError(t, err)
+	}()
+	wg.Wait()
+
+	assert.Equal(t, []string{"1", "2", "3", "4"}, eventUUIDs(t, path), "reassignments are new events")
+
+	restored := newMemory(t, path)
+	urls, err := restored.GetURLsByUserID(ctx, to)
+	require.NoError(t, err)
+	assert.Equal(t, []model.UserURL{
+		{ShortURLID: "aaa", OriginalURL: "https://a.example.com"},
+		{ShortURLID: "bbb", OriginalURL: "https://b.example.com"},
+	}, urls, "the new owner is restored and the urls keep their save order")
+}
+
+func TestAdminOperations(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.out")
+	userID := uuid.NewString()
+
+	m := newMemory(t, path)
+	require.NoError(t, m.Save(ctx, "aaa", "https://a.example.com", userID))
+	require.NoError(t, m.Save(ctx, "bbb", "https://b.example.com", userID))
+
+	disabled, err := m.SetDisabled(ctx, []string{"aaa", "unknown"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), disabled)
+
+	deleted, err := m.ForceDeleteBatch(ctx, []string{"bbb", "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	assert.Equal(t, []string{"1", "2", "3", "4"}, eventUUIDs(t, path), "changes are new events")
+
+	for _, r := range []*inmemory.Memory{m, newMemory(t, path)} {
+		u, err := r.Get(ctx, "aaa")
+		require.NoError(t, err)
+		assert.True(t, u.IsDisabled)
+
+		u, err = r.Get(ctx, "bbb")
+		require.NoError(t, err)
+		assert.Equal(t, "https://b.example.com", u.OriginalURL, "force deleted urls are kept, as the database does")
+		assert.True(t, u.IsDeleted)
+	}
+}
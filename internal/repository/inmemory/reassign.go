@@ -0,0 +1,34 @@
+package inmemory
+
+import (
+	"context"
+)
+
+// ReassignURLs transfers ownership of every short URL owned by fromUserID to toUserID.
+// Each reassignment is appended to the event log as a new event, so the new owner survives a restore.
+// Returns the number of reassigned URLs and an error if writing an event fails.
+func (s *Memory) ReassignURLs(
+	ctx context.Context,
+	fromUserID, toUserID string,
+) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var counter int64
+
+	for shortURL, event := range s.shortURLToEvent {
+		if event.UserID != fromUserID {
+			continue
+		}
+
+		event.UserID = toUserID
+		s.shortURLToEvent[shortURL] = event
+
+		if err := s.appendEvent(event); err != nil {
+			return counter, err
+		}
+		counter++
+	}
+
+	return counter, nil
+}
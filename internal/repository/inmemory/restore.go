@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"os"
 
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
 	"github.com/mp1947/ya-url-shortener/internal/eventlog"
 	"go.uber.org/zap"
 )
@@ -13,6 +14,7 @@ import (
 // RestoreFromFile restores the in-memory storage state from a file specified in the configuration.
 // It reads each line from the file, unmarshals it into an eventlog.Event, and saves it to the storage.
 // The method returns the number of records restored and any error encountered during the process.
+// Later events for an already restored short URL replace the stored event, so ownership changes, disabled flags and deletions are kept.
 // If an error occurs while saving a record, it logs a warning but continues processing the rest of the file.
 func (s *Memory) RestoreFromFile(l *zap.Logger) (int, error) {
 	file, err := os.OpenFile(*s.cfg.FileStoragePath, os.O_RDONLY|os.O_CREATE, 0666)
@@ -28,6 +30,9 @@ func (s *Memory) RestoreFromFile(l *zap.Logger) (int, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.isInRestoreMode = true
 
 	for scanner.Scan() {
@@ -37,8 +42,23 @@ func (s *Memory) RestoreFromFile(l *zap.Logger) (int, error) {
 		if err := json.Unmarshal([]byte(line), &event); err != nil {
 			return 0, err
 		}
-		if err := s.Save(ctx, event.ShortURL, event.OriginalURL, event.UserID); err != nil {
+		if existing, ok := s.shortURLToEvent[event.ShortURL]; ok {
+			// later events for the same short url (e.g. ownership changes, deletions) supersede earlier ones
+			event.UUID = existing.UUID
+			s.shortURLToEvent[event.ShortURL] = event
+			currentUUID += 1
+			continue
+		}
+
+		if s.exists(event.ShortURL, event.OriginalURL) {
+			l.Warn("error saving record to file during restore phase", zap.Error(shrterr.ErrOriginalURLAlreadyExists))
+		} else if err := s.save(ctx, event.ShortURL, event.OriginalURL, event.UserID); err != nil {
 			l.Warn("error saving record to file during restore phase", zap.Error(err))
+		} else {
+			// a compacted event log holds the flags in the first event of a short url
+			restored := s.shortURLToEvent[event.ShortURL]
+			restored.IsDisabled, restored.IsDeleted = event.IsDisabled, event.IsDeleted
+			s.shortURLToEvent[event.ShortURL] = restored
 		}
 
 		currentUUID += 1
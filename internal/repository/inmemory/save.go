@@ -6,47 +6,71 @@ import (
 
 	"github.com/mp1947/ya-url-shortener/internal/eventlog"
 	"github.com/mp1947/ya-url-shortener/internal/model"
+	"go.uber.org/zap"
 
 	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
 )
 
 // Save stores the mapping between a short URL ID and its original URL for a given user.
-// If the short URL ID does not already exist in memory, it saves the mapping, logs the event,
-// and optionally writes the event to persistent storage unless in restore mode.
-// Returns an error if the short URL ID already exists.
+// If neither the short URL ID nor the original URL is already stored, deleted ones included, it saves the mapping,
+// logs the event, and optionally writes the event to persistent storage unless in restore mode.
+// Returns shrterr.ErrOriginalURLAlreadyExists if the short URL ID or the original URL already exists.
 func (s *Memory) Save(
 	ctx context.Context,
 	shortURLID,
 	originalURL string,
 	userID string,
 ) error {
-	if s.data[shortURLID] == "" {
-		s.data[shortURLID] = originalURL
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-		s.EP.IncrementUUID()
-		event := eventlog.Event{
-			UUID:        strconv.Itoa(s.EP.CurrentUUID),
-			ShortURL:    shortURLID,
-			OriginalURL: originalURL,
-			UserID:      userID,
-			IsDeleted:   false,
-		}
-		s.shortURLToEvent[shortURLID] = event
-		if !s.isInRestoreMode {
-			err := s.EP.WriteEvent(&event)
-			if err != nil {
-				return err
-			}
+	if s.exists(shortURLID, originalURL) {
+		return shrterr.ErrOriginalURLAlreadyExists
+	}
+
+	return s.save(ctx, shortURLID, originalURL, userID)
+}
+
+// exists reports whether shortURLID or originalURL is already stored. The caller must hold the lock.
+func (s *Memory) exists(shortURLID, originalURL string) bool {
+	_, shortExists := s.data[shortURLID]
+	_, originalExists := s.originalToShort[originalURL]
+	return shortExists || originalExists
+}
+
+// save stores a mapping which does not exist yet. The caller must hold the lock.
+func (s *Memory) save(
+	ctx context.Context,
+	shortURLID,
+	originalURL string,
+	userID string,
+) error {
+	s.data[shortURLID] = originalURL
+	s.originalToShort[originalURL] = shortURLID
+
+	s.EP.IncrementUUID()
+	event := eventlog.Event{
+		UUID:        strconv.Itoa(s.EP.CurrentUUID),
+		ShortURL:    shortURLID,
+		OriginalURL: originalURL,
+		UserID:      userID,
+		IsDeleted:   false,
+	}
+	s.shortURLToEvent[shortURLID] = event
+	if !s.isInRestoreMode {
+		err := s.EP.WriteEvent(&event)
+		if err != nil {
+			s.log(ctx).Warn("error writing event to the event log", zap.String("short_url_id", shortURLID), zap.Error(err))
+			return err
 		}
-		return nil
 	}
-	return shrterr.ErrOriginalURLAlreadyExists
+	return nil
 }
 
 // SaveBatch saves a batch of URL mappings for a specific user into memory.
-// It iterates over the provided slice of URLWithCorrelation, saving each URL using the Save method.
-// If any save operation fails, it returns false and the encountered error immediately.
-// On success, it returns true and a nil error.
+// Like the database transaction, the batch is saved entirely or not at all: if a short URL ID or an
+// original URL of the batch is already stored, or appears twice in the batch, nothing is saved and
+// shrterr.ErrOriginalURLAlreadyExists is returned.
 //
 // Parameters:
 //   - ctx: context for cancellation and deadlines.
@@ -61,10 +85,22 @@ func (s *Memory) SaveBatch(
 	urls []model.URLWithCorrelation,
 	userID string,
 ) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shortURLs := make(map[string]bool, len(urls))
+	originalURLs := make(map[string]bool, len(urls))
+
 	for _, v := range urls {
+		if s.exists(v.ShortURLID, v.OriginalURL) || shortURLs[v.ShortURLID] || originalURLs[v.OriginalURL] {
+			return false, shrterr.ErrOriginalURLAlreadyExists
+		}
+		shortURLs[v.ShortURLID] = true
+		originalURLs[v.OriginalURL] = true
+	}
 
-		err := s.Save(ctx, v.ShortURLID, v.OriginalURL, userID)
-		if err != nil {
+	for _, v := range urls {
+		if err := s.save(ctx, v.ShortURLID, v.OriginalURL, userID); err != nil {
 			return false, err
 		}
 	}
@@ -6,13 +6,24 @@ import (
 	"github.com/mp1947/ya-url-shortener/internal/dto"
 )
 
-// GetInternalStats retrieves internal statistics from the in-memory repository.
+// GetInternalStats retrieves internal statistics from the in-memory repository: the number of stored
+// URLs, deleted ones included, and the number of distinct users owning them.
 // It returns a pointer to dto.InternalStatsResp containing the statistics data,
 // or an error if the operation fails.
 // The context parameter allows for request cancellation and timeout control.
 func (s *Memory) GetInternalStats(ctx context.Context) (*dto.InternalStatsResp, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users := make(map[string]bool)
+	for _, event := range s.shortURLToEvent {
+		if event.UserID != "" {
+			users[event.UserID] = true
+		}
+	}
+
 	return &dto.InternalStatsResp{
-		URLs:  s.EP.CurrentUUID,
-		Users: s.EP.CurrentUUID,
+		URLs:  len(s.shortURLToEvent),
+		Users: len(users),
 	}, nil
 }
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/audit"
 	"github.com/mp1947/ya-url-shortener/internal/dto"
 	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
 	"github.com/mp1947/ya-url-shortener/internal/model"
@@ -17,8 +18,9 @@ import (
 // Repository defines the interface for URL storage and retrieval operations.
 // It abstracts the underlying data storage mechanism and provides methods for
 // initializing the repository, saving single or multiple URLs, deleting URLs in batch,
-// retrieving a URL by its short identifier, fetching all URLs associated with a user,
-// and obtaining the repository type.
+// retrieving a URL by its short identifier, fetching or listing all URLs associated with a user,
+// transferring URLs between users, operator actions on arbitrary URLs (disabling,
+// force-deleting and transferring ownership), listing every stored URL, and obtaining the repository type.
 type Repository interface {
 	Init(ctx context.Context, cfg config.Config, l *zap.Logger) error
 	Save(ctx context.Context, shortURLID, originalURL string, userID string) error
@@ -26,6 +28,12 @@ type Repository interface {
 	DeleteBatch(ctx context.Context, shortURLs model.BatchDeleteShortURLs) (int64, error)
 	Get(ctx context.Context, shortURL string) (model.URL, error)
 	GetURLsByUserID(ctx context.Context, userID string) ([]model.UserURL, error)
+	ListUserURLs(ctx context.Context, userID string, fn func(model.UserURL) error) error
+	ReassignURLs(ctx context.Context, fromUserID, toUserID string) (int64, error)
+	SetDisabled(ctx context.Context, shortURLs []string, disabled bool) (int64, error)
+	ForceDeleteBatch(ctx context.Context, shortURLs []string) (int64, error)
+	TransferURLs(ctx context.Context, shortURLs []string, toUserID string) (int64, error)
+	ListURLs(ctx context.Context, fn func(model.URL) error) error
 	GetType() string
 	GetInternalStats(ctx context.Context) (*dto.InternalStatsResp, error)
 }
@@ -78,3 +86,25 @@ func CreateRepository(
 
 	return nil, shrterr.ErrUnableToDetermineStorageType
 }
+
+// CreateAuditStore returns the audit.Store matching the given repository.
+// A database repository keeps audit entries in its audit_log table, an in-memory
+// repository appends them as JSON lines to the file configured by AUDIT_LOG_PATH.
+func CreateAuditStore(
+	l *zap.Logger,
+	cfg config.Config,
+	repo Repository,
+) (audit.Store, error) {
+	if db, ok := repo.(*database.Database); ok {
+		l.Info("storing audit entries in the database")
+		return db.AuditStore(), nil
+	}
+
+	if cfg.AuditLogPath == nil || *cfg.AuditLogPath == "" {
+		return nil, shrterr.ErrAuditLogPathUndefined
+	}
+
+	l.Info("storing audit entries in file", zap.String("audit_log_path", *cfg.AuditLogPath))
+
+	return audit.NewFileStore(*cfg.AuditLogPath)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
 	"github.com/mp1947/ya-url-shortener/internal/logger"
 	"github.com/mp1947/ya-url-shortener/internal/repository"
 	"github.com/stretchr/testify/assert"
@@ -14,7 +15,8 @@ func TestCreateRepository(t *testing.T) {
 	l, err := logger.InitLogger()
 	assert.NoError(t, err)
 
-	cfg := config.InitConfig()
+	cfg, err := config.Load(nil, []string{authtest.Environ}, nil)
+	assert.NoError(t, err)
 
 	_, err = repository.CreateRepository(l, *cfg, context.Background())
 	assert.NoError(t, err)
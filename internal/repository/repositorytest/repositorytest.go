@@ -0,0 +1,473 @@
+// Package repositorytest provides the conformance suite of the repository.Repository contract,
+// which every storage backend runs so that they behave alike: conflicts, batches, deletions and
+// their flags, listings, operator actions, statistics and concurrent use.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrency is the number of goroutines used by the concurrency checks.
+const concurrency = 32
+
+// Factory returns a new, initialized and empty repository, releasing it with t.Cleanup.
+type Factory func(t *testing.T) repository.Repository
+
+// Run runs the conformance suite against the repositories returned by newRepository, each check
+// getting a new one. User IDs are UUIDs, as the database stores them.
+func Run(t *testing.T, newRepository Factory) {
+	for _, check := range []struct {
+		name string
+		run  func(t *testing.T, r repository.Repository)
+	}{
+		{"type", testType},
+		{"save and get", testSaveGet},
+		{"get unknown", testGetUnknown},
+		{"save conflicts", testSaveConflicts},
+		{"save batch", testSaveBatch},
+		{"save batch is atomic", testSaveBatchAtomic},
+		{"delete batch", testDeleteBatch},
+		{"get urls by user id", testGetURLsByUserID},
+		{"list user urls", testListUserURLs},
+		{"reassign urls", testReassignURLs},
+		{"set disabled", testSetDisabled},
+		{"force delete batch", testForceDeleteBatch},
+		{"transfer urls", testTransferURLs},
+		{"list urls", testListURLs},
+		{"internal stats", testInternalStats},
+		{"concurrent saves", testConcurrentSaves},
+		{"concurrent conflicting saves", testConcurrentConflictingSaves},
+		{"concurrent reads and deletions", testConcurrentReadsAndDeletions},
+	} {
+		t.Run(check.name, func(t *testing.T) {
+			check.run(t, newRepository(t))
+		})
+	}
+}
+
+// newURL returns the URL saved under the short URL ID id.
+func newURL(id, userID string) model.URL {
+	return model.URL{ShortURLID: id, OriginalURL: "https://" + id + ".example.com/path", UserID: userID}
+}
+
+// save saves urls and fails the test on error.
+func save(t *testing.T, r repository.Repository, urls ...model.URL) {
+	t.Helper()
+	for _, u := range urls {
+		require.NoError(t, r.Save(context.Background(), u.ShortURLID, u.OriginalURL, u.UserID))
+	}
+}
+
+// get looks up shortURL and fails the test on error.
+func get(t *testing.T, r repository.Repository, shortURL string) model.URL {
+	t.Helper()
+	u, err := r.Get(context.Background(), shortURL)
+	require.NoError(t, err)
+	return u
+}
+
+// userURL returns u as listed by GetURLsByUserID.
+func userURL(u model.URL) model.UserURL {
+	return model.UserURL{ShortURLID: u.ShortURLID, OriginalURL: u.OriginalURL, IsDeleted: u.IsDeleted, IsDisabled: u.IsDisabled}
+}
+
+// listURLs returns every URL listed by ListURLs.
+func listURLs(t *testing.T, r repository.Repository) []model.URL {
+	t.Helper()
+	var urls []model.URL
+	require.NoError(t, r.ListURLs(context.Background(), func(u model.URL) error {
+		urls = append(urls, u)
+		return nil
+	}))
+	return urls
+}
+
+// assertNotFound checks that a lookup found no URL.
+func assertNotFound(t *testing.T, u model.URL, err error) {
+	t.Helper()
+	assert.ErrorIs(t, err, shrterr.ErrShortURLNotFound)
+	assert.Empty(t, u.OriginalURL)
+}
+
+func testType(t *testing.T, r repository.Repository) {
+	assert.NotEmpty(t, r.GetType())
+}
+
+func testSaveGet(t *testing.T, r repository.Repository) {
+	u := newURL("aaa", uuid.NewString())
+	save(t, r, u)
+
+	assert.Equal(t, u, get(t, r, "aaa"))
+}
+
+func testGetUnknown(t *testing.T, r repository.Repository) {
+	u, err := r.Get(context.Background(), "unknown")
+	assertNotFound(t, u, err)
+}
+
+func testSaveConflicts(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	u := newURL("aaa", uuid.NewString())
+	save(t, r, u)
+
+	err := r.Save(ctx, u.ShortURLID, u.OriginalURL, uuid.NewString())
+	assert.ErrorIs(t, err, shrterr.ErrOriginalURLAlreadyExists, "same short and original url")
+
+	err = r.Save(ctx, "bbb", u.OriginalURL, uuid.NewString())
+	assert.ErrorIs(t, err, shrterr.ErrOriginalURLAlreadyExists, "same original url")
+
+	err = r.Save(ctx, u.ShortURLID, "https://other.example.com/aaa", uuid.NewString())
+	assert.ErrorIs(t, err, shrterr.ErrOriginalURLAlreadyExists, "same short url")
+
+	assert.Equal(t, u, get(t, r, "aaa"), "the stored url is unchanged")
+
+	_, err = r.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: u.UserID, ShortURLs: []string{"aaa"}})
+	require.NoError(t, err)
+
+	err = r.Save(ctx, u.ShortURLID, u.OriginalURL, u.UserID)
+	assert.ErrorIs(t, err, shrterr.ErrOriginalURLAlreadyExists, "deleted urls are kept")
+}
+
+func testSaveBatch(t *testing.T, r repository.Repository) {
+	userID := uuid.NewString()
+	a, b := newURL("aaa", userID), newURL("bbb", userID)
+
+	ok, err := r.SaveBatch(context.Background(), []model.URLWithCorrelation{
+		{ShortURLID: a.ShortURLID, OriginalURL: a.OriginalURL, CorrelationID: "1"},
+		{ShortURLID: b.ShortURLID, OriginalURL: b.OriginalURL, CorrelationID: "2"},
+	}, userID)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	assert.Equal(t, a, get(t, r, "aaa"))
+	assert.Equal(t, b, get(t, r, "bbb"))
+
+	ok, err = r.SaveBatch(context.Background(), nil, userID)
+	require.NoError(t, err)
+	assert.True(t, ok, "empty batch")
+}
+
+func testSaveBatchAtomic(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	stored := newURL("aaa", userID)
+	save(t, r, stored)
+
+	fresh := newURL("bbb", userID)
+
+	ok, err := r.SaveBatch(ctx, []model.URLWithCorrelation{
+		{ShortURLID: fresh.ShortURLID, OriginalURL: fresh.OriginalURL},
+		{ShortURLID: stored.ShortURLID, OriginalURL: stored.OriginalURL},
+	}, userID)
+	assert.ErrorIs(t, err, shrterr.ErrOriginalURLAlreadyExists, "conflict with a stored url")
+	assert.False(t, ok)
+
+	ok, err = r.SaveBatch(ctx, []model.URLWithCorrelation{
+		{ShortURLID: fresh.ShortURLID, OriginalURL: fresh.OriginalURL},
+		{ShortURLID: fresh.ShortURLID, OriginalURL: fresh.OriginalURL},
+	}, userID)
+	assert.ErrorIs(t, err, shrterr.ErrOriginalURLAlreadyExists, "duplicate in the batch")
+	assert.False(t, ok)
+
+	u, err := r.Get(ctx, fresh.ShortURLID)
+	assertNotFound(t, u, err)
+	assert.Len(t, listURLs(t, r), 1)
+}
+
+func testDeleteBatch(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	owner, other := uuid.NewString(), uuid.NewString()
+	a, b, c := newURL("aaa", owner), newURL("bbb", owner), newURL("ccc", other)
+	save(t, r, a, b, c)
+
+	deleted, err := r.DeleteBatch(ctx, model.BatchDeleteShortURLs{
+		UserID:    owner,
+		ShortURLs: []string{"aaa", "ccc", "unknown"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted, "only the urls of the user are deleted")
+
+	a.IsDeleted = true
+	assert.Equal(t, a, get(t, r, "aaa"), "deleted urls keep their data")
+	assert.Equal(t, b, get(t, r, "bbb"))
+	assert.Equal(t, c, get(t, r, "ccc"))
+
+	deleted, err = r.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: owner, ShortURLs: []string{"aaa", "bbb"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted, "already deleted urls are not counted")
+
+	deleted, err = r.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: owner})
+	require.NoError(t, err)
+	assert.Zero(t, deleted, "empty batch")
+}
+
+func testGetURLsByUserID(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	a, b, c := newURL("aaa", userID), newURL("bbb", userID), newURL("ccc", userID)
+	save(t, r, c, a, b, newURL("ddd", uuid.NewString()))
+
+	_, err := r.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: userID, ShortURLs: []string{"aaa"}})
+	require.NoError(t, err)
+	_, err = r.SetDisabled(ctx, []string{"bbb"}, true)
+	require.NoError(t, err)
+	a.IsDeleted, b.IsDisabled = true, true
+
+	urls, err := r.GetURLsByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []model.UserURL{userURL(c), userURL(a), userURL(b)}, urls, "every url of the user, in the order they were saved")
+
+	urls, err = r.GetURLsByUserID(ctx, uuid.NewString())
+	require.NoError(t, err)
+	assert.Empty(t, urls, "unknown user")
+}
+
+func testListUserURLs(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	a, b, c := newURL("aaa", userID), newURL("bbb", userID), newURL("ccc", userID)
+	save(t, r, c, a, newURL("ddd", uuid.NewString()), b)
+
+	_, err := r.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: userID, ShortURLs: []string{"aaa"}})
+	require.NoError(t, err)
+	a.IsDeleted = true
+
+	var urls []model.UserURL
+	require.NoError(t, r.ListUserURLs(ctx, userID, func(u model.UserURL) error {
+		urls = append(urls, u)
+		return nil
+	}))
+	assert.Equal(t, []model.UserURL{userURL(c), userURL(a), userURL(b)}, urls, "every url of the user, in the order they were saved")
+
+	errStop := errors.New("stop")
+	var listed int
+	err = r.ListUserURLs(ctx, userID, func(model.UserURL) error {
+		listed++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, listed, "listing stops at the first error")
+
+	require.NoError(t, r.ListUserURLs(ctx, uuid.NewString(), func(model.UserURL) error {
+		t.Error("unknown user has no urls")
+		return nil
+	}))
+}
+
+func testReassignURLs(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	from, to := uuid.NewString(), uuid.NewString()
+	save(t, r, newURL("aaa", from), newURL("bbb", from), newURL("ccc", to))
+
+	reassigned, err := r.ReassignURLs(ctx, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), reassigned)
+
+	urls, err := r.GetURLsByUserID(ctx, from)
+	require.NoError(t, err)
+	assert.Empty(t, urls)
+
+	urls, err = r.GetURLsByUserID(ctx, to)
+	require.NoError(t, err)
+	assert.Len(t, urls, 3)
+	assert.Equal(t, to, get(t, r, "aaa").UserID)
+
+	reassigned, err = r.ReassignURLs(ctx, from, to)
+	require.NoError(t, err)
+	assert.Zero(t, reassigned)
+}
+
+func testSetDisabled(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	save(t, r, newURL("aaa", userID), newURL("bbb", userID))
+	_, err := r.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: userID, ShortURLs: []string{"bbb"}})
+	require.NoError(t, err)
+
+	updated, err := r.SetDisabled(ctx, []string{"aaa", "bbb", "unknown"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), updated, "unknown and deleted urls are skipped")
+	assert.True(t, get(t, r, "aaa").IsDisabled)
+	assert.False(t, get(t, r, "bbb").IsDisabled)
+
+	updated, err = r.SetDisabled(ctx, []string{"aaa"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), updated)
+	assert.False(t, get(t, r, "aaa").IsDisabled)
+}
+
+func testForceDeleteBatch(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	save(t, r, newURL("aaa", uuid.NewString()), newURL("bbb", uuid.NewString()))
+
+	deleted, err := r.ForceDeleteBatch(ctx, []string{"aaa", "bbb", "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted, "urls of any user are deleted")
+	assert.True(t, get(t, r, "aaa").IsDeleted)
+	assert.True(t, get(t, r, "bbb").IsDeleted)
+
+	deleted, err = r.ForceDeleteBatch(ctx, []string{"aaa"})
+	require.NoError(t, err)
+	assert.Zero(t, deleted, "already deleted urls are not counted")
+}
+
+func testTransferURLs(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	owner, to := uuid.NewString(), uuid.NewString()
+	save(t, r, newURL("aaa", owner), newURL("bbb", owner), newURL("ccc", owner))
+	_, err := r.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: owner, ShortURLs: []string{"ccc"}})
+	require.NoError(t, err)
+
+	transferred, err := r.TransferURLs(ctx, []string{"aaa", "ccc", "unknown"}, to)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), transferred, "unknown and deleted urls are skipped")
+	assert.Equal(t, to, get(t, r, "aaa").UserID)
+	assert.Equal(t, owner, get(t, r, "bbb").UserID)
+	assert.Equal(t, owner, get(t, r, "ccc").UserID)
+}
+
+func testListURLs(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	a, b, c := newURL("aaa", userID), newURL("bbb", uuid.NewString()), newURL("ccc", userID)
+	save(t, r, b, a, c)
+
+	_, err := r.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: userID, ShortURLs: []string{"aaa"}})
+	require.NoError(t, err)
+	_, err = r.SetDisabled(ctx, []string{"ccc"}, true)
+	require.NoError(t, err)
+	a.IsDeleted, c.IsDisabled = true, true
+
+	assert.Equal(t, []model.URL{b, a, c}, listURLs(t, r), "every url, in the order they were saved")
+
+	errStop := errors.New("stop")
+	var listed int
+	err = r.ListURLs(ctx, func(model.URL) error {
+		listed++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, listed, "listing stops at the first error")
+}
+
+func testInternalStats(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+
+	stats, err := r.GetInternalStats(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, stats.URLs)
+	assert.Zero(t, stats.Users)
+
+	first, second := uuid.NewString(), uuid.NewString()
+	save(t, r, newURL("aaa", first), newURL("bbb", first), newURL("ccc", second))
+	_, err = r.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: second, ShortURLs: []string{"ccc"}})
+	require.NoError(t, err)
+
+	stats, err = r.GetInternalStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.URLs, "deleted urls are counted")
+	assert.Equal(t, 2, stats.Users)
+}
+
+func testConcurrentSaves(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+	for i := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u := newURL(fmt.Sprintf("url%d", i), userID)
+			errs <- r.Save(ctx, u.ShortURLID, u.OriginalURL, u.UserID)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	urls, err := r.GetURLsByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, urls, concurrency)
+}
+
+func testConcurrentConflictingSaves(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	u := newURL("aaa", uuid.NewString())
+
+	var saved, conflicts atomic.Int32
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := r.Save(ctx, u.ShortURLID, u.OriginalURL, u.UserID)
+			switch {
+			case err == nil:
+				saved.Add(1)
+			case errors.Is(err, shrterr.ErrOriginalURLAlreadyExists):
+				conflicts.Add(1)
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), saved.Load(), "exactly one save succeeds")
+	assert.Equal(t, int32(concurrency-1), conflicts.Load())
+}
+
+func testConcurrentReadsAndDeletions(t *testing.T, r repository.Repository) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	shortURLs := make([]string, concurrency)
+	for i := range shortURLs {
+		u := newURL(fmt.Sprintf("url%d", i), userID)
+		save(t, r, u)
+		shortURLs[i] = u.ShortURLID
+	}
+
+	var deleted atomic.Int64
+	var wg sync.WaitGroup
+	for i := range concurrency {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			n, err := r.DeleteBatch(ctx, model.BatchDeleteShortURLs{UserID: userID, ShortURLs: shortURLs[i:]})
+			assert.NoError(t, err)
+			deleted.Add(n)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := r.Get(ctx, shortURLs[i])
+			assert.NoError(t, err)
+			_, err = r.GetURLsByUserID(ctx, userID)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(concurrency), deleted.Load(), "every url is deleted exactly once")
+
+	for _, u := range listURLs(t, r) {
+		assert.True(t, u.IsDeleted)
+	}
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// tracedRepository is a Repository decorator starting a span around every call of the wrapped Repository.
+// Spans are named after the Repository method, carry the storage type and record the returned error.
+type tracedRepository struct {
+	next Repository
+}
+
+// WithTracing returns a Repository tracing every call of r. Code relying on the concrete type
+// of a repository must keep a reference to r itself.
+func WithTracing(r Repository) Repository {
+	return &tracedRepository{next: r}
+}
+
+func (t *tracedRepository) start(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "Repository."+method,
+		trace.WithAttributes(attribute.String("storage.type", t.next.GetType())),
+	)
+}
+
+// GetType returns the storage type of the wrapped Repository.
+func (t *tracedRepository) GetType() string {
+	return t.next.GetType()
+}
+
+func (t *tracedRepository) Init(ctx context.Context, cfg config.Config, l *zap.Logger) (err error) {
+	ctx, span := t.start(ctx, "Init")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Init(ctx, cfg, l)
+}
+
+func (t *tracedRepository) Save(ctx context.Context, shortURLID string, originalURL string, userID string) (err error) {
+	ctx, span := t.start(ctx, "Save")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Save(ctx, shortURLID, originalURL, userID)
+}
+
+func (t *tracedRepository) SaveBatch(ctx context.Context, urls []model.URLWithCorrelation, userID string) (saved bool, err error) {
+	ctx, span := t.start(ctx, "SaveBatch")
+	defer func() { tracing.End(span, err) }()
+	return t.next.SaveBatch(ctx, urls, userID)
+}
+
+func (t *tracedRepository) DeleteBatch(ctx context.Context, shortURLs model.BatchDeleteShortURLs) (deleted int64, err error) {
+	ctx, span := t.start(ctx, "DeleteBatch")
+	defer func() { tracing.End(span, err) }()
+	return t.next.DeleteBatch(ctx, shortURLs)
+}
+
+func (t *tracedRepository) Get(ctx context.Context, shortURL string) (url model.URL, err error) {
+	ctx, span := t.start(ctx, "Get")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Get(ctx, shortURL)
+}
+
+func (t *tracedRepository) GetURLsByUserID(ctx context.Context, userID string) (urls []model.UserURL, err error) {
+	ctx, span := t.start(ctx, "GetURLsByUserID")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetURLsByUserID(ctx, userID)
+}
+
+func (t *tracedRepository) ListUserURLs(ctx context.Context, userID string, fn func(model.UserURL) error) (err error) {
+	ctx, span := t.start(ctx, "ListUserURLs")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ListUserURLs(ctx, userID, fn)
+}
+
+func (t *tracedRepository) ReassignURLs(ctx context.Context, fromUserID string, toUserID string) (affected int64, err error) {
+	ctx, span := t.start(ctx, "ReassignURLs")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ReassignURLs(ctx, fromUserID, toUserID)
+}
+
+func (t *tracedRepository) SetDisabled(ctx context.Context, shortURLs []string, disabled bool) (affected int64, err error) {
+	ctx, span := t.start(ctx, "SetDisabled")
+	defer func() { tracing.End(span, err) }()
+	return t.next.SetDisabled(ctx, shortURLs, disabled)
+}
+
+func (t *tracedRepository) ForceDeleteBatch(ctx context.Context, shortURLs []string) (affected int64, err error) {
+	ctx, span := t.start(ctx, "ForceDeleteBatch")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ForceDeleteBatch(ctx, shortURLs)
+}
+
+func (t *tracedRepository) TransferURLs(ctx context.Context, shortURLs []string, toUserID string) (affected int64, err error) {
+	ctx, span := t.start(ctx, "TransferURLs")
+	defer func() { tracing.End(span, err) }()
+	return t.next.TransferURLs(ctx, shortURLs, toUserID)
+}
+
+func (t *tracedRepository) GetInternalStats(ctx context.Context) (stats *dto.InternalStatsResp, err error) {
+	ctx, span := t.start(ctx, "GetInternalStats")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetInternalStats(ctx)
+}
+
+func (t *tracedRepository) ListURLs(ctx context.Context, fn func(model.URL) error) (err error) {
+	ctx, span := t.start(ctx, "ListURLs")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ListURLs(ctx, fn)
+}
@@ -0,0 +1,61 @@
+// Package requestid assigns an identifier to every HTTP request and gRPC call, so that the log
+// lines, responses and error bodies of one request can be correlated. An identifier supplied by
+// the client is kept when it is well-formed, otherwise a new one is generated.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Header is the HTTP header carrying the request ID, in requests and responses.
+	Header = "X-Request-ID"
+
+	// MetadataKey is the gRPC metadata key carrying the request ID, in incoming metadata and response headers.
+	MetadataKey = "x-request-id"
+
+	// maxLength is the maximum length of a request ID supplied by a client.
+	maxLength = 128
+)
+
+type idCtxKey struct{}
+
+// New generates a new request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// Resolve returns the request ID supplied by the client when it is valid, or a new one otherwise.
+func Resolve(supplied string) string {
+	if Valid(supplied) {
+		return supplied
+	}
+	return New()
+}
+
+// Valid reports whether id is acceptable as a request ID: non-empty, at most maxLength long and
+// made of printable ASCII characters, so that it cannot break log lines or response headers.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// WithID returns a copy of ctx carrying the request ID.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idCtxKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or an empty string if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(idCtxKey{}).(string)
+	return id
+}
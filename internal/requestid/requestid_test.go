@@ -0,0 +1,43 @@
+package requestid_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		supplied string
+		keep     bool
+	}{
+		{name: "keeps valid id", supplied: "req-123", keep: true},
+		{name: "generates when empty", supplied: ""},
+		{name: "generates when too long", supplied: strings.Repeat("a", 129)},
+		{name: "generates when containing spaces", supplied: "req 123"},
+		{name: "generates when containing newline", supplied: "req\n123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := requestid.Resolve(tt.supplied)
+			assert.True(t, requestid.Valid(id))
+			if tt.keep {
+				assert.Equal(t, tt.supplied, id)
+			} else {
+				assert.NotEqual(t, tt.supplied, id)
+			}
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.Empty(t, requestid.FromContext(context.Background()))
+
+	ctx := requestid.WithID(context.Background(), "req-123")
+	assert.Equal(t, "req-123", requestid.FromContext(ctx))
+}
@@ -2,22 +2,44 @@
 package router
 
 import (
+	"net/http"
+
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
 	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/errorpage"
+	"github.com/mp1947/ya-url-shortener/internal/gateway"
 	handler "github.com/mp1947/ya-url-shortener/internal/handler/http"
+	"github.com/mp1947/ya-url-shortener/internal/health"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
 	im "github.com/mp1947/ya-url-shortener/internal/middleware"
+	"github.com/mp1947/ya-url-shortener/internal/oidc"
+	"github.com/mp1947/ya-url-shortener/internal/ratelimit"
+	"github.com/mp1947/ya-url-shortener/internal/reload"
 	"github.com/mp1947/ya-url-shortener/internal/repository"
 	"github.com/mp1947/ya-url-shortener/internal/repository/database"
 	"github.com/mp1947/ya-url-shortener/internal/service"
+	"github.com/mp1947/ya-url-shortener/internal/tracing"
 	pm "github.com/mp1947/ya-url-shortener/pkg/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.uber.org/zap"
 )
 
 // CreateRouter initializes and configures a new Gin router with the provided configuration, service, repository, and logger.
-// It sets up middleware for recovery, authentication, logging, and gzip compression.
-// The function registers HTTP handlers for URL shortening, retrieval, batch operations, user-specific endpoints, and health checks.
+// It sets up middleware for recovery, tracing, request IDs, client IP resolution, metrics, authentication, logging, and gzip compression.
+// The function registers HTTP handlers for URL shortening, retrieval, batch operations, user-specific endpoints including bulk import and export, and health checks.
+// The /healthz liveness and /readyz readiness endpoints report the state of the given health checker,
+// the /metrics endpoint serves Prometheus metrics to clients from the trusted subnets.
 // If the repository type is "database", a /ping endpoint is added for database connectivity checks.
+// If an OpenID Connect provider is given, the /auth/login and /auth/callback endpoints are added.
+// The trusted subnets, trusted proxies and admin API keys are those of the given reloadable settings,
+// or of c when none are given. The /api/admin group exposes operator endpoints guarded by the admin role,
+// including the /api/admin/log/level endpoint changing the logger level of the settings when they have one.
+// If a gateway handler is given, it serves the JSON/HTTP transcoding of the gRPC API under /v2/.
+// If a rate limiter is given, it limits the requests of every client IP, except those of the health
+// checks and metrics endpoints.
+// Browsers following unknown or deleted short URLs are answered with the given error pages, or with the
+// embedded default ones when none are given.
 // The function also registers pprof endpoints for profiling and debugging.
 // Returns the configured *gin.Engine instance.
 func CreateRouter(
@@ -25,32 +47,93 @@ func CreateRouter(
 	s service.Service,
 	repo repository.Repository,
 	l *zap.Logger,
+	oidcProvider *oidc.Provider,
+	gw http.Handler,
+	hc *health.Checker,
+	rs *reload.Settings,
+	limiter *ratelimit.Limiter,
+	pages *errorpage.Pages,
 ) *gin.Engine {
 
+	if rs == nil {
+		rs = reload.NewSettings(&c, nil, limiter)
+	}
+
+	if pages == nil {
+		pages = errorpage.Default()
+	}
+
 	r := gin.New()
 
+	// The client IP is resolved by ClientIPMiddleware, gin must not trust forwarding headers on its own.
+	_ = r.SetTrustedProxies(nil)
+
 	r.Use(gin.Recovery())
+	r.Use(otelgin.Middleware(tracing.ServiceName))
+	r.Use(im.RequestIDMiddleware(l))
+	r.Use(im.ClientIPMiddleware(rs.Resolver))
+	r.Use(pm.MetricsMiddleware())
+	if limiter != nil {
+		r.Use(im.RateLimitMiddleware(l, limiter, "/healthz", "/readyz", "/metrics"))
+	}
 	r.Use(im.AuthMiddleware(l))
 	r.Use(pm.LoggerMiddleware(l))
 	r.Use(pm.GzipMiddleware())
 
-	h := handler.HandlerService{Service: s}
+	h := handler.HandlerService{Service: s, Pages: pages}
 
 	r.Any("/", h.ShortenURL)
 	r.Any("/:id", h.GetOriginalURLByID)
 
+	if hc == nil {
+		hc = health.NewChecker()
+	}
+	r.GET("/healthz", h.Healthz)
+	r.GET("/readyz", h.Readyz(hc))
+	r.GET("/metrics", im.WithAuthorizedIP(l, rs.TrustedSubnets, gin.WrapH(metrics.Handler())))
+
 	if repo.GetType() == "database" {
 		r.GET("/ping", h.Ping(repo.(*database.Database)))
 	}
 
+	if oidcProvider != nil {
+		authGroup := r.Group("/auth")
+		authGroup.GET("/login", h.OIDCLogin(oidcProvider))
+		authGroup.GET("/callback", h.OIDCCallback(oidcProvider))
+	}
+
 	api := r.Group("/api")
 	api.POST("/shorten", h.JSONShortenURL)
 	api.POST("/shorten/batch", h.BatchShortenURL)
 
 	api.GET("/user/urls", h.GetUserURLs)
 	api.DELETE("/user/urls", h.DeleteUserURLs)
+	api.GET("/user/urls/deletions/:id", h.GetDeletionJob)
+	api.POST("/user/urls/import", h.ImportUserURLs)
+	api.GET("/user/urls/export", h.ExportUserURLs)
+
+	api.GET("/internal/stats", im.WithAuthorizedIP(l, rs.TrustedSubnets, h.InternalStats))
 
-	api.GET("/internal/stats", im.WithAuthorizedIP(l, c, h.InternalStats))
+	admin := api.Group("/admin", im.AdminOnly(l, rs.AdminAPIKeys))
+	admin.GET("/urls/:id", h.AdminGetURL)
+	admin.POST("/urls/:id/disable", h.AdminDisableURL)
+	admin.POST("/urls/:id/enable", h.AdminEnableURL)
+	admin.DELETE("/urls", h.AdminDeleteURLs)
+	admin.POST("/urls/reassign", h.AdminReassignURLs)
+	admin.GET("/users/:user_id/urls", h.AdminGetUserURLs)
+	admin.GET("/audit", h.AdminQueryAudit)
+
+	if rs.LogLevel != nil {
+		admin.GET("/log/level", h.AdminGetLogLevel(*rs.LogLevel))
+		admin.PUT("/log/level", h.AdminSetLogLevel(*rs.LogLevel))
+	}
+
+	if gw != nil {
+		r.Any("/v2/*path", func(c *gin.Context) {
+			c.Request = c.Request.WithContext(gateway.WithToken(c.Request.Context(), c.GetString("token")))
+			gw.ServeHTTP(c.Writer, c.Request)
+		})
+	}
 
 	pprof.Register(r, "debug/pprof")
 
@@ -2,15 +2,20 @@ package router_test
 
 import (
 	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mp1947/ya-url-shortener/config"
 	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
 	"github.com/mp1947/ya-url-shortener/internal/repository/inmemory"
 	"github.com/mp1947/ya-url-shortener/internal/router"
 	"github.com/mp1947/ya-url-shortener/internal/service"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateRouter(t *testing.T) {
@@ -29,7 +34,76 @@ func TestCreateRouter(t *testing.T) {
 		err = storage.Init(context.Background(), cfg, l)
 		assert.NoError(t, err)
 		service := service.ShortenService{Storage: storage, Logger: l, Cfg: &cfg}
-		r := router.CreateRouter(cfg, &service, storage, l)
+		r := router.CreateRouter(cfg, &service, storage, l, nil, nil, nil, nil, nil, nil)
 		assert.IsType(t, &gin.Engine{}, r)
 	})
+
+	t.Run("mount gateway under v2", func(t *testing.T) {
+		listenAddr := ":8080"
+		baseURL := "http://localhost:8080"
+		fileStoragePath := "./test.out"
+		cfg := config.Config{
+			HTTPServerAddress: &listenAddr,
+			BaseHTTPURL:       &baseURL,
+			FileStoragePath:   &fileStoragePath,
+		}
+		storage := &inmemory.Memory{}
+		l, err := logger.InitLogger()
+		assert.NoError(t, err)
+		err = storage.Init(context.Background(), cfg, l)
+		assert.NoError(t, err)
+		service := service.ShortenService{Storage: storage, Logger: l, Cfg: &cfg}
+
+		gw := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+		r := router.CreateRouter(cfg, &service, storage, l, nil, gw, nil, nil, nil, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/user/urls", nil))
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	})
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	listenAddr := ":8080"
+	baseURL := "http://localhost:8080"
+	fileStoragePath := "./test.out"
+	_, trusted, _ := net.ParseCIDR("192.168.1.0/24")
+	cfg := config.Config{
+		HTTPServerAddress: &listenAddr,
+		BaseHTTPURL:       &baseURL,
+		FileStoragePath:   &fileStoragePath,
+		TrustedSubnets:    []*net.IPNet{trusted},
+	}
+	storage := &inmemory.Memory{}
+	l, err := logger.InitLogger()
+	require.NoError(t, err)
+	require.NoError(t, storage.Init(context.Background(), cfg, l))
+	service := service.ShortenService{Storage: storage, Logger: l, Cfg: &cfg}
+
+	metrics.SetBuildInfo("v1.2.3", "abc123", "2025-01-01")
+
+	r := router.CreateRouter(cfg, &service, storage, l, nil, nil, nil, nil, nil, nil)
+
+	scrape := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, http.StatusUnauthorized, scrape("203.0.113.5:40000").Code)
+
+	w = scrape("192.168.1.10:40000")
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, `shortener_build_info{commit="abc123",date="2025-01-01",version="v1.2.3"} 1`)
+	assert.Contains(t, body, `shortener_http_requests_total{method="GET",route="/healthz",status="200"}`)
+	assert.Contains(t, body, `shortener_http_requests_total{method="GET",route="/metrics",status="401"}`)
 }
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mp1947/ya-url-shortener/internal/audit"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"go.uber.org/zap"
+)
+
+// AdminGetURL looks up any shortened URL together with its owner and state flags.
+// Returns shrterr.ErrShortURLNotFound if nothing is stored under shortURLID.
+// The lookup is recorded in the audit trail.
+func (s *ShortenService) AdminGetURL(
+	ctx context.Context,
+	shortURLID string,
+) (dto.AdminURL, error) {
+	url, err := s.Storage.Get(ctx, shortURLID)
+
+	s.recordAudit(ctx, audit.OpAdminGetURL, []string{shortURLID}, "", err)
+
+	if err != nil {
+		s.log(ctx).Warn("admin: error getting url", zap.String("short_url_id", shortURLID), zap.Error(err))
+		return dto.AdminURL{}, err
+	}
+
+	return dto.AdminURL{
+		ShortURLID:  url.ShortURLID,
+		ShortURL:    generateShortURL(*s.Cfg.BaseHTTPURL, url.ShortURLID),
+		OriginalURL: url.OriginalURL,
+		UserID:      url.UserID,
+		IsDeleted:   url.IsDeleted,
+		IsDisabled:  url.IsDisabled,
+	}, nil
+}
+
+// AdminGetUserURLs lists every shortened URL owned by userID, including deleted and disabled ones.
+// The listing is recorded in the audit trail.
+func (s *ShortenService) AdminGetUserURLs(
+	ctx context.Context,
+	userID string,
+) ([]dto.AdminURL, error) {
+	userURLs, err := s.Storage.GetURLsByUserID(ctx, userID)
+
+	s.recordAudit(ctx, audit.OpAdminGetUserURLs, nil, userID, err)
+
+	if err != nil {
+		s.log(ctx).Warn("admin: error getting user urls", zap.String("target_user_id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	result := make([]dto.AdminURL, len(userURLs))
+
+	for i, v := range userURLs {
+		result[i] = dto.AdminURL{
+			ShortURLID:  v.ShortURLID,
+			ShortURL:    generateShortURL(*s.Cfg.BaseHTTPURL, v.ShortURLID),
+			OriginalURL: v.OriginalURL,
+			UserID:      userID,
+			IsDeleted:   v.IsDeleted,
+			IsDisabled:  v.IsDisabled,
+		}
+	}
+
+	return result, nil
+}
+
+// AdminSetURLsDisabled disables or re-enables the given short URLs regardless of their owner.
+// Disabled URLs are kept in storage but are no longer redirected.
+// Returns the number of affected URLs. The action is recorded in the audit trail.
+func (s *ShortenService) AdminSetURLsDisabled(
+	ctx context.Context,
+	shortURLIDs []string,
+	disabled bool,
+) (int64, error) {
+	affected, err := s.Storage.SetDisabled(ctx, shortURLIDs, disabled)
+
+	operation := audit.OpAdminEnableURLs
+	if disabled {
+		operation = audit.OpAdminDisableURLs
+	}
+	s.recordAudit(ctx, operation, shortURLIDs, "", err)
+
+	if err != nil {
+		s.log(ctx).Warn("admin: error changing disabled flag", zap.Bool("disabled", disabled), zap.Error(err))
+		return 0, err
+	}
+
+	return affected, nil
+}
+
+// AdminDeleteURLs synchronously deletes the given short URLs regardless of their owner.
+// Returns the number of deleted URLs. The action is recorded in the audit trail.
+func (s *ShortenService) AdminDeleteURLs(
+	ctx context.Context,
+	shortURLIDs []string,
+) (int64, error) {
+	affected, err := s.Storage.ForceDeleteBatch(ctx, shortURLIDs)
+
+	s.recordAudit(ctx, audit.OpAdminDeleteURLs, shortURLIDs, "", err)
+
+	if err != nil {
+		s.log(ctx).Warn("admin: error force-deleting urls", zap.Error(err))
+		return 0, err
+	}
+
+	return affected, nil
+}
+
+// AdminReassignURLs transfers the given short URLs to toUserID regardless of their current owner.
+// Returns the number of transferred URLs. The action is recorded in the audit trail.
+func (s *ShortenService) AdminReassignURLs(
+	ctx context.Context,
+	shortURLIDs []string,
+	toUserID string,
+) (int64, error) {
+	affected, err := s.Storage.TransferURLs(ctx, shortURLIDs, toUserID)
+
+	s.recordAudit(ctx, audit.OpAdminReassign, shortURLIDs, toUserID, err)
+
+	if err != nil {
+		s.log(ctx).Warn("admin: error reassigning urls", zap.String("to_user_id", toUserID), zap.Error(err))
+		return 0, err
+	}
+
+	return affected, nil
+}
+
+// AdminQueryAudit returns the newest audit entries matching the filter.
+// An empty result is returned when the audit trail is not configured.
+func (s *ShortenService) AdminQueryAudit(
+	ctx context.Context,
+	filter audit.Filter,
+) ([]audit.Entry, error) {
+	if s.Audit == nil {
+		return []audit.Entry{}, nil
+	}
+
+	entries, err := s.Audit.Query(ctx, filter)
+	if err != nil {
+		s.log(ctx).Warn("admin: error querying audit entries", zap.Error(err))
+		return nil, err
+	}
+
+	return entries, nil
+}
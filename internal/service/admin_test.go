@@ -0,0 +1,185 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/audit"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/mocks"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testRecorder struct {
+	entries []audit.Entry
+}
+
+func (r *testRecorder) Record(ctx context.Context, e audit.Entry) error {
+	r.entries = append(r.entries, e)
+	return nil
+}
+
+func (r *testRecorder) Query(ctx context.Context, f audit.Filter) ([]audit.Entry, error) {
+	result := []audit.Entry{}
+	for _, e := range r.entries {
+		if f.Match(e) {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func TestAdminActions(t *testing.T) {
+	adminCtx := auth.WithActor(context.Background(), auth.Actor{
+		ID:     "apikey:abc",
+		Method: auth.MethodAPIKey,
+		Role:   auth.RoleAdmin,
+	})
+
+	t.Run("get url with owner", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ownerID := uuid.NewString()
+		mockStorage := mocks.NewMockRepository(ctrl)
+		mockStorage.EXPECT().
+			Get(gomock.Any(), "abc123").
+			Return(model.URL{
+				ShortURLID:  "abc123",
+				OriginalURL: "https://example.com",
+				UserID:      ownerID,
+				IsDisabled:  true,
+			}, nil).Times(1)
+
+		recorder := &testRecorder{}
+		s := initTestService(mockStorage)
+		s.Audit = recorder
+
+		url, err := s.AdminGetURL(adminCtx, "abc123")
+		require.NoError(t, err)
+		assert.Equal(t, ownerID, url.UserID)
+		assert.Equal(t, baseURL+"/abc123", url.ShortURL)
+		assert.True(t, url.IsDisabled)
+
+		require.Len(t, recorder.entries, 1)
+		assert.Equal(t, audit.OpAdminGetURL, recorder.entries[0].Operation)
+		assert.Equal(t, "apikey:abc", recorder.entries[0].ActorID)
+		assert.Equal(t, audit.ResultSuccess, recorder.entries[0].Result)
+	})
+
+	t.Run("get unknown url", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockRepository(ctrl)
+		mockStorage.EXPECT().
+			Get(gomock.Any(), "unknown").
+			Return(model.URL{}, shrterr.ErrShortURLNotFound).Times(1)
+
+		recorder := &testRecorder{}
+		s := initTestService(mockStorage)
+		s.Audit = recorder
+
+		_, err := s.AdminGetURL(adminCtx, "unknown")
+		assert.ErrorIs(t, err, shrterr.ErrShortURLNotFound)
+
+		require.Len(t, recorder.entries, 1)
+		assert.Equal(t, audit.ResultFailure, recorder.entries[0].Result)
+	})
+
+	t.Run("disable, delete and reassign are audited", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		newOwnerID := uuid.NewString()
+		shortURLs := []string{"abc123", "def456"}
+
+		mockStorage := mocks.NewMockRepository(ctrl)
+		mockStorage.EXPECT().SetDisabled(gomock.Any(), shortURLs, true).Return(int64(2), nil).Times(1)
+		mockStorage.EXPECT().ForceDeleteBatch(gomock.Any(), shortURLs).Return(int64(0), errors.New("db down")).Times(1)
+		mockStorage.EXPECT().TransferURLs(gomock.Any(), shortURLs, newOwnerID).Return(int64(2), nil).Times(1)
+
+		recorder := &testRecorder{}
+		s := initTestService(mockStorage)
+		s.Audit = recorder
+
+		affected, err := s.AdminSetURLsDisabled(adminCtx, shortURLs, true)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), affected)
+
+		_, err = s.AdminDeleteURLs(adminCtx, shortURLs)
+		assert.Error(t, err)
+
+		affected, err = s.AdminReassignURLs(adminCtx, shortURLs, newOwnerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), affected)
+
+		require.Len(t, recorder.entries, 3)
+		assert.Equal(t, audit.OpAdminDisableURLs, recorder.entries[0].Operation)
+		assert.Equal(t, audit.OpAdminDeleteURLs, recorder.entries[1].Operation)
+		assert.Equal(t, audit.ResultFailure, recorder.entries[1].Result)
+		assert.Equal(t, "db down", recorder.entries[1].Error)
+		assert.Equal(t, audit.OpAdminReassign, recorder.entries[2].Operation)
+		assert.Equal(t, newOwnerID, recorder.entries[2].TargetUser)
+	})
+}
+
+func TestAuditOfMutatingOperations(t *testing.T) {
+	userID := uuid.NewString()
+	userCtx := auth.WithActor(context.Background(), auth.Actor{
+		ID:       userID,
+		Method:   auth.MethodAnonymous,
+		ClientIP: "192.0.2.10",
+	})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRepository(ctrl)
+	mockStorage.EXPECT().Save(gomock.Any(), gomock.Any(), "https://example.com", userID).Return(nil).Times(1)
+	mockStorage.EXPECT().SaveBatch(gomock.Any(), gomock.Any(), userID).Return(false, nil).Times(1)
+	mockStorage.EXPECT().DeleteBatch(gomock.Any(), gomock.Any()).Return(int64(1), nil).Times(1)
+
+	recorder := &testRecorder{}
+	s := initTestService(mockStorage)
+	s.Audit = recorder
+
+	shortURL, err := s.ShortenURL(userCtx, "https://example.com", userID)
+	require.NoError(t, err)
+	shortURLID := strings.TrimPrefix(shortURL, baseURL+"/")
+
+	_, err = s.ShortenURLBatch(userCtx, []dto.BatchShortenRequest{
+		{CorrelationID: "1", OriginalURL: "https://example.org"},
+	}, userID)
+	require.NoError(t, err)
+
+	s.DeleteURLsBatch(userCtx, model.BatchDeleteShortURLs{UserID: userID, ShortURLs: []string{shortURLID}})
+	close(s.CommCh)
+	s.ProcessDeletions()
+
+	require.Len(t, recorder.entries, 3)
+
+	assert.Equal(t, audit.OpCreateURL, recorder.entries[0].Operation)
+	assert.Equal(t, []string{shortURLID}, recorder.entries[0].ShortURLs)
+	assert.Equal(t, audit.OpCreateURLBatch, recorder.entries[1].Operation)
+	assert.Equal(t, audit.OpDeleteURLs, recorder.entries[2].Operation)
+
+	for _, e := range recorder.entries {
+		assert.Equal(t, userID, e.ActorID)
+		assert.Equal(t, auth.MethodAnonymous, e.AuthMethod)
+		assert.Equal(t, "192.0.2.10", e.ClientIP)
+		assert.Equal(t, audit.ResultSuccess, e.Result)
+	}
+
+	entries, err := s.AdminQueryAudit(context.Background(), audit.Filter{ShortURL: shortURLID})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
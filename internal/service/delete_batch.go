@@ -2,49 +2,168 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/audit"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/jobs"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
 	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
 	"go.uber.org/zap"
 )
 
 // DeleteURLsBatch enqueues a batch of short URLs for deletion by sending them
 // to the service's communication channel. It logs the operation and does not
 // perform the deletion synchronously. The actual deletion is handled
-// asynchronously by another component listening on the channel.
+// asynchronously by another component listening on the channel, the ID of the
+// request is kept with the batch so that the deletion logs can be correlated with it.
+// Queued batches are reported by the deletion queue depth metric.
 //
 // Parameters:
 //   - ctx: context for cancellation and deadlines.
 //   - shortURLs: a batch of short URLs to be deleted.
+//
+// Returns the ID of the deletion job, whose status is recorded in the job store.
 func (s *ShortenService) DeleteURLsBatch(
 	ctx context.Context,
 	shortURLs model.BatchDeleteShortURLs,
-) {
-	s.Logger.Info(
+) string {
+	if actor, ok := auth.ActorFromContext(ctx); ok {
+		shortURLs.Actor = actor
+	}
+	shortURLs.RequestID = requestid.FromContext(ctx)
+	shortURLs.JobID = uuid.NewString()
+
+	s.putJob(ctx, newJob(shortURLs, time.Now().UTC()))
+
+	s.log(ctx).Debug(
 		"putting short urls to delete into channel",
-		zap.Any("data", shortURLs),
+		zap.Strings("short_urls", shortURLs.ShortURLs),
+		zap.String("job_id", shortURLs.JobID),
 	)
+	metrics.DeletionQueueDepth.Inc()
 	s.CommCh <- shortURLs
 
+	return shortURLs.JobID
+}
+
+// GetDeletionJob returns the status of the deletion job with the given ID requested by userID.
+// Returns shrterr.ErrDeletionJobNotFound if the user has no such job or its status has expired.
+func (s *ShortenService) GetDeletionJob(
+	ctx context.Context,
+	jobID, userID string,
+) (dto.DeletionJob, error) {
+	if s.Jobs == nil {
+		return dto.DeletionJob{}, shrterr.ErrDeletionJobNotFound
+	}
+
+	status, ok, err := s.Jobs.Get(ctx, jobID)
+	if err != nil {
+		s.log(ctx).Error("error getting deletion job", zap.String("job_id", jobID), zap.Error(err))
+		return dto.DeletionJob{}, err
+	}
+	if !ok || status.UserID != userID {
+		return dto.DeletionJob{}, shrterr.ErrDeletionJobNotFound
+	}
+
+	return dto.DeletionJob{
+		ID:        status.ID,
+		State:     status.State,
+		ShortURLs: status.ShortURLs,
+		Deleted:   status.Deleted,
+		Error:     status.Error,
+		CreatedAt: status.CreatedAt,
+		UpdatedAt: status.UpdatedAt,
+	}, nil
+}
+
+// newJob returns the status of the pending deletion job of data.
+func newJob(data model.BatchDeleteShortURLs, now time.Time) jobs.Status {
+	return jobs.Status{
+		ID:        data.JobID,
+		UserID:    data.UserID,
+		State:     jobs.StatePending,
+		ShortURLs: len(data.ShortURLs),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// updateJob applies update to the recorded status of the deletion job of data, or to a new one when
+// the status has expired or cannot be read, and records it.
+func (s *ShortenService) updateJob(ctx context.Context, data model.BatchDeleteShortURLs, update func(*jobs.Status)) {
+	if s.Jobs == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	status, ok, err := s.Jobs.Get(ctx, data.JobID)
+	if err != nil || !ok {
+		status = newJob(data, now)
+	}
+
+	update(&status)
+	status.UpdatedAt = now
+	s.putJob(ctx, status)
+}
+
+// putJob records the status of a deletion job. Errors of the job store are logged, the
+// deletion does not depend on its status being recorded.
+func (s *ShortenService) putJob(ctx context.Context, status jobs.Status) {
+	if s.Jobs == nil {
+		return
+	}
+	if err := s.Jobs.Put(ctx, status); err != nil {
+		s.log(ctx).Warn("error recording deletion job status", zap.String("job_id", status.ID), zap.Error(err))
+	}
 }
 
 // ProcessDeletions starts a goroutine that listens for deletion requests on the CommCh channel.
 // For each batch of data received, it attempts to delete the corresponding short URLs from the storage.
 // The method logs the start of processing, each received deletion request, any errors encountered during deletion,
 // and the result of each deletion operation, including the number of rows deleted and the user ID associated with the request.
+// The logs of a batch, and those of the storage deleting it, carry the ID of the request that queued it.
+// The processing time of each batch is observed in the deletion duration metric, and the status of its
+// deletion job is updated when the processing starts and ends.
 func (s *ShortenService) ProcessDeletions() {
 	s.Logger.Info("starting deletions processing goroutine")
 	for data := range s.CommCh {
+		metrics.DeletionQueueDepth.Dec()
 		ctx, cancel := context.WithCancel(context.Background())
-		s.Logger.Info("received new data for deletion", zap.Any("data", data))
+		ctx = requestid.WithID(ctx, data.RequestID)
+		ctx = logger.WithContext(ctx, s.Logger.With(
+			zap.String("request_id", data.RequestID),
+			zap.String("user_id", data.UserID),
+		))
+		s.log(ctx).Debug("received new data for deletion", zap.Strings("short_urls", data.ShortURLs))
+		s.updateJob(ctx, data, func(job *jobs.Status) {
+			job.State = jobs.StateRunning
+		})
+
+		t := time.Now()
 		rowsDeleted, err := s.Storage.DeleteBatch(ctx, data)
+		metrics.DeletionDuration.Observe(time.Since(t).Seconds())
+
+		s.updateJob(ctx, data, func(job *jobs.Status) {
+			job.State, job.Deleted = jobs.StateDone, rowsDeleted
+			if err != nil {
+				job.State, job.Error = jobs.StateFailed, shrterr.Classify(err).Message
+			}
+		})
+
+		s.recordAudit(auth.WithActor(ctx, data.Actor), audit.OpDeleteURLs, data.ShortURLs, data.UserID, err)
 		if err != nil {
-			s.Logger.Warn("error batch-deleting short urls", zap.Error(err))
+			s.log(ctx).Warn("error batch-deleting short urls", zap.Error(err))
 			cancel()
 		}
-		s.Logger.Info(
+		s.log(ctx).Info(
 			"data has been deleted from the database",
-			zap.Any("data", data.ShortURLs),
-			zap.String("user_id", data.UserID),
+			zap.Strings("short_urls", data.ShortURLs),
 			zap.Int64("rows_deleted", rowsDeleted),
 		)
 		cancel()
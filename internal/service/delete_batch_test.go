@@ -2,14 +2,19 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/jobs"
 	"github.com/mp1947/ya-url-shortener/internal/mocks"
 	"github.com/mp1947/ya-url-shortener/internal/model"
 	"github.com/mp1947/ya-url-shortener/internal/repository/inmemory"
+	"github.com/mp1947/ya-url-shortener/internal/requestid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.uber.org/mock/gomock"
 )
@@ -29,7 +34,8 @@ func TestDeleteURLsBatch(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 		defer cancel()
 
-		s.DeleteURLsBatch(ctx, expected)
+		expected.JobID = s.DeleteURLsBatch(ctx, expected)
+		assert.NotEmpty(t, expected.JobID)
 
 		select {
 		case actual := <-s.CommCh:
@@ -38,6 +44,20 @@ func TestDeleteURLsBatch(t *testing.T) {
 			t.Fatal("timeout: value was not written to channel")
 		}
 	})
+
+	t.Run("keeps request id", func(t *testing.T) {
+		s := initTestService(&inmemory.Memory{})
+		ctx := requestid.WithID(context.Background(), "req-delete")
+
+		s.DeleteURLsBatch(ctx, model.BatchDeleteShortURLs{ShortURLs: []string{"abc123"}})
+
+		select {
+		case actual := <-s.CommCh:
+			assert.Equal(t, "req-delete", actual.RequestID)
+		case <-time.After(time.Second):
+			t.Fatal("timeout: value was not written to channel")
+		}
+	})
 }
 
 func TestProcessDeletions(t *testing.T) {
@@ -47,13 +67,17 @@ func TestProcessDeletions(t *testing.T) {
 	testData := model.BatchDeleteShortURLs{
 		UserID:    "user42",
 		ShortURLs: []string{"abc", "xyz"},
+		RequestID: "req42",
 	}
 
 	mockStorage := mocks.NewMockRepository(ctrl)
 
 	mockStorage.EXPECT().
 		DeleteBatch(gomock.Any(), testData).
-		Return(int64(2), nil).Times(1)
+		DoAndReturn(func(ctx context.Context, _ model.BatchDeleteShortURLs) (int64, error) {
+			assert.Equal(t, "req42", requestid.FromContext(ctx))
+			return 2, nil
+		}).Times(1)
 
 	s := initTestService(mockStorage)
 
@@ -74,3 +98,54 @@ func TestProcessDeletions(t *testing.T) {
 	}
 
 }
+
+func TestDeletionJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRepository(ctrl)
+	mockStorage.EXPECT().DeleteBatch(gomock.Any(), gomock.Any()).Return(int64(1), nil).Times(1)
+	mockStorage.EXPECT().DeleteBatch(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db down")).Times(1)
+
+	s := initTestService(mockStorage)
+	s.Jobs = jobs.NewLocal(10, time.Minute)
+	ctx := context.Background()
+
+	doneID := s.DeleteURLsBatch(ctx, model.BatchDeleteShortURLs{UserID: "user42", ShortURLs: []string{"abc", "xyz"}})
+
+	job, err := s.GetDeletionJob(ctx, doneID, "user42")
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatePending, job.State)
+	assert.Equal(t, 2, job.ShortURLs)
+
+	_, err = s.GetDeletionJob(ctx, doneID, "someone-else")
+	assert.ErrorIs(t, err, shrterr.ErrDeletionJobNotFound)
+
+	_, err = s.GetDeletionJob(ctx, "unknown", "user42")
+	assert.ErrorIs(t, err, shrterr.ErrDeletionJobNotFound)
+
+	done := make(chan struct{})
+	go func() {
+		s.ProcessDeletions()
+		close(done)
+	}()
+
+	failedID := s.DeleteURLsBatch(ctx, model.BatchDeleteShortURLs{UserID: "user42", ShortURLs: []string{"def"}})
+	close(s.CommCh)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 30):
+		t.Fatal("timeout waiting for ProcessDeletions to finish")
+	}
+
+	job, err = s.GetDeletionJob(ctx, doneID, "user42")
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StateDone, job.State)
+	assert.Equal(t, int64(1), job.Deleted)
+
+	job, err = s.GetDeletionJob(ctx, failedID, "user42")
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StateFailed, job.State)
+	assert.Equal(t, "internal server error", job.Error, "the cause of the failure is not disclosed")
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"go.uber.org/zap"
+)
+
+// ExportUserURLs calls fn for every shortened URL associated with userID, for a bulk export.
+// Unlike GetUserURLs, each entry carries its short URL ID and its deleted and disabled flags,
+// and entries are passed to fn as they are read from the storage rather than loaded at once.
+// It stops and returns the error of fn, or the error of the storage if listing fails.
+func (s *ShortenService) ExportUserURLs(
+	ctx context.Context,
+	userID string,
+	fn func(dto.ExportedURL) error,
+) error {
+	s.log(ctx).Info("exporting urls of user")
+
+	err := s.Storage.ListUserURLs(ctx, userID, func(v model.UserURL) error {
+		return fn(dto.ExportedURL{
+			ShortURLID:  v.ShortURLID,
+			ShortURL:    generateShortURL(*s.Cfg.BaseHTTPURL, v.ShortURLID),
+			OriginalURL: v.OriginalURL,
+			IsDeleted:   v.IsDeleted,
+			IsDisabled:  v.IsDisabled,
+		})
+	})
+	if err != nil {
+		s.log(ctx).Warn("error listing urls of user", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -15,17 +15,17 @@ func (s *ShortenService) GetOriginalURL(
 	shortURLID string,
 ) (model.URL, error) {
 
-	s.Logger.Info("processing short url with id", zap.String("short_url_id", shortURLID))
+	s.log(ctx).Debug("processing short url with id", zap.String("short_url_id", shortURLID))
 	data, err := s.Storage.Get(ctx, shortURLID)
 	if err != nil {
-		s.Logger.Warn(
+		s.log(ctx).Warn(
 			"error getting original_url by short_url_id",
-			zap.String("short_url", shortURLID),
+			zap.String("short_url_id", shortURLID),
 			zap.Error(err),
 		)
 		return model.URL{}, err
 	}
-	s.Logger.Info(
+	s.log(ctx).Debug(
 		"retrieved original_url by short_url_id",
 		zap.String("short_url_id", shortURLID),
 		zap.String("original_url", data.OriginalURL),
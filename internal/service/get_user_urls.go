@@ -23,15 +23,12 @@ func (s *ShortenService) GetUserURLs(
 	ctx context.Context,
 	userID string,
 ) ([]dto.ShortenURLsByUserID, error) {
-	s.Logger.Info(
-		"processing shorten urls for user",
-		zap.String("user_id", userID),
-	)
+	s.log(ctx).Debug("processing shorten urls for user")
 
 	userURLs, err := s.Storage.GetURLsByUserID(ctx, userID)
 
 	if err != nil {
-		s.Logger.Warn("error getting urls by user id", zap.Error(err))
+		s.log(ctx).Warn("error getting urls by user id", zap.Error(err))
 		return nil, err
 	}
 
@@ -1,9 +1,60 @@
 package service
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/internal/audit"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"go.uber.org/zap"
+)
 
 // generateShortURL constructs a full short URL by combining the provided base URL and short URL identifier.
 // It returns the resulting URL as a string in the format: baseURL/shortURLID.
 func generateShortURL(baseURL, shortURLID string) string {
 	return fmt.Sprintf("%s/%s", baseURL, shortURLID)
 }
+
+// log returns the request-scoped logger of ctx, which carries the request and user IDs,
+// or the logger of the service when ctx has none.
+func (s *ShortenService) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, s.Logger)
+}
+
+// recordAudit writes an audit entry for an operation performed by the actor stored in ctx.
+// Failing to record an entry is logged and never changes the outcome of the operation.
+func (s *ShortenService) recordAudit(
+	ctx context.Context,
+	operation string,
+	shortURLs []string,
+	targetUserID string,
+	opErr error,
+) {
+	if s.Audit == nil {
+		return
+	}
+
+	actor, _ := auth.ActorFromContext(ctx)
+
+	entry := audit.Entry{
+		Time:       time.Now().UTC(),
+		ActorID:    actor.ID,
+		AuthMethod: actor.Method,
+		ClientIP:   actor.ClientIP,
+		Operation:  operation,
+		ShortURLs:  shortURLs,
+		TargetUser: targetUserID,
+		Result:     audit.ResultSuccess,
+	}
+
+	if opErr != nil {
+		entry.Result = audit.ResultFailure
+		entry.Error = opErr.Error()
+	}
+
+	if err := s.Audit.Record(ctx, entry); err != nil {
+		s.log(ctx).Warn("error recording audit entry", zap.String("operation", operation), zap.Error(err))
+	}
+}
@@ -24,7 +24,7 @@ func (s *ShortenService) GetInternalStats(
 	resp, err := s.Storage.GetInternalStats(ctx)
 
 	if err != nil {
-		s.Logger.Error("received error from storage", zap.Error(err))
+		s.log(ctx).Error("received error from storage", zap.Error(err))
 		return nil, err
 	}
 
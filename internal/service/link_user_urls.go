@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mp1947/ya-url-shortener/internal/audit"
+	"go.uber.org/zap"
+)
+
+// LinkUserURLs transfers every shortened URL owned by fromUserID to toUserID.
+// It is used to keep the links created by an anonymous user after that user signs in
+// with an identity provider. Linking a user to itself is a no-op.
+//
+// Parameters:
+//   - ctx: context.Context for request-scoped values and cancellation.
+//   - fromUserID: the identifier of the user whose URLs are transferred.
+//   - toUserID: the identifier of the user receiving the URLs.
+//
+// Returns:
+//   - int64: the number of URLs that were linked to toUserID.
+//   - error: error encountered while updating the storage, if any.
+func (s *ShortenService) LinkUserURLs(
+	ctx context.Context,
+	fromUserID, toUserID string,
+) (int64, error) {
+	if fromUserID == toUserID {
+		return 0, nil
+	}
+
+	s.log(ctx).Info(
+		"linking user urls",
+		zap.String("from_user_id", fromUserID),
+		zap.String("to_user_id", toUserID),
+	)
+
+	linked, err := s.Storage.ReassignURLs(ctx, fromUserID, toUserID)
+	s.recordAudit(ctx, audit.OpLinkUserURLs, nil, toUserID, err)
+
+	if err != nil {
+		s.log(ctx).Warn("error linking user urls", zap.Error(err))
+		return 0, err
+	}
+
+	return linked, nil
+}
@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLinkUserURLs(t *testing.T) {
+	t.Run("test link user urls", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockRepository(ctrl)
+
+		fromUserID := uuid.NewString()
+		toUserID := uuid.NewString()
+
+		mockStorage.EXPECT().
+			ReassignURLs(gomock.Any(), fromUserID, toUserID).
+			Return(int64(3), nil).Times(1)
+
+		s := initTestService(mockStorage)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+
+		linked, err := s.LinkUserURLs(ctx, fromUserID, toUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), linked)
+	})
+
+	t.Run("test link user to itself", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStorage := mocks.NewMockRepository(ctrl)
+
+		userID := uuid.NewString()
+
+		s := initTestService(mockStorage)
+
+		linked, err := s.LinkUserURLs(context.Background(), userID, userID)
+		assert.NoError(t, err)
+		assert.Zero(t, linked)
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 
+	"github.com/mp1947/ya-url-shortener/internal/audit"
 	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
 	"github.com/mp1947/ya-url-shortener/internal/usecase"
 	"go.uber.org/zap"
 )
@@ -28,27 +30,29 @@ func (s *ShortenService) ShortenURL(
 	url string,
 	userID string,
 ) (string, error) {
-	s.Logger.Info("shortening incoming url", zap.String("original_url", url))
+	s.log(ctx).Debug("shortening incoming url", zap.String("original_url", url))
 
 	shortURLID := usecase.GenerateIDFromURL(url)
 
-	s.Logger.Info(
+	s.log(ctx).Debug(
 		"short_url id generated for url",
 		zap.String("short_url_id", shortURLID),
 		zap.String("original_url", url),
-		zap.String("user_id", userID),
 	)
 
 	err := s.Storage.Save(ctx, shortURLID, url, userID)
+	s.recordAudit(ctx, audit.OpCreateURL, []string{shortURLID}, "", err)
+
 	if errors.Is(err, shrterr.ErrOriginalURLAlreadyExists) {
-		s.Logger.Info(
+		metrics.ShortenConflictsTotal.Inc()
+		s.log(ctx).Debug(
 			"original_url already exists, returning error with short url",
 			zap.Error(err),
 			zap.String("original_url", url),
 		)
 		return generateShortURL(*s.Cfg.BaseHTTPURL, shortURLID), err
 	} else if err != nil {
-		s.Logger.Warn("unexpected error", zap.Error(err))
+		s.log(ctx).Warn("unexpected error", zap.Error(err))
 		return "", err
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mp1947/ya-url-shortener/internal/audit"
 	"github.com/mp1947/ya-url-shortener/internal/dto"
 	"github.com/mp1947/ya-url-shortener/internal/model"
 	"github.com/mp1947/ya-url-shortener/internal/usecase"
@@ -28,16 +29,15 @@ func (s *ShortenService) ShortenURLBatch(
 	batchData []dto.BatchShortenRequest,
 	userID string,
 ) ([]dto.BatchShortenResponse, error) {
-	s.Logger.Info(
-		"processing batch of urls",
-		zap.Any("batch_data", batchData),
-	)
+	s.log(ctx).Debug("processing batch of urls", zap.Int("batch_size", len(batchData)))
 
 	urls := make([]model.URLWithCorrelation, len(batchData))
+	shortURLIDs := make([]string, len(batchData))
 	result := make([]dto.BatchShortenResponse, len(batchData))
 
 	for i, v := range batchData {
 		shortURLID := usecase.GenerateIDFromURL(v.OriginalURL)
+		shortURLIDs[i] = shortURLID
 		urls[i] = model.URLWithCorrelation{
 			ShortURLID:    shortURLID,
 			OriginalURL:   v.OriginalURL,
@@ -50,12 +50,14 @@ func (s *ShortenService) ShortenURLBatch(
 	}
 
 	_, err := s.Storage.SaveBatch(ctx, urls, userID)
+	s.recordAudit(ctx, audit.OpCreateURLBatch, shortURLIDs, "", err)
+
 	if err != nil {
-		s.Logger.Warn("error while saving batch of urls", zap.Error(err))
+		s.log(ctx).Warn("error while saving batch of urls", zap.Error(err))
 		return nil, err
 	}
 
-	s.Logger.Info("batch of urls were successfully processed")
+	s.log(ctx).Debug("batch of urls were successfully processed")
 
 	return result, nil
 
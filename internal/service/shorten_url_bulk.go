@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+)
+
+// ShortenBulkURLs shortens a chunk of the URLs of a streamed or imported bulk request with ShortenURLBatch,
+// so that the chunk is stored and audited at once. A batch is rejected as a whole when one of its URLs
+// is already shortened, the URLs of such a chunk are then shortened one by one with ShortenURL, so that
+// the conflict only fails its own URL. The outcome of each URL is reported as a result rather than an error,
+// one per request in the same order: the short URL, including the one of a URL which was already shortened,
+// and the message of the error which prevented shortening it.
+func (s *ShortenService) ShortenBulkURLs(
+	ctx context.Context,
+	requests []dto.BatchShortenRequest,
+	userID string,
+) []dto.BulkShortenResult {
+	results := make([]dto.BulkShortenResult, len(requests))
+	if len(requests) == 0 {
+		return results
+	}
+
+	shortened, err := s.ShortenURLBatch(ctx, requests, userID)
+
+	switch {
+	case err == nil:
+		for i, v := range shortened {
+			results[i] = dto.BulkShortenResult{CorrelationID: v.CorrelationID, ShortURL: v.ShortURL}
+		}
+	case errors.Is(err, shrterr.ErrOriginalURLAlreadyExists):
+		for i, request := range requests {
+			shortURL, err := s.ShortenURL(ctx, request.OriginalURL, userID)
+			results[i] = dto.BulkShortenResult{CorrelationID: request.CorrelationID, ShortURL: shortURL}
+			if err != nil {
+				results[i].Error = shrterr.Classify(err).Message
+			}
+		}
+	default:
+		message := shrterr.Classify(err).Message
+		for i, request := range requests {
+			results[i] = dto.BulkShortenResult{CorrelationID: request.CorrelationID, Error: message}
+		}
+	}
+
+	return results
+}
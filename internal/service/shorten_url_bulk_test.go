@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestShortenBulkURLs(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.NewString()
+	requests := []dto.BatchShortenRequest{
+		{CorrelationID: "a", OriginalURL: "https://bulk.example.com/a"},
+		{CorrelationID: "b", OriginalURL: "https://bulk.example.com/b"},
+	}
+
+	t.Run("chunk saved in one batch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepository := mocks.NewMockRepository(ctrl)
+		mockRepository.EXPECT().SaveBatch(gomock.Any(), gomock.Len(2), userID).Return(true, nil)
+
+		results := initTestService(mockRepository).ShortenBulkURLs(ctx, requests, userID)
+
+		require.Len(t, results, 2)
+		for i, result := range results {
+			assert.Equal(t, requests[i].CorrelationID, result.CorrelationID)
+			assert.NotEmpty(t, result.ShortURL)
+			assert.Empty(t, result.Error)
+		}
+	})
+
+	t.Run("conflicting chunk shortened one by one", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepository := mocks.NewMockRepository(ctrl)
+		mockRepository.EXPECT().SaveBatch(gomock.Any(), gomock.Len(2), userID).Return(false, shrterr.ErrOriginalURLAlreadyExists)
+		mockRepository.EXPECT().Save(gomock.Any(), gomock.Any(), requests[0].OriginalURL, userID).Return(shrterr.ErrOriginalURLAlreadyExists)
+		mockRepository.EXPECT().Save(gomock.Any(), gomock.Any(), requests[1].OriginalURL, userID).Return(nil)
+
+		results := initTestService(mockRepository).ShortenBulkURLs(ctx, requests, userID)
+
+		require.Len(t, results, 2)
+		assert.NotEmpty(t, results[0].ShortURL)
+		assert.NotEmpty(t, results[0].Error, "only the conflicting url fails")
+		assert.NotEmpty(t, results[1].ShortURL)
+		assert.Empty(t, results[1].Error)
+	})
+
+	t.Run("storage error fails the chunk", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepository := mocks.NewMockRepository(ctrl)
+		mockRepository.EXPECT().SaveBatch(gomock.Any(), gomock.Len(2), userID).Return(false, errors.New("connection refused"))
+
+		results := initTestService(mockRepository).ShortenBulkURLs(ctx, requests, userID)
+
+		require.Len(t, results, 2)
+		for i, result := range results {
+			assert.Equal(t, requests[i].CorrelationID, result.CorrelationID)
+			assert.Empty(t, result.ShortURL)
+			assert.Equal(t, "internal server error", result.Error)
+		}
+	})
+}
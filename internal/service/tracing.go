@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mp1947/ya-url-shortener/internal/audit"
+	"github.com/mp1947/ya-url-shortener/internal/dto"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/tracing"
+)
+
+// tracedService is a Service decorator starting a span around every call of the wrapped Service.
+// Spans are named after the ShortenService method and record the returned error.
+type tracedService struct {
+	next Service
+}
+
+// WithTracing returns a Service tracing every call of s.
+func WithTracing(s Service) Service {
+	return &tracedService{next: s}
+}
+
+func (t *tracedService) ShortenURL(ctx context.Context, url string, userID string) (shortURL string, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.ShortenURL")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ShortenURL(ctx, url, userID)
+}
+
+func (t *tracedService) GetOriginalURL(ctx context.Context, shortURLID string) (url model.URL, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.GetOriginalURL")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetOriginalURL(ctx, shortURLID)
+}
+
+func (t *tracedService) ShortenURLBatch(ctx context.Context, batchData []dto.BatchShortenRequest, userID string) (result []dto.BatchShortenResponse, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.ShortenURLBatch")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ShortenURLBatch(ctx, batchData, userID)
+}
+
+func (t *tracedService) DeleteURLsBatch(ctx context.Context, shortURLs model.BatchDeleteShortURLs) string {
+	ctx, span := tracing.Start(ctx, "ShortenService.DeleteURLsBatch")
+	defer span.End()
+	return t.next.DeleteURLsBatch(ctx, shortURLs)
+}
+
+func (t *tracedService) GetDeletionJob(ctx context.Context, jobID, userID string) (job dto.DeletionJob, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.GetDeletionJob")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetDeletionJob(ctx, jobID, userID)
+}
+
+func (t *tracedService) GetUserURLs(ctx context.Context, userID string) (urls []dto.ShortenURLsByUserID, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.GetUserURLs")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetUserURLs(ctx, userID)
+}
+
+func (t *tracedService) ShortenBulkURLs(ctx context.Context, requests []dto.BatchShortenRequest, userID string) []dto.BulkShortenResult {
+	ctx, span := tracing.Start(ctx, "ShortenService.ShortenBulkURLs")
+	defer span.End()
+	return t.next.ShortenBulkURLs(ctx, requests, userID)
+}
+
+func (t *tracedService) ExportUserURLs(ctx context.Context, userID string, fn func(dto.ExportedURL) error) (err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.ExportUserURLs")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ExportUserURLs(ctx, userID, fn)
+}
+
+func (t *tracedService) GetInternalStats(ctx context.Context) (stats *dto.InternalStatsResp, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.GetInternalStats")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetInternalStats(ctx)
+}
+
+func (t *tracedService) LinkUserURLs(ctx context.Context, fromUserID string, toUserID string) (linked int64, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.LinkUserURLs")
+	defer func() { tracing.End(span, err) }()
+	return t.next.LinkUserURLs(ctx, fromUserID, toUserID)
+}
+
+func (t *tracedService) AdminGetURL(ctx context.Context, shortURLID string) (url dto.AdminURL, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.AdminGetURL")
+	defer func() { tracing.End(span, err) }()
+	return t.next.AdminGetURL(ctx, shortURLID)
+}
+
+func (t *tracedService) AdminGetUserURLs(ctx context.Context, userID string) (urls []dto.AdminURL, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.AdminGetUserURLs")
+	defer func() { tracing.End(span, err) }()
+	return t.next.AdminGetUserURLs(ctx, userID)
+}
+
+func (t *tracedService) AdminSetURLsDisabled(ctx context.Context, shortURLIDs []string, disabled bool) (affected int64, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.AdminSetURLsDisabled")
+	defer func() { tracing.End(span, err) }()
+	return t.next.AdminSetURLsDisabled(ctx, shortURLIDs, disabled)
+}
+
+func (t *tracedService) AdminDeleteURLs(ctx context.Context, shortURLIDs []string) (affected int64, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.AdminDeleteURLs")
+	defer func() { tracing.End(span, err) }()
+	return t.next.AdminDeleteURLs(ctx, shortURLIDs)
+}
+
+func (t *tracedService) AdminReassignURLs(ctx context.Context, shortURLIDs []string, toUserID string) (affected int64, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.AdminReassignURLs")
+	defer func() { tracing.End(span, err) }()
+	return t.next.AdminReassignURLs(ctx, shortURLIDs, toUserID)
+}
+
+func (t *tracedService) AdminQueryAudit(ctx context.Context, filter audit.Filter) (entries []audit.Entry, err error) {
+	ctx, span := tracing.Start(ctx, "ShortenService.AdminQueryAudit")
+	defer func() { tracing.End(span, err) }()
+	return t.next.AdminQueryAudit(ctx, filter)
+}
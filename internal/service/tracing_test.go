@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/mocks"
+	"github.com/mp1947/ya-url-shortener/internal/repository"
+	"github.com/mp1947/ya-url-shortener/internal/service"
+	"github.com/mp1947/ya-url-shortener/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	ctrl := gomock.NewController(t)
+	mockRepository := mocks.NewMockRepository(ctrl)
+
+	urlToTest := "https://tracing.example.com"
+	userID := uuid.NewString()
+
+	mockRepository.EXPECT().GetType().Return("inmemory").AnyTimes()
+	mockRepository.EXPECT().
+		Save(gomock.Any(), usecase.GenerateIDFromURL(urlToTest), urlToTest, userID).
+		Return(errors.New("storage unavailable"))
+
+	s := service.WithTracing(initTestService(repository.WithTracing(mockRepository)))
+
+	_, err := s.ShortenURL(context.Background(), urlToTest, userID)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	repoSpan, serviceSpan := spans[0], spans[1]
+
+	assert.Equal(t, "Repository.Save", repoSpan.Name())
+	assert.Equal(t, "ShortenService.ShortenURL", serviceSpan.Name())
+	assert.Equal(t, serviceSpan.SpanContext().SpanID(), repoSpan.Parent().SpanID())
+	assert.Equal(t, codes.Error, repoSpan.Status().Code)
+	assert.Equal(t, codes.Error, serviceSpan.Status().Code)
+}
@@ -6,17 +6,21 @@ import (
 	"context"
 
 	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/audit"
 	"github.com/mp1947/ya-url-shortener/internal/dto"
 	"github.com/mp1947/ya-url-shortener/internal/eventlog"
+	"github.com/mp1947/ya-url-shortener/internal/jobs"
 	"github.com/mp1947/ya-url-shortener/internal/model"
 	"github.com/mp1947/ya-url-shortener/internal/repository"
 	"go.uber.org/zap"
 )
 
 // Service defines the interface for URL shortening service operations.
-// It provides methods for shortening URLs (individually and in batch),
+// It provides methods for shortening URLs (individually, in batch and in chunks of a bulk request),
 // retrieving the original URL by its shortened ID, deleting batches of URLs,
-// and fetching all shortened URLs associated with a specific user.
+// fetching all shortened URLs associated with a specific user, linking
+// the URLs of one user to another, operator actions on arbitrary URLs and
+// querying the audit trail.
 type Service interface {
 	ShortenURL(
 		ctx context.Context,
@@ -29,12 +33,22 @@ type Service interface {
 		batchData []dto.BatchShortenRequest,
 		userID string,
 	) ([]dto.BatchShortenResponse, error)
-	DeleteURLsBatch(ctx context.Context, shortURLs model.BatchDeleteShortURLs)
+	DeleteURLsBatch(ctx context.Context, shortURLs model.BatchDeleteShortURLs) string
+	GetDeletionJob(ctx context.Context, jobID, userID string) (dto.DeletionJob, error)
 	GetUserURLs(
 		ctx context.Context,
 		userID string,
 	) ([]dto.ShortenURLsByUserID, error)
+	ShortenBulkURLs(ctx context.Context, requests []dto.BatchShortenRequest, userID string) []dto.BulkShortenResult
+	ExportUserURLs(ctx context.Context, userID string, fn func(dto.ExportedURL) error) error
 	GetInternalStats(ctx context.Context) (*dto.InternalStatsResp, error)
+	LinkUserURLs(ctx context.Context, fromUserID, toUserID string) (int64, error)
+	AdminGetURL(ctx context.Context, shortURLID string) (dto.AdminURL, error)
+	AdminGetUserURLs(ctx context.Context, userID string) ([]dto.AdminURL, error)
+	AdminSetURLsDisabled(ctx context.Context, shortURLIDs []string, disabled bool) (int64, error)
+	AdminDeleteURLs(ctx context.Context, shortURLIDs []string) (int64, error)
+	AdminReassignURLs(ctx context.Context, shortURLIDs []string, toUserID string) (int64, error)
+	AdminQueryAudit(ctx context.Context, filter audit.Filter) ([]audit.Entry, error)
 }
 
 // ShortenService provides methods for URL shortening operations.
@@ -45,10 +59,14 @@ type Service interface {
 //   - Cfg: Service configuration settings.
 //   - Logger: Structured logger for service logging.
 //   - CommCh: Channel for batch deletion of short URLs.
+//   - Audit: Store for the audit trail of mutating and administrative actions, audit entries are skipped when nil.
+//   - Jobs: Store for the status of the deletion jobs, statuses are not recorded when nil.
 type ShortenService struct {
 	Cfg     *config.Config
 	Logger  *zap.Logger
 	CommCh  chan model.BatchDeleteShortURLs
 	Storage repository.Repository
 	EP      eventlog.EventProcessor
+	Audit   audit.Store
+	Jobs    jobs.Store
 }
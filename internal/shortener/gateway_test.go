@@ -0,0 +1,93 @@
+package shortener
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSelfSigned writes a self-signed certificate for localhost and its key to crtPath and keyPath.
+func writeSelfSigned(t *testing.T, crtPath, keyPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(crtPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+}
+
+func TestGatewayWithTLS(t *testing.T) {
+	dir := t.TempDir()
+	crtPath, keyPath := filepath.Join(dir, "cert.crt"), filepath.Join(dir, "key.pem")
+	writeSelfSigned(t, crtPath, keyPath)
+
+	cfg, err := config.Load([]string{"-s", "-g", "-gw"}, []string{
+		authtest.Environ,
+		"TLS_CRT_FILE=" + crtPath,
+		"TLS_KEY_FILE=" + keyPath,
+		"FILE_STORAGE_PATH=" + filepath.Join(dir, "events.out"),
+		"AUDIT_LOG_PATH=" + filepath.Join(dir, "audit.out"),
+		"LOG_LEVEL=error",
+	}, nil)
+	require.NoError(t, err)
+
+	sh, err := InitShortener(context.Background(), cfg, "test", "test", "test")
+	require.NoError(t, err)
+	require.NotSame(t, sh.grpcServer, sh.gwServer, "the gateway is not served by the TLS server")
+
+	go func() { _ = sh.gateway.Serve(sh.gwServer) }()
+	t.Cleanup(func() {
+		sh.gwServer.Stop()
+		sh.grpcServer.Stop()
+		_ = sh.gateway.Close()
+	})
+
+	srv := httptest.NewServer(sh.httpServer.Handler)
+	defer srv.Close()
+
+	resp, err := srv.Client().Post(srv.URL+"/v2/shorten", "application/json", strings.NewReader(`{"url": "https://example.com/tls"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var shortened struct {
+		ShortURL string `json:"short_url"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&shortened))
+	assert.NotEmpty(t, shortened.ShortURL)
+}
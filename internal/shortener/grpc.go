@@ -6,11 +6,38 @@ import (
 	handlegrpc "github.com/mp1947/ya-url-shortener/internal/handler/grpc"
 	"github.com/mp1947/ya-url-shortener/internal/proto"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
+// registerGRPCServices registers the Shortener and Admin gRPC services and the grpc.health.v1 service
+// on the gRPC servers, and reflection when it is enabled by the configuration.
+func (s *Shortener) registerGRPCServices() {
+	s.grpcHealth = grpchealth.NewServer()
+
+	for _, server := range s.grpcServers() {
+		proto.RegisterShortenerServer(server, handlegrpc.NewGRPCService(s.api, s.cfg))
+		proto.RegisterAdminServer(server, handlegrpc.NewAdminService(s.api, s.cfg))
+		healthpb.RegisterHealthServer(server, s.grpcHealth)
+
+		if *s.cfg.ReflectionEnabled {
+			reflection.Register(server)
+		}
+	}
+}
+
+// grpcServers returns the gRPC server and, when it is a distinct one, the server of the gateway.
+func (s *Shortener) grpcServers() []*grpc.Server {
+	if s.gwServer == nil || s.gwServer == s.grpcServer {
+		return []*grpc.Server{s.grpcServer}
+	}
+	return []*grpc.Server{s.grpcServer, s.gwServer}
+}
+
 // runGRPC starts the gRPC server for the Shortener service.
-// It sets up the TCP listener on the configured address, registers the gRPC service and reflection,
+// It sets up the TCP listener on the configured address
 // and begins serving incoming gRPC requests. If any error occurs during setup or serving,
 // it logs the error and returns it.
 func (s *Shortener) runGRPC() error {
@@ -21,9 +48,6 @@ func (s *Shortener) runGRPC() error {
 		return err
 	}
 
-	proto.RegisterShortenerServer(s.grpcServer, handlegrpc.NewGRPCService(&s.service, s.cfg))
-	reflection.Register(s.grpcServer)
-
 	s.Logger.Info("starting grpc server on address", zap.String("address", *s.cfg.GRPCServerAddress))
 
 	if err := s.grpcServer.Serve(l); err != nil {
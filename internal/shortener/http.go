@@ -8,7 +8,8 @@ import (
 
 // runHTTP starts the HTTP web server for the Shortener service.
 // It checks the configuration to determine whether to use TLS or not.
-// If TLS is enabled, it starts the server with the provided TLS certificate and key files.
+// If TLS is enabled, it starts the server with the certificate served by the certificate manager,
+// so rotated certificate and key files are picked up without a restart.
 // Otherwise, it starts a standard HTTP server.
 // Logs are generated for server startup and any errors encountered.
 // Returns an error if the server fails to start, except when the error is http.ErrServerClosed.
@@ -16,10 +17,7 @@ func (s *Shortener) runHTTP() error {
 	s.Logger.Info("preparing to start http web server")
 	if *s.cfg.ShouldUseTLS {
 		s.Logger.Info("starting web server with tls config", zap.Any("config", *s.cfg.TLSConfig))
-		if err := s.httpServer.ListenAndServeTLS(
-			s.cfg.TLSConfig.CrtFilePath,
-			s.cfg.TLSConfig.KeyFilePath,
-		); err != nil && err != http.ErrServerClosed {
+		if err := s.httpServer.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
 
 			s.Logger.Fatal("error starting http web server with tls", zap.Error(err))
 			return err
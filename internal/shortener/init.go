@@ -2,27 +2,54 @@ package shortener
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"net/http"
 
 	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/auth"
+	"github.com/mp1947/ya-url-shortener/internal/certs"
+	"github.com/mp1947/ya-url-shortener/internal/errorpage"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/gateway"
+	"github.com/mp1947/ya-url-shortener/internal/health"
 	"github.com/mp1947/ya-url-shortener/internal/interceptor"
 	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
 	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/oidc"
+	"github.com/mp1947/ya-url-shortener/internal/reload"
 	"github.com/mp1947/ya-url-shortener/internal/repository"
+	"github.com/mp1947/ya-url-shortener/internal/repository/database"
 	"github.com/mp1947/ya-url-shortener/internal/router"
 	"github.com/mp1947/ya-url-shortener/internal/service"
+	"github.com/mp1947/ya-url-shortener/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // InitShortener initializes and configures the URL shortener application.
 //
-// It sets up the configuration, logger, storage repository, service layer, HTTP router, and optionally a gRPC server.
+// It sets the key signing the JWTs, then sets up the logger, tracing, storage repository, service layer, HTTP router, and optionally a gRPC server from the given configuration.
+// The service layer and the storage are traced, the HTTP and gRPC servers propagate the W3C trace context.
+// When TLS is enabled, both servers serve the certificate held by a certs.Manager, and the gRPC server
+// optionally requires client certificates issued by the configured client CA, which only gRPC calls must
+// present when both are served on a single port.
+// The in-process calls of the gateway are served without TLS, by a separate gRPC server when the gRPC listener uses it.
+// The lookup cache, the rate limit buckets and the deletion job statuses are kept in Redis when it is configured,
+// so that they are shared by every instance, and in process otherwise.
+// Readiness checks of the storage, of Redis and of the deletion worker back the HTTP and gRPC health endpoints,
+// database replicas are reported by them without affecting the readiness since reads fall back to the primary.
+// When the gateway is enabled, the gRPC API is also served as JSON under /v2/ of the HTTP server.
 // The function logs build information and initialization steps. It returns a pointer to a Shortener instance
 // containing all initialized components, or an error if any step fails.
 //
 // Parameters:
 //   - ctx: Context for managing lifecycle and cancellation.
+//   - cfg: The loaded application configuration.
 //   - buildVersion: The version string of the build.
 //   - buildDate: The build date string.
 //   - buildCommit: The commit hash of the build.
@@ -32,14 +59,16 @@ import (
 //   - error: An error if initialization fails.
 func InitShortener(
 	ctx context.Context,
+	cfg *config.Config,
 	buildVersion string,
 	buildDate string,
 	buildCommit string,
 ) (*Shortener, error) {
+	if err := auth.SetSecretKey(*cfg.SecretKey); err != nil {
+		return nil, fmt.Errorf("SECRET_KEY: %w", err)
+	}
 
-	cfg := config.InitConfig()
-
-	logger, err := logger.InitLogger()
+	logger, logLevel, err := logger.New(cfg.Logging)
 
 	if err != nil {
 		return nil, err
@@ -49,6 +78,14 @@ func InitShortener(
 	logger.Info("Build date", zap.String("date", buildDate))
 	logger.Info("Build commit", zap.String("commit", buildCommit))
 
+	metrics.SetBuildInfo(buildVersion, buildCommit, buildDate)
+
+	shutdownTracing, err := tracing.Init(ctx, cfg.Tracing, buildVersion)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("tracing has been initialized", zap.String("exporter", cfg.Tracing.Exporter))
+
 	logger.Info(
 		"initializing web application with config",
 		zap.String("host", *cfg.HTTPServerAddress),
@@ -65,14 +102,73 @@ func InitShortener(
 		zap.String("type", storage.GetType()),
 	)
 
-	service := service.ShortenService{
+	auditStore, err := repository.CreateAuditStore(logger, *cfg, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	stores, err := openSharedStores(ctx, cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedStorage := storage
+	if stores.cache != nil {
+		cachedStorage = repository.WithCache(storage, *cfg.Cache, stores.cache, *cfg.ReadYourWrites)
+		logger.Info(
+			"caching short url lookups",
+			zap.Int("size", cfg.Cache.Size),
+			zap.String("policy", cfg.Cache.Policy),
+		)
+	}
+
+	svc := service.ShortenService{
 		Cfg:     cfg,
 		Logger:  logger,
 		CommCh:  make(chan model.BatchDeleteShortURLs),
-		Storage: storage,
+		Storage: repository.WithTracing(cachedStorage),
+		Audit:   auditStore,
+		Jobs:    stores.jobs,
+	}
+	api := service.WithTracing(&svc)
+
+	var oidcProvider *oidc.Provider
+
+	if cfg.OIDC.Enabled() {
+		logger.Info("discovering oidc provider", zap.String("issuer", cfg.OIDC.IssuerURL))
+		oidcProvider, err = oidc.NewProvider(ctx, *cfg.OIDC)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var gw *gateway.Gateway
+	var gwHandler http.Handler
+
+	if *cfg.GatewayEnabled {
+		gw, err = gateway.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		gwHandler = gw
+		logger.Info("serving grpc api as json under /v2/", zap.String("openapi", gateway.OpenAPIPath))
 	}
 
-	r := router.CreateRouter(*cfg, &service, storage, logger)
+	pages, err := errorpage.Load(*cfg.ErrorPagesDir)
+	if err != nil {
+		return nil, err
+	}
+	if *cfg.ErrorPagesDir != "" {
+		logger.Info("error page templates have been loaded", zap.String("dir", *cfg.ErrorPagesDir))
+	}
+
+	hc := health.NewChecker()
+
+	// The trusted subnets, trusted proxies, admin API keys, log level and rate limit are shared by
+	// the HTTP and gRPC servers and can be reloaded on SIGHUP.
+	settings := reload.NewSettings(cfg, &logLevel, stores.limiter)
+
+	r := router.CreateRouter(*cfg, api, storage, logger, oidcProvider, gwHandler, hc, settings, stores.limiter, pages)
 
 	logger.Info(
 		"router has been created. web server is ready to start",
@@ -83,18 +179,173 @@ func InitShortener(
 		Handler: r.Handler(),
 	}
 
-	var grpcServer *grpc.Server
+	var certManager *certs.Manager
+
+	if *cfg.ShouldUseTLS {
+		certManager, err = certs.NewManager(cfg.TLSConfig.CrtFilePath, cfg.TLSConfig.KeyFilePath, logger)
+		if err != nil {
+			return nil, err
+		}
+		srv.TLSConfig = certManager.TLSConfig()
+	}
+
+	var grpcServer, gatewayServer *grpc.Server
+
+	// The gateway forwards its requests to the gRPC server in-process, so the server is created
+	// even when it does not listen on a network address.
+	if *cfg.GRPCEnabled || gw != nil {
+		var internalClientCAs *x509.CertPool
+		if *cfg.InternalClientCA != "" {
+			internalClientCAs, err = certs.LoadCertPool(*cfg.InternalClientCA)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		opts := []grpc.ServerOption{grpc.StatsHandler(otelgrpc.NewServerHandler())}
+
+		// The transport credentials of the gRPC listener, the in-process connections of the gateway are not encrypted.
+		var creds []grpc.ServerOption
+		// The CAs of the client certificates verified by the interceptors rather than by the TLS handshake.
+		var grpcClientCAs *x509.CertPool
+
+		if certManager != nil && *cfg.GRPCEnabled {
+			serverTLSConfig, err := grpcTLSConfig(certManager, cfg.TLSConfig.ClientCAFile, internalClientCAs != nil)
+			if err != nil {
+				return nil, err
+			}
+
+			if *cfg.SinglePort {
+				// The shared listener negotiates both HTTP/1.1 and h2. HTTP clients are not required to present
+				// a certificate, so it is only requested and the one of gRPC calls is verified by an interceptor.
+				serverTLSConfig.NextProtos = []string{"h2", "http/1.1"}
+				if serverTLSConfig.ClientAuth == tls.RequireAndVerifyClientCert {
+					serverTLSConfig.ClientAuth = tls.RequestClientCert
+					grpcClientCAs = serverTLSConfig.ClientCAs
+				}
+				srv.TLSConfig = serverTLSConfig
+			} else {
+				creds = append(creds, grpc.Creds(credentials.NewTLS(serverTLSConfig)))
+			}
+		}
+
+		streamInterceptors := []grpc.StreamServerInterceptor{
+			interceptor.MetricsStreamInterceptor,
+			interceptor.RequestIDStreamInterceptor(logger),
+			interceptor.ClientIPStreamInterceptor(settings.Resolver),
+		}
+		unaryInterceptors := []grpc.UnaryServerInterceptor{
+			interceptor.MetricsUnaryInterceptor,
+			interceptor.RequestIDUnaryInterceptor(logger),
+			interceptor.ClientIPUnaryInterceptor(settings.Resolver),
+		}
+
+		if grpcClientCAs != nil {
+			streamInterceptors = append(streamInterceptors, interceptor.ClientCertStreamInterceptor(grpcClientCAs))
+			unaryInterceptors = append(unaryInterceptors, interceptor.ClientCertUnaryInterceptor(grpcClientCAs))
+		}
+
+		// Clients are rate limited by their IP address, resolved by the preceding interceptors.
+		if stores.limiter != nil {
+			streamInterceptors = append(streamInterceptors, interceptor.RateLimitStreamInterceptor(logger, stores.limiter))
+			unaryInterceptors = append(unaryInterceptors, interceptor.RateLimitUnaryInterceptor(logger, stores.limiter))
+		}
+
+		opts = append(opts, grpc.ChainStreamInterceptor(append(streamInterceptors,
+			interceptor.AuthStreamInterceptor,
+		)...))
+
+		opts = append(opts, grpc.ChainUnaryInterceptor(append(unaryInterceptors,
+			interceptor.AuthUnaryInterceptor,
+			interceptor.TrustedSubnetUnaryInterceptor(
+				settings.TrustedSubnets,
+				internalClientCAs,
+				interceptor.InternalStatsMethod,
+			),
+			interceptor.AdminUnaryInterceptor(settings.AdminAPIKeys),
+		)...))
+
+		grpcServer = grpc.NewServer(append(opts, creds...)...)
+
+		// The gateway dials its in-memory listener without TLS, which a server with transport credentials
+		// would refuse, so its calls are served by a server with the same services and interceptors but none.
+		gatewayServer = grpcServer
+		if gw != nil && len(creds) > 0 {
+			gatewayServer = grpc.NewServer(opts...)
+		}
+	}
+
+	sh := &Shortener{
+		repo:        storage,
+		service:     svc,
+		api:         api,
+		cfg:         cfg,
+		Logger:      logger,
+		httpServer:  srv,
+		grpcServer:  grpcServer,
+		gwServer:    gatewayServer,
+		certManager: certManager,
+		gateway:     gw,
+		health:      hc,
+		settings:    settings,
+		redis:       stores.redis,
+		loadConfig:  config.InitConfig,
+
+		shutdownTracing: shutdownTracing,
+	}
+
+	if db, ok := storage.(*database.Database); ok {
+		if err := metrics.RegisterPoolStats(db.Stat); err != nil {
+			return nil, err
+		}
+		hc.Add("storage", db.Ping)
+		hc.Add("migrations", db.CheckMigrations)
+		for i := range db.ReplicaCount() {
+			hc.AddOptional(fmt.Sprintf("storage_replica_%d", i+1), func(ctx context.Context) error {
+				return db.PingReplica(ctx, i)
+			})
+		}
+	}
+	if stores.redis != nil {
+		hc.Add("redis", stores.redis.Ping)
+	}
+	hc.Add("deletion_worker", func(context.Context) error {
+		if !sh.deletionWorkerRunning.Load() {
+			return shrterr.ErrDeletionWorkerStopped
+		}
+		return nil
+	})
+
+	if grpcServer != nil {
+		sh.registerGRPCServices()
+
+		if *cfg.SinglePort && *cfg.GRPCEnabled {
+			logger.Info("serving grpc and http on a single port", zap.String("address", *cfg.HTTPServerAddress))
+			srv.Handler = singlePortHandler(grpcServer, srv.Handler, *cfg.ShouldUseTLS)
+		}
+	}
+
+	return sh, nil
+}
+
+// grpcTLSConfig builds the TLS configuration of the gRPC server serving the certificates of m.
+// If clientCAFile is set, clients must present a certificate issued by one of its CAs (mutual TLS).
+// Otherwise, if requestClientCert is true, client certificates are requested but verified later
+// by the interceptors that need them.
+func grpcTLSConfig(m *certs.Manager, clientCAFile string, requestClientCert bool) (*tls.Config, error) {
+	tlsConfig := m.TLSConfig()
+	tlsConfig.NextProtos = []string{"h2"}
 
-	if *cfg.GRPCEnabled {
-		grpcServer = grpc.NewServer(grpc.UnaryInterceptor(interceptor.AuthUnaryInterceptor))
+	if clientCAFile != "" {
+		clientCAs, err := certs.LoadCertPool(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	} else if requestClientCert {
+		tlsConfig.ClientAuth = tls.RequestClientCert
 	}
 
-	return &Shortener{
-		repo:       storage,
-		service:    service,
-		cfg:        cfg,
-		Logger:     logger,
-		httpServer: srv,
-		grpcServer: grpcServer,
-	}, nil
+	return tlsConfig, nil
 }
@@ -0,0 +1,58 @@
+package shortener
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// watchReload reloads the configuration on every SIGHUP until ctx is done.
+func (s *Shortener) watchReload(ctx context.Context) {
+	reloadCh := make(chan os.Signal, 1)
+	signal.Notify(reloadCh, syscall.SIGHUP)
+	defer signal.Stop(reloadCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reloadCh:
+			_ = s.Reload()
+		}
+	}
+}
+
+// Reload re-reads the configuration from the same flags, environment and configuration file as
+// at startup and applies its reloadable settings: the trusted subnets, the trusted proxies, the admin
+// API keys and the log level. Changed settings which require a restart, such as listen addresses
+// or the database DSN, are reported. An invalid configuration is rejected as a whole and the
+// running configuration is kept. The outcome is logged and recorded in the config metrics.
+func (s *Shortener) Reload() error {
+	cfg, err := s.loadConfig()
+	if err != nil {
+		metrics.ConfigReloadsTotal.WithLabelValues(metrics.ReloadFailure).Inc()
+		s.Logger.Error("error reloading configuration, keeping the running configuration", zap.Error(err))
+		return err
+	}
+
+	result := s.settings.Apply(cfg)
+
+	metrics.ConfigReloadsTotal.WithLabelValues(metrics.ReloadSuccess).Inc()
+	metrics.ConfigLastReloadSuccess.SetToCurrentTime()
+	metrics.ConfigRestartRequired.Set(float64(len(result.RestartRequired)))
+
+	s.Logger.Info("configuration has been reloaded", zap.Strings("applied", result.Applied))
+
+	if len(result.RestartRequired) > 0 {
+		s.Logger.Warn(
+			"reloaded configuration changes settings which require a restart",
+			zap.Strings("settings", result.RestartRequired),
+		)
+	}
+
+	return nil
+}
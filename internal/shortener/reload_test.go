@@ -0,0 +1,59 @@
+package shortener
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
+	"github.com/mp1947/ya-url-shortener/internal/reload"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestReload(t *testing.T) {
+	cfg, err := config.Load(nil, []string{authtest.Environ}, nil)
+	require.NoError(t, err)
+
+	core, logs := observer.New(zap.InfoLevel)
+
+	var next *config.Config
+	var loadErr error
+
+	s := &Shortener{
+		cfg:        cfg,
+		Logger:     zap.New(core),
+		settings:   reload.NewSettings(cfg, nil, nil),
+		loadConfig: func() (*config.Config, error) { return next, loadErr },
+	}
+
+	t.Run("applies reloadable settings", func(t *testing.T) {
+		successes := testutil.ToFloat64(metrics.ConfigReloadsTotal.WithLabelValues(metrics.ReloadSuccess))
+
+		next, err = config.Load([]string{"-a", ":9090"}, []string{authtest.Environ, "TRUSTED_SUBNET=10.0.0.0/8"}, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, s.Reload())
+
+		assert.True(t, s.settings.TrustedSubnets.Contains(net.ParseIP("10.1.2.3")))
+		assert.Equal(t, successes+1, testutil.ToFloat64(metrics.ConfigReloadsTotal.WithLabelValues(metrics.ReloadSuccess)))
+		assert.Positive(t, testutil.ToFloat64(metrics.ConfigRestartRequired))
+		assert.Equal(t, 1, logs.FilterMessage("reloaded configuration changes settings which require a restart").Len())
+	})
+
+	t.Run("keeps running configuration on error", func(t *testing.T) {
+		failures := testutil.ToFloat64(metrics.ConfigReloadsTotal.WithLabelValues(metrics.ReloadFailure))
+
+		next, loadErr = nil, errors.New("TRUSTED_SUBNET: invalid CIDR address")
+
+		assert.Error(t, s.Reload())
+
+		assert.True(t, s.settings.TrustedSubnets.Contains(net.ParseIP("10.1.2.3")))
+		assert.Equal(t, failures+1, testutil.ToFloat64(metrics.ConfigReloadsTotal.WithLabelValues(metrics.ReloadFailure)))
+	})
+}
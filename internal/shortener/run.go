@@ -7,16 +7,37 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/mp1947/ya-url-shortener/internal/proto"
 	"go.uber.org/zap"
 )
 
 // Run starts the Shortener service by launching background processes for handling deletions,
-// running the HTTP and optional gRPC servers, and waits for a termination signal (SIGINT or SIGTERM).
+// watching TLS certificate files for changes and reporting readiness to the gRPC health service,
+// running the HTTP and optional gRPC servers (the gRPC server has no listener of its own in single port mode)
+// and the in-process connections of the optional gateway, and waits for a termination signal (SIGINT or SIGTERM).
+// Meanwhile, the configuration is reloaded on SIGHUP as described by Reload.
 // Upon receiving a shutdown signal, it gracefully shuts down all running services within a 10-second timeout.
 // Logs errors encountered during server execution or shutdown.
 func (s *Shortener) Run() {
 
-	go s.service.ProcessDeletions()
+	s.deletionWorkerRunning.Store(true)
+	go func() {
+		defer s.deletionWorkerRunning.Store(false)
+		s.service.ProcessDeletions()
+	}()
+
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+
+	if s.certManager != nil {
+		go s.certManager.Watch(watchCtx, s.cfg.TLSConfig.ReloadInterval)
+	}
+
+	go s.watchReload(watchCtx)
+
+	if s.grpcHealth != nil {
+		go s.health.Watch(watchCtx, 0, s.grpcHealth, proto.Shortener_ServiceDesc.ServiceName)
+	}
 
 	go func() {
 		if err := s.runHTTP(); err != nil {
@@ -24,7 +45,7 @@ func (s *Shortener) Run() {
 		}
 	}()
 
-	if s.grpcServer != nil {
+	if *s.cfg.GRPCEnabled && !*s.cfg.SinglePort {
 		go func() {
 			if err := s.runGRPC(); err != nil {
 				s.Logger.Error("error running gRPC server", zap.Error(err))
@@ -32,6 +53,14 @@ func (s *Shortener) Run() {
 		}()
 	}
 
+	if s.gateway != nil {
+		go func() {
+			if err := s.gateway.Serve(s.gwServer); err != nil {
+				s.Logger.Error("error serving gateway connections", zap.Error(err))
+			}
+		}()
+	}
+
 	gracefuShutdownCh := make(chan os.Signal, 1)
 
 	signal.Notify(gracefuShutdownCh, syscall.SIGINT, syscall.SIGTERM)
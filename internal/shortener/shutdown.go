@@ -3,21 +3,30 @@ package shortener
 import (
 	"context"
 	"errors"
+	"io"
 	"log"
 	"syscall"
 
 	"github.com/mp1947/ya-url-shortener/internal/repository/database"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
-// Shutdown gracefully shuts down the Shortener service, including the HTTP and gRPC servers,
-// and closes any open database connections. It logs the shutdown process, ensures the logger
-// is properly synced, and closes the communication channel. The method accepts a context
+// Shutdown gracefully shuts down the Shortener service. It first reports the service as not ready on the
+// HTTP and gRPC health endpoints, then shuts down the HTTP and gRPC servers,
+// and closes any open database and Redis connections. It logs the shutdown process, ensures the logger
+// is properly synced, closes the audit log and the communication channel and flushes pending traces. The method accepts a context
 // for controlling the shutdown timeout and returns an error if any part of the shutdown fails.
 func (s *Shortener) Shutdown(ctx context.Context) error {
 
 	s.Logger.Info("received shutdown signal, gracefully shutting down shortener...")
 
+	// Readiness is lost first, so that load balancers stop routing traffic while in-flight requests complete.
+	s.health.SetShuttingDown()
+	if s.grpcHealth != nil {
+		s.grpcHealth.Shutdown()
+	}
+
 	defer close(s.service.CommCh)
 
 	defer func() {
@@ -26,33 +35,78 @@ func (s *Shortener) Shutdown(ctx context.Context) error {
 		}
 	}()
 
+	// In single port mode gRPC calls are served by the HTTP server, they are drained first
+	// so that the HTTP server does not wait for long-running gRPC streams.
+	if s.grpcServer != nil && *s.cfg.SinglePort && *s.cfg.GRPCEnabled {
+		if err := s.stopGRPC(ctx, s.grpcServer); err != nil {
+			return err
+		}
+	}
+
 	if err := s.httpServer.Shutdown(ctx); err != nil {
 		s.Logger.Error("http server graceful shutdown error", zap.Error(err))
 		return err
 	}
 
-	if s.grpcServer != nil {
-		s.Logger.Info("gracefully shutting down gRPC server with timeout")
-		stopped := make(chan struct{})
-		go func() {
-			s.grpcServer.GracefulStop()
-			close(stopped)
-		}()
-		select {
-		case <-ctx.Done():
-			s.Logger.Warn("timeout reached, forcing gRPC server stop")
-			s.grpcServer.Stop()
-			return ctx.Err()
-		case <-stopped:
+	if s.grpcServer != nil && !(*s.cfg.SinglePort && *s.cfg.GRPCEnabled) {
+		if err := s.stopGRPC(ctx, s.grpcServer); err != nil {
+			return err
+		}
+	}
+
+	if s.gwServer != nil && s.gwServer != s.grpcServer {
+		if err := s.stopGRPC(ctx, s.gwServer); err != nil {
+			return err
+		}
+	}
+
+	if s.gateway != nil {
+		if err := s.gateway.Close(); err != nil {
+			s.Logger.Warn("error closing gateway connection", zap.Error(err))
+		}
+	}
+
+	if closer, ok := s.service.Audit.(io.Closer); ok {
+		s.Logger.Info("closing audit log")
+		if err := closer.Close(); err != nil {
+			s.Logger.Warn("error closing audit log", zap.Error(err))
 		}
 	}
 
 	if s.repo.GetType() == "database" {
 		s.Logger.Info("closing connections to the database")
-		s.service.Storage.(*database.Database).Close()
+		s.repo.(*database.Database).Close()
+	}
+
+	if s.redis != nil {
+		if err := s.redis.Close(); err != nil {
+			s.Logger.Warn("error closing redis connections", zap.Error(err))
+		}
+	}
+
+	if err := s.shutdownTracing(ctx); err != nil {
+		s.Logger.Warn("error flushing traces", zap.Error(err))
 	}
 
 	s.Logger.Info("goodbye!")
 
 	return nil
 }
+
+// stopGRPC gracefully stops the gRPC server, forcing it to stop when ctx is done first.
+func (s *Shortener) stopGRPC(ctx context.Context, server *grpc.Server) error {
+	s.Logger.Info("gracefully shutting down gRPC server with timeout")
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-ctx.Done():
+		s.Logger.Warn("timeout reached, forcing gRPC server stop")
+		server.Stop()
+		return ctx.Err()
+	case <-stopped:
+	}
+	return nil
+}
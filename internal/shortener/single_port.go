@@ -0,0 +1,30 @@
+package shortener
+
+import (
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+// singlePortHandler returns an HTTP handler serving gRPC calls with grpcServer and any other
+// request with httpHandler, so both can share one listener. gRPC calls are detected as HTTP/2
+// requests with an "application/grpc" content type. Over TLS, HTTP/2 is negotiated through ALPN
+// by the HTTP server. Without TLS, the handler additionally accepts HTTP/2 with prior knowledge (h2c).
+func singlePortHandler(grpcServer *grpc.Server, httpHandler http.Handler, useTLS bool) http.Handler {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		httpHandler.ServeHTTP(w, r)
+	})
+
+	if useTLS {
+		return handler
+	}
+
+	return h2c.NewHandler(handler, &http2.Server{})
+}
@@ -0,0 +1,186 @@
+package shortener
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/auth/authtest"
+	pb "github.com/mp1947/ya-url-shortener/internal/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type statsStub struct {
+	pb.UnimplementedShortenerServer
+}
+
+func (statsStub) GetInternalStats(ctx context.Context, in *pb.Empty) (*pb.InternalStatsResp, error) {
+	return &pb.InternalStatsResp{Urls: 42, Users: 7}, nil
+}
+
+func TestSinglePortHandler(t *testing.T) {
+	httpHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "served by http handler")
+	})
+
+	tests := []struct {
+		name   string
+		useTLS bool
+	}{
+		{name: "h2c", useTLS: false},
+		{name: "tls with alpn", useTLS: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grpcServer := grpc.NewServer()
+			pb.RegisterShortenerServer(grpcServer, statsStub{})
+
+			srv := httptest.NewUnstartedServer(singlePortHandler(grpcServer, httpHandler, tt.useTLS))
+
+			var creds credentials.TransportCredentials
+			if tt.useTLS {
+				srv.EnableHTTP2 = true
+				srv.StartTLS()
+				creds = credentials.NewTLS(&tls.Config{
+					RootCAs: srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs,
+				})
+			} else {
+				srv.Start()
+				creds = insecure.NewCredentials()
+			}
+			defer srv.Close()
+
+			conn, err := grpc.NewClient(strings.TrimPrefix(strings.TrimPrefix(srv.URL, "https://"), "http://"),
+				grpc.WithTransportCredentials(creds))
+			require.NoError(t, err)
+			defer conn.Close()
+
+			stats, err := pb.NewShortenerClient(conn).GetInternalStats(context.Background(), &pb.Empty{})
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), stats.Urls)
+
+			resp, err := srv.Client().Get(srv.URL + "/")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "served by http handler", string(body))
+
+			grpcServer.GracefulStop()
+		})
+	}
+}
+
+// writeClientCA writes a CA certificate to caPath and returns a client certificate it issued.
+func writeClientCA(t *testing.T, caPath string) tls.Certificate {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "clients-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0600))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "grpc-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, caTemplate, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestSinglePortClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	crtPath, keyPath, caPath := filepath.Join(dir, "cert.crt"), filepath.Join(dir, "key.pem"), filepath.Join(dir, "ca.crt")
+	writeSelfSigned(t, crtPath, keyPath)
+	clientCert := writeClientCA(t, caPath)
+
+	cfg, err := config.Load([]string{"-s", "-g", "-sp"}, []string{
+		authtest.Environ,
+		"TLS_CRT_FILE=" + crtPath,
+		"TLS_KEY_FILE=" + keyPath,
+		"TLS_CLIENT_CA_FILE=" + caPath,
+		"FILE_STORAGE_PATH=" + filepath.Join(dir, "events.out"),
+		"AUDIT_LOG_PATH=" + filepath.Join(dir, "audit.out"),
+		"LOG_LEVEL=error",
+	}, nil)
+	require.NoError(t, err)
+
+	sh, err := InitShortener(context.Background(), cfg, "test", "test", "test")
+	require.NoError(t, err)
+	t.Cleanup(sh.grpcServer.Stop)
+
+	srv := httptest.NewUnstartedServer(sh.httpServer.Handler)
+	srv.TLS = sh.httpServer.TLSConfig
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+
+	t.Run("http clients without certificate", func(t *testing.T) {
+		resp, err := srv.Client().Get(srv.URL + "/healthz")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	shorten := func(cfg *tls.Config) error {
+		conn, err := grpc.NewClient(strings.TrimPrefix(srv.URL, "https://"),
+			grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = pb.NewShortenerClient(conn).ShortenURL(context.Background(), &pb.ShortenURLReq{Url: "https://example.com/mtls"})
+		return err
+	}
+
+	t.Run("grpc clients without certificate", func(t *testing.T) {
+		err := shorten(&tls.Config{RootCAs: roots})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("grpc clients with certificate", func(t *testing.T) {
+		err := shorten(&tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,61 @@
+package shortener
+
+import (
+	"context"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/cache"
+	"github.com/mp1947/ya-url-shortener/internal/jobs"
+	"github.com/mp1947/ya-url-shortener/internal/ratelimit"
+	"github.com/mp1947/ya-url-shortener/internal/redisstore"
+	"go.uber.org/zap"
+)
+
+// localDeletionJobs is the number of deletion job statuses kept in memory when Redis is not configured.
+const localDeletionJobs = 10000
+
+// sharedStores holds the state that is shared between the replicas of the service when Redis is
+// configured, and kept in process otherwise.
+type sharedStores struct {
+	redis   *redisstore.Client
+	cache   cache.Store
+	jobs    jobs.Store
+	limiter *ratelimit.Limiter
+}
+
+// openSharedStores opens the stores of the lookup cache, the deletion job statuses and the rate limit
+// buckets described by cfg, in Redis when REDIS_URL is set and in process otherwise. The cache store is
+// nil when the cache is disabled. The limiter allows every request while rate limiting is disabled, so that
+// a reloaded configuration can enable it.
+func openSharedStores(ctx context.Context, cfg *config.Config, logger *zap.Logger) (*sharedStores, error) {
+	stores := &sharedStores{}
+	limit := ratelimit.Limit{Rate: cfg.RateLimit.RPS, Burst: cfg.RateLimit.Burst}
+
+	if cfg.Redis.Enabled() {
+		client, err := redisstore.Open(ctx, cfg.Redis.URL, cfg.Redis.KeyPrefix)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("sharing the lookup cache, rate limits and deletion jobs through redis",
+			zap.String("key_prefix", cfg.Redis.KeyPrefix))
+
+		stores.redis = client
+		stores.jobs = client.Jobs(*cfg.DeletionJobTTL)
+		if cfg.Cache.Enabled() {
+			stores.cache = client.Cache()
+		}
+		stores.limiter = ratelimit.New(client.Limiter(), limit)
+		return stores, nil
+	}
+
+	stores.jobs = jobs.NewLocal(localDeletionJobs, *cfg.DeletionJobTTL)
+	if cfg.Cache.Enabled() {
+		local, err := cache.NewLocal(cfg.Cache.Size, cfg.Cache.Policy)
+		if err != nil {
+			return nil, err
+		}
+		stores.cache = local
+	}
+	stores.limiter = ratelimit.New(ratelimit.NewLocal(), limit)
+	return stores, nil
+}
@@ -4,13 +4,21 @@
 package shortener
 
 import (
+	"context"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/certs"
+	"github.com/mp1947/ya-url-shortener/internal/gateway"
+	"github.com/mp1947/ya-url-shortener/internal/health"
+	"github.com/mp1947/ya-url-shortener/internal/redisstore"
+	"github.com/mp1947/ya-url-shortener/internal/reload"
 	"github.com/mp1947/ya-url-shortener/internal/repository"
 	"github.com/mp1947/ya-url-shortener/internal/service"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
 )
 
 // Shortener encapsulates the core components required for running the URL shortener service,
@@ -22,4 +30,19 @@ type Shortener struct {
 	Logger     *zap.Logger
 	repo       repository.Repository
 	service    service.ShortenService
+	api        service.Service
+
+	certManager *certs.Manager
+	gateway     *gateway.Gateway
+	gwServer    *grpc.Server // serves the calls of gateway, grpcServer unless it uses TLS
+
+	health                *health.Checker
+	grpcHealth            *grpchealth.Server
+	deletionWorkerRunning atomic.Bool
+
+	settings   *reload.Settings
+	redis      *redisstore.Client
+	loadConfig func() (*config.Config, error)
+
+	shutdownTracing func(context.Context) error
 }
@@ -0,0 +1,110 @@
+// Package tracing configures OpenTelemetry tracing for the URL shortener. It installs the global
+// tracer provider with the exporter selected by the configuration and the W3C trace-context
+// propagator, and provides the helpers used to trace the service, repository and pgx layers.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Supported values of the TRACING_EXPORTER setting.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// ServiceName is the service.name resource attribute of the exported spans.
+const ServiceName = "ya-url-shortener"
+
+const instrumentationName = "github.com/mp1947/ya-url-shortener"
+
+// ErrUnknownExporter is returned by Init when the configured exporter is not supported.
+var ErrUnknownExporter = errors.New("unknown tracing exporter")
+
+// Init installs the global tracer provider and propagator according to cfg and returns the function
+// flushing and stopping the provider on shutdown. With the "none" exporter (or a nil cfg) spans are
+// still created, so that trace IDs are propagated and logged, but they are not exported.
+func Init(ctx context.Context, cfg *config.Tracing, version string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporterName := ExporterNone
+	sampleRatio := 1.0
+	if cfg != nil {
+		exporterName = cfg.Exporter
+		sampleRatio = cfg.SampleRatio
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(version),
+		)),
+	}
+
+	switch exporterName {
+	case ExporterNone, "":
+	case ExporterStdout:
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case ExporterOTLP:
+		var clientOpts []otlptracegrpc.Option
+		if cfg.OTLPEndpoint != "" {
+			clientOpts = append(clientOpts, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint))
+		}
+		if cfg.OTLPInsecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExporter, exporterName)
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the application from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts a span named name as a child of the span in ctx.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,38 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/config"
+	"github.com/mp1947/ya-url-shortener/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestInit(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	t.Run("unknown exporter", func(t *testing.T) {
+		_, err := tracing.Init(context.Background(), &config.Tracing{Exporter: "zipkin"}, "test")
+		assert.ErrorIs(t, err, tracing.ErrUnknownExporter)
+	})
+
+	t.Run("spans are created without exporter", func(t *testing.T) {
+		shutdown, err := tracing.Init(context.Background(), &config.Tracing{Exporter: tracing.ExporterNone, SampleRatio: 1}, "test")
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, shutdown(context.Background())) }()
+
+		ctx, span := tracing.Start(context.Background(), "test")
+		defer tracing.End(span, errors.New("failed"))
+		require.True(t, span.SpanContext().IsValid())
+
+		carrier := propagation.MapCarrier{}
+		otel.GetTextMapPropagator().Inject(ctx, carrier)
+		assert.Contains(t, carrier.Get("traceparent"), span.SpanContext().TraceID().String())
+	})
+}
@@ -0,0 +1,49 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/repository/database"
+)
+
+// DatabaseSource returns the Source reading every link stored in db.
+func DatabaseSource(db *database.Database) Source {
+	return db.ListURLs
+}
+
+// DatabaseTarget is a Target inserting links into PostgreSQL, one statement per batch.
+type DatabaseTarget struct {
+	DB *database.Database
+}
+
+// Validate rejects links whose owner is not a UUID, which the database cannot store.
+func (t DatabaseTarget) Validate(u model.URL) error {
+	if u.UserID == "" {
+		return nil
+	}
+	if _, err := uuid.Parse(u.UserID); err != nil {
+		return fmt.Errorf("user id is not a uuid: %w", err)
+	}
+	return nil
+}
+
+// Lookup returns the stored links matching the given short or original URLs.
+func (t DatabaseTarget) Lookup(ctx context.Context, shortURLs, originalURLs []string) ([]model.URL, error) {
+	return t.DB.LookupURLs(ctx, shortURLs, originalURLs)
+}
+
+// Write inserts links. As links are looked up before, fewer inserted links mean that
+// the database was written concurrently, which is reported as an error.
+func (t DatabaseTarget) Write(ctx context.Context, links []model.URL) error {
+	inserted, err := t.DB.InsertURLs(ctx, links)
+	if err != nil {
+		return err
+	}
+	if inserted != int64(len(links)) {
+		return fmt.Errorf("inserted %d of %d links, the database is written by another process", inserted, len(links))
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package transfer
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/mp1947/ya-url-shortener/internal/eventlog"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+)
+
+// EventLogSource returns the Source reading the event log at path. Every short URL is read once,
+// with its latest owner and state, in the order of their latest event. The log is read twice rather
+// than loaded: the first pass finds the line of the latest event of every short URL, the second one
+// reads the links from these lines.
+func EventLogSource(path string) Source {
+	return func(ctx context.Context, fn func(model.URL) error) error {
+		latest := make(map[string]int)
+
+		err := eventlog.ScanFile(path, func(line int, e eventlog.Event) error {
+			latest[e.ShortURL] = line
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return eventlog.ScanFile(path, func(line int, e eventlog.Event) error {
+			if latest[e.ShortURL] != line {
+				return nil
+			}
+			return fn(eventURL(e))
+		})
+	}
+}
+
+// EventLogTarget is a Target appending links to an event log, which the in-memory storage restores.
+// The file is only created once links are written to it.
+type EventLogTarget struct {
+	path          string
+	ep            *eventlog.EventProcessor
+	events        int
+	byShortURL    map[string]model.URL
+	byOriginalURL map[string]model.URL
+}
+
+// OpenEventLog returns the EventLogTarget writing to the event log at path, which may
+// already hold links.
+func OpenEventLog(path string) (*EventLogTarget, error) {
+	t := &EventLogTarget{
+		path:          path,
+		byShortURL:    make(map[string]model.URL),
+		byOriginalURL: make(map[string]model.URL),
+	}
+
+	err := eventlog.ScanFile(path, func(line int, e eventlog.Event) error {
+		t.store(eventURL(e))
+		t.events = line
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Validate accepts every link.
+func (t *EventLogTarget) Validate(u model.URL) error {
+	return nil
+}
+
+// Lookup returns the links of the event log matching the given short or original URLs.
+func (t *EventLogTarget) Lookup(ctx context.Context, shortURLs, originalURLs []string) ([]model.URL, error) {
+	var result []model.URL
+
+	for _, shortURL := range shortURLs {
+		if u, ok := t.byShortURL[shortURL]; ok {
+			result = append(result, u)
+		}
+	}
+	for _, originalURL := range originalURLs {
+		if u, ok := t.byOriginalURL[originalURL]; ok {
+			result = append(result, u)
+		}
+	}
+
+	return result, nil
+}
+
+// Write appends a save event for every link, followed by a deletion event for deleted links,
+// which is how the in-memory storage records them.
+func (t *EventLogTarget) Write(ctx context.Context, links []model.URL) error {
+	if t.ep == nil {
+		ep, err := eventlog.Open(t.path)
+		if err != nil {
+			return err
+		}
+		t.ep = ep
+	}
+
+	for _, u := range links {
+		t.events++
+		event := eventlog.Event{
+			UUID:        strconv.Itoa(t.events),
+			ShortURL:    u.ShortURLID,
+			OriginalURL: u.OriginalURL,
+			UserID:      u.UserID,
+			IsDisabled:  u.IsDisabled,
+		}
+		if err := t.ep.WriteEvent(&event); err != nil {
+			return err
+		}
+
+		if u.IsDeleted {
+			event.IsDeleted = true
+			if err := t.ep.WriteEvent(&event); err != nil {
+				return err
+			}
+		}
+
+		t.store(u)
+	}
+
+	return t.ep.File.Sync()
+}
+
+// Close closes the event log.
+func (t *EventLogTarget) Close() error {
+	if t.ep == nil {
+		return nil
+	}
+	return t.ep.File.Close()
+}
+
+func (t *EventLogTarget) store(u model.URL) {
+	t.byShortURL[u.ShortURLID] = u
+	t.byOriginalURL[u.OriginalURL] = u
+}
+
+// eventURL returns the link described by an event.
+func eventURL(e eventlog.Event) model.URL {
+	return model.URL{
+		ShortURLID:  e.ShortURL,
+		OriginalURL: e.OriginalURL,
+		UserID:      e.UserID,
+		IsDeleted:   e.IsDeleted,
+		IsDisabled:  e.IsDisabled,
+	}
+}
@@ -0,0 +1,183 @@
+// Package transfer moves the links of a storage to another one while the service is stopped, such as
+// from the event log of the in-memory storage to PostgreSQL and back. Links are read with their owner,
+// deletion and disabled state and written in batches. Links already present in the target are skipped,
+// so a transfer is idempotent and an interrupted one resumes where it stopped when run again. Links
+// conflicting with the content of the target are reported rather than written.
+package transfer
+
+import (
+	"context"
+
+	"github.com/mp1947/ya-url-shortener/internal/model"
+)
+
+// DefaultBatchSize is the number of links written at once when Options.BatchSize is not set.
+const DefaultBatchSize = 500
+
+// Kinds of conflicts reported by Run.
+const (
+	// ConflictShortURL marks a link whose short URL is stored in the target with another
+	// original URL, owner or state.
+	ConflictShortURL = "short_url"
+	// ConflictOriginalURL marks a link whose original URL is stored in the target, or written
+	// by the transfer, under another short URL.
+	ConflictOriginalURL = "original_url"
+	// ConflictInvalid marks a link the target cannot store.
+	ConflictInvalid = "invalid"
+)
+
+// Source calls fn for every link of a storage, deleted ones included, and stops at the first error.
+type Source func(ctx context.Context, fn func(model.URL) error) error
+
+// Target is a storage links are transferred to.
+type Target interface {
+	// Validate returns an error if the target cannot store u.
+	Validate(u model.URL) error
+	// Lookup returns the stored links, deleted ones included, whose short URL is one of
+	// shortURLs or whose original URL is one of originalURLs.
+	Lookup(ctx context.Context, shortURLs, originalURLs []string) ([]model.URL, error)
+	// Write stores links which are not stored yet, along with their owner and state.
+	Write(ctx context.Context, links []model.URL) error
+}
+
+// Options configures a transfer. A dry run reads the source and the target and reports
+// what would be written, without writing anything.
+type Options struct {
+	BatchSize int
+	DryRun    bool
+}
+
+// Conflict describes a link of the source which is not written because of the content of the target.
+// Existing is the conflicting link of the target, Reason explains why an invalid link is rejected.
+type Conflict struct {
+	Kind     string
+	Link     model.URL
+	Existing model.URL
+	Reason   string
+}
+
+// Report describes the outcome of a transfer. Written counts the links written, or which would be
+// written by a dry run, Present the links which were already stored in the target.
+type Report struct {
+	Read      int
+	Written   int
+	Present   int
+	Conflicts []Conflict
+}
+
+// Run transfers every link of src to dst in batches, as described by the package, and returns the
+// report of the transfer. On error, the report describes the batches written before the error.
+func Run(ctx context.Context, src Source, dst Target, opts Options) (Report, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+
+	t := &transfer{
+		dst:      dst,
+		opts:     opts,
+		accepted: make(map[string]model.URL),
+	}
+
+	err := src(ctx, func(u model.URL) error {
+		t.report.Read++
+		t.batch = append(t.batch, u)
+		if len(t.batch) < opts.BatchSize {
+			return nil
+		}
+		return t.flush(ctx)
+	})
+	if err != nil {
+		return t.report, err
+	}
+
+	return t.report, t.flush(ctx)
+}
+
+// transfer holds the state of a running transfer.
+type transfer struct {
+	dst    Target
+	opts   Options
+	report Report
+	batch  []model.URL
+	// accepted holds the links written by the transfer by original URL.
+	accepted map[string]model.URL
+}
+
+// flush classifies the links of the current batch against the target and writes the new ones.
+func (t *transfer) flush(ctx context.Context) error {
+	if len(t.batch) == 0 {
+		return nil
+	}
+
+	batch := t.batch
+	t.batch = nil
+
+	shortURLs := make([]string, len(batch))
+	originalURLs := make([]string, len(batch))
+	for i, u := range batch {
+		shortURLs[i] = u.ShortURLID
+		originalURLs[i] = u.OriginalURL
+	}
+
+	existing, err := t.dst.Lookup(ctx, shortURLs, originalURLs)
+	if err != nil {
+		return err
+	}
+
+	byShortURL := make(map[string]model.URL, len(existing))
+	byOriginalURL := make(map[string]model.URL, len(existing))
+	for _, e := range existing {
+		byShortURL[e.ShortURLID] = e
+		byOriginalURL[e.OriginalURL] = e
+	}
+
+	var links []model.URL
+
+	for _, u := range batch {
+		if err := t.dst.Validate(u); err != nil {
+			t.conflict(ConflictInvalid, u, model.URL{}, err.Error())
+			continue
+		}
+
+		if e, ok := byShortURL[u.ShortURLID]; ok {
+			if e == u {
+				t.report.Present++
+			} else {
+				t.conflict(ConflictShortURL, u, e, "")
+			}
+			continue
+		}
+
+		if e, ok := byOriginalURL[u.OriginalURL]; ok {
+			t.conflict(ConflictOriginalURL, u, e, "")
+			continue
+		}
+		if e, ok := t.accepted[u.OriginalURL]; ok {
+			t.conflict(ConflictOriginalURL, u, e, "")
+			continue
+		}
+
+		t.accepted[u.OriginalURL] = u
+		links = append(links, u)
+	}
+
+	if len(links) > 0 && !t.opts.DryRun {
+		if err := t.dst.Write(ctx, links); err != nil {
+			return err
+		}
+	}
+
+	t.report.Written += len(links)
+
+	return nil
+}
+
+// conflict records a conflict in the report.
+func (t *transfer) conflict(kind string, link, existing model.URL, reason string) {
+	t.report.Conflicts = append(t.report.Conflicts, Conflict{
+		Kind:     kind,
+		Link:     link,
+		Existing: existing,
+		Reason:   reason,
+	})
+}
@@ -0,0 +1,153 @@
+package transfer_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/mp1947/ya-url-shortener/internal/eventlog"
+	"github.com/mp1947/ya-url-shortener/internal/model"
+	"github.com/mp1947/ya-url-shortener/internal/transfer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sourceLinks = []model.URL{
+	{ShortURLID: "aaa", OriginalURL: "https://a.example.com", UserID: "user-1"},
+	{ShortURLID: "bbb", OriginalURL: "https://b.example.com", UserID: "user-1", IsDisabled: true},
+	{ShortURLID: "ccc", OriginalURL: "https://c.example.com", UserID: "user-2", IsDeleted: true},
+}
+
+// sliceSource returns the Source reading links.
+func sliceSource(links []model.URL) transfer.Source {
+	return func(ctx context.Context, fn func(model.URL) error) error {
+		for _, u := range links {
+			if err := fn(u); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// readLinks returns the links of the event log at path.
+func readLinks(t *testing.T, path string) []model.URL {
+	t.Helper()
+
+	var links []model.URL
+	err := transfer.EventLogSource(path)(context.Background(), func(u model.URL) error {
+		links = append(links, u)
+		return nil
+	})
+	require.NoError(t, err)
+	return links
+}
+
+func TestRunEventLog(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "target.out")
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		target, err := transfer.OpenEventLog(path)
+		require.NoError(t, err)
+		defer target.Close()
+
+		report, err := transfer.Run(ctx, sliceSource(sourceLinks), target, transfer.Options{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, transfer.Report{Read: 3, Written: 3}, report)
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("links are written with their state", func(t *testing.T) {
+		target, err := transfer.OpenEventLog(path)
+		require.NoError(t, err)
+		defer target.Close()
+
+		report, err := transfer.Run(ctx, sliceSource(sourceLinks), target, transfer.Options{BatchSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, transfer.Report{Read: 3, Written: 3}, report)
+		assert.Equal(t, sourceLinks, readLinks(t, path))
+	})
+
+	t.Run("rerun skips present links and reports conflicts", func(t *testing.T) {
+		target, err := transfer.OpenEventLog(path)
+		require.NoError(t, err)
+		defer target.Close()
+
+		links := append([]model.URL{
+			{ShortURLID: "aaa", OriginalURL: "https://other.example.com"},
+			{ShortURLID: "ddd", OriginalURL: "https://b.example.com"},
+			{ShortURLID: "eee", OriginalURL: "https://e.example.com"},
+			{ShortURLID: "fff", OriginalURL: "https://e.example.com"},
+		}, sourceLinks...)
+
+		report, err := transfer.Run(ctx, sliceSource(links), target, transfer.Options{BatchSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, transfer.Report{
+			Read:    7,
+			Written: 1,
+			Present: 3,
+			Conflicts: []transfer.Conflict{
+				{Kind: transfer.ConflictShortURL, Link: links[0], Existing: sourceLinks[0]},
+				{Kind: transfer.ConflictOriginalURL, Link: links[1], Existing: sourceLinks[1]},
+				{Kind: transfer.ConflictOriginalURL, Link: links[3], Existing: links[2]},
+			},
+		}, report)
+	})
+}
+
+// rejectingTarget is a Target rejecting links without owner and recording the written batches.
+type rejectingTarget struct {
+	batches [][]model.URL
+}
+
+func (t *rejectingTarget) Validate(u model.URL) error {
+	if u.UserID == "" {
+		return errors.New("no owner")
+	}
+	return nil
+}
+
+func (t *rejectingTarget) Lookup(ctx context.Context, shortURLs, originalURLs []string) ([]model.URL, error) {
+	return nil, nil
+}
+
+func (t *rejectingTarget) Write(ctx context.Context, links []model.URL) error {
+	t.batches = append(t.batches, links)
+	return nil
+}
+
+func TestRunInvalid(t *testing.T) {
+	target := &rejectingTarget{}
+	links := append([]model.URL{{ShortURLID: "zzz", OriginalURL: "https://z.example.com"}}, sourceLinks...)
+
+	report, err := transfer.Run(context.Background(), sliceSource(links), target, transfer.Options{BatchSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, transfer.Report{
+		Read:    4,
+		Written: 3,
+		Conflicts: []transfer.Conflict{
+			{Kind: transfer.ConflictInvalid, Link: links[0], Reason: "no owner"},
+		},
+	}, report)
+	assert.Equal(t, [][]model.URL{sourceLinks[:1], sourceLinks[1:]}, target.batches)
+}
+
+func TestEventLogSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "source.out")
+
+	ep, err := eventlog.Open(path)
+	require.NoError(t, err)
+	require.NoError(t, ep.WriteEvent(&eventlog.Event{UUID: "1", ShortURL: "aaa", OriginalURL: "https://a.example.com", UserID: "user-1"}))
+	require.NoError(t, ep.WriteEvent(&eventlog.Event{UUID: "2", ShortURL: "bbb", OriginalURL: "https://b.example.com", UserID: "user-2"}))
+	require.NoError(t, ep.WriteEvent(&eventlog.Event{UUID: "1", ShortURL: "aaa", OriginalURL: "https://a.example.com", UserID: "user-1", IsDeleted: true}))
+	require.NoError(t, ep.File.Close())
+
+	assert.Equal(t, []model.URL{
+		{ShortURLID: "bbb", OriginalURL: "https://b.example.com", UserID: "user-2"},
+		{ShortURLID: "aaa", OriginalURL: "https://a.example.com", UserID: "user-1", IsDeleted: true},
+	}, readLinks(t, path))
+
+	assert.Empty(t, readLinks(t, filepath.Join(t.TempDir(), "missing.out")))
+}
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_log (
+  id BIGSERIAL PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL,
+  actor_id TEXT NOT NULL,
+  auth_method TEXT NOT NULL,
+  client_ip TEXT NOT NULL DEFAULT '',
+  operation TEXT NOT NULL,
+  short_urls TEXT[] NOT NULL DEFAULT '{}',
+  target_user_id TEXT NOT NULL DEFAULT '',
+  result TEXT NOT NULL,
+  error TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX IF NOT EXISTS audit_log_actor_id_idx ON audit_log (actor_id);
+CREATE INDEX IF NOT EXISTS audit_log_target_user_id_idx ON audit_log (target_user_id);
+CREATE INDEX IF NOT EXISTS audit_log_short_urls_idx ON audit_log USING GIN (short_urls);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_log;
+-- +goose StatementEnd
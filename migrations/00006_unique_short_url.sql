@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Short URLs identify the links, a short URL stored twice would redirect to either original URL.
+-- Duplicated short URLs keep their first stored row: the later ones never redirected reliably and are removed
+-- before the index is built, list them first with
+-- SELECT short_url, count(*) FROM urls GROUP BY short_url HAVING count(*) > 1;
+DELETE FROM urls AS u
+USING urls AS first
+WHERE u.short_url = first.short_url AND u.uuid > first.uuid;
+CREATE UNIQUE INDEX IF NOT EXISTS short_url ON urls (short_url);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS short_url;
+-- +goose StatementEnd
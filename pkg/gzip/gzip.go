@@ -20,12 +20,38 @@ type GzipWriter struct {
 // Write writes the provided byte slice to the underlying gzip writer.
 // It returns the number of bytes written and any error encountered during the write operation.
 func (gzw *GzipWriter) Write(p []byte) (int, error) {
+	gzw.dropContentLength()
 	return gzw.Writer.Write(p)
 }
 
+// WriteString writes the provided string to the underlying gzip writer, so that string writes
+// are compressed like the ones made through Write.
+func (gzw *GzipWriter) WriteString(s string) (int, error) {
+	gzw.dropContentLength()
+	return io.WriteString(gzw.Writer, s)
+}
+
 // WriteHeader sets the HTTP status code for the response and writes it to the underlying ResponseWriter.
 // It also stores the status code in the gzipWriter for later reference.
 func (gzw *GzipWriter) WriteHeader(statusCode int) {
+	gzw.dropContentLength()
 	gzw.statusCode = statusCode
 	gzw.ResponseWriter.WriteHeader(gzw.statusCode)
 }
+
+// dropContentLength removes the Content-Length header set by handlers for the uncompressed body
+// (as the gRPC gateway does), which would cut the compressed one.
+func (gzw *GzipWriter) dropContentLength() {
+	if gzw.ResponseWriter != nil {
+		gzw.Header().Del("Content-Length")
+	}
+}
+
+// Flush writes any buffered compressed data to the underlying ResponseWriter and flushes it,
+// so that streamed responses reach the client as they are produced.
+func (gzw *GzipWriter) Flush() {
+	if f, ok := gzw.Writer.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	gzw.ResponseWriter.Flush()
+}
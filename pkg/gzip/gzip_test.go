@@ -37,3 +37,30 @@ func TestWriteHeader(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, gzw.Status())
 
 }
+
+func TestWriteString(t *testing.T) {
+	var buf bytes.Buffer
+	gzw := gzip.GzipWriter{
+		Writer: &buf,
+	}
+	n, err := gzw.WriteString("test payload")
+	assert.NoError(t, err)
+	assert.Equal(t, len("test payload"), n)
+	assert.Equal(t, "test payload", buf.String())
+}
+
+func TestContentLengthDropped(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+
+	var buf bytes.Buffer
+	gzw := &gzip.GzipWriter{
+		ResponseWriter: c.Writer,
+		Writer:         &buf,
+	}
+	gzw.Header().Set("Content-Length", "12")
+
+	_, err := gzw.Write([]byte("test payload"))
+	assert.NoError(t, err)
+	assert.Empty(t, gzw.Header().Get("Content-Length"), "the length of the uncompressed body is dropped")
+}
@@ -5,18 +5,27 @@ package middleware
 import (
 	"compress/gzip"
 	"io"
-	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mp1947/ya-url-shortener/internal/clientip"
+	shrterr "github.com/mp1947/ya-url-shortener/internal/errors"
+	"github.com/mp1947/ya-url-shortener/internal/logger"
+	"github.com/mp1947/ya-url-shortener/internal/metrics"
+	"github.com/mp1947/ya-url-shortener/internal/problem"
 	gz "github.com/mp1947/ya-url-shortener/pkg/gzip"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 // LoggerMiddleware returns a Gin middleware handler that logs details about each HTTP request.
-// It logs the request URI, HTTP method, processing duration, response status code, and response body size
-// using the provided zap.Logger instance. The middleware should be attached to a Gin router to enable
+// It logs the request URI, HTTP method, client IP, processing duration, response status code, and response body size,
+// along with the last error attached to the context, such as the unexpected error reported by problem.Write,
+// and the trace and span IDs when the request is traced,
+// using the request-scoped logger of the request context, which carries the request and user IDs,
+// or the provided zap.Logger instance when the request has none. The middleware should be attached to a Gin router to enable
 // structured logging of incoming requests and their corresponding responses.
 func LoggerMiddleware(log *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -26,18 +35,53 @@ func LoggerMiddleware(log *zap.Logger) gin.HandlerFunc {
 
 		c.Next()
 
+		clientIP := clientip.StringFromContext(c.Request.Context())
 		duration := time.Since(t)
 		status := c.Writer.Status()
 		bodySize := c.Writer.Size()
 
-		log.Info(
-			"request processed",
+		fields := []zap.Field{
 			zap.String("request_uri", requestURI),
 			zap.String("request_method", requestMethod),
+			zap.String("client_ip", clientIP),
 			zap.Any("request_duration", duration),
 			zap.Int("response_status_code", status),
 			zap.Int("response_body_size", bodySize),
-		)
+		}
+
+		if err := c.Errors.Last(); err != nil {
+			fields = append(fields, zap.Error(err.Err))
+		}
+
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+			fields = append(fields,
+				zap.String("trace_id", sc.TraceID().String()),
+				zap.String("span_id", sc.SpanID().String()),
+			)
+		}
+
+		logger.FromContext(c.Request.Context(), log).Info("request processed", fields...)
+	}
+}
+
+// MetricsMiddleware returns a Gin middleware handler that counts HTTP requests and observes their latency
+// in the metrics.HTTPRequestsTotal and metrics.HTTPRequestDuration metrics. Requests are labelled by method,
+// route template (so that path parameters do not create new series) and response status code.
+// Requests matching no route are labelled with the "unmatched" route.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		t := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(t).Seconds())
 	}
 }
 
@@ -57,9 +101,7 @@ func GzipMiddleware() gin.HandlerFunc {
 		if isRequestEncoded {
 			reader, err := gzip.NewReader(c.Request.Body)
 			if err != nil {
-				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-					"error": "invalid gzip data",
-				})
+				problem.Write(c, shrterr.InvalidInput("Content-Encoding", "invalid gzip data"))
 				return
 			}
 			defer func() {